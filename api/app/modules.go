@@ -2,12 +2,23 @@ package app
 
 import (
 	"go.uber.org/fx"
+	"microservice/internal/modules/blocklist"
 	"microservice/internal/modules/credit"
+	"microservice/internal/modules/export"
 	"microservice/internal/modules/health"
+	"microservice/internal/modules/link"
 	"microservice/internal/modules/message"
+	"microservice/internal/modules/otp"
 	"microservice/internal/modules/outbox"
+	"microservice/internal/modules/policy"
+	"microservice/internal/modules/pricing"
+	"microservice/internal/modules/ratecard"
+	"microservice/internal/modules/report"
+	"microservice/internal/modules/sender"
+	"microservice/internal/modules/template"
 	"microservice/internal/modules/tenant"
 	"microservice/internal/modules/transaction"
+	"microservice/internal/modules/webhook"
 )
 
 type Modules []fx.Option
@@ -20,6 +31,17 @@ func (a *App) InitModules() {
 		fx.Module("transaction", fx.Provide(transaction.NewRepositoryFx)),
 		fx.Module("message", fx.Provide(message.NewRepositoryFx, message.NewUsecaseFx, message.NewHttpHandlerFx)),
 		fx.Module("outbox", fx.Provide(outbox.NewRepositoryFx)),
+		fx.Module("template", fx.Provide(template.NewRepositoryFx, template.NewUsecaseFx, template.NewHttpHandlerFx)),
+		fx.Module("webhook", fx.Provide(webhook.NewRepositoryFx)),
+		fx.Module("otp", fx.Provide(otp.NewUsecaseFx, otp.NewHttpHandlerFx)),
+		fx.Module("blocklist", fx.Provide(blocklist.NewRepositoryFx, blocklist.NewUsecaseFx, blocklist.NewHttpHandlerFx)),
+		fx.Module("sender", fx.Provide(sender.NewRepositoryFx, sender.NewUsecaseFx, sender.NewHttpHandlerFx)),
+		fx.Module("export", fx.Provide(export.NewRepositoryFx, export.NewUsecaseFx, export.NewHttpHandlerFx)),
+		fx.Module("pricing", fx.Provide(pricing.NewRepositoryFx, pricing.NewUsecaseFx, pricing.NewHttpHandlerFx)),
+		fx.Module("policy", fx.Provide(policy.NewRepositoryFx, policy.NewUsecaseFx, policy.NewHttpHandlerFx)),
+		fx.Module("link", fx.Provide(link.NewRepositoryFx, link.NewUsecaseFx, link.NewHttpHandlerFx)),
+		fx.Module("ratecard", fx.Provide(ratecard.NewRepositoryFx)),
+		fx.Module("report", fx.Provide(report.NewRepositoryFx, report.NewUsecaseFx, report.NewHttpHandlerFx)),
 	})
 
 	a.Span().AddEvent("fx-modules initialized")
@@ -0,0 +1,5 @@
+package config
+
+type Admin struct {
+	Token string `mapstructure:"ADMIN_TOKEN"` // the shared token of the admin endpoints, the admin endpoints are closed if empty
+}
@@ -0,0 +1,9 @@
+package config
+
+type Export struct {
+	// Dir the directory of the background export files, the os temp dir by default.
+	// the jobs run in the process of the instance which created them, so a single instance serves the exports,
+	// the unfinished jobs are failed on the startup
+	Dir string `mapstructure:"EXPORT_DIR"`
+	Ttl int    `mapstructure:"EXPORT_FILE_TTL_HOURS"` // the export files are removed after the ttl, 24 hours by default
+}
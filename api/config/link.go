@@ -0,0 +1,5 @@
+package config
+
+type Link struct {
+	BaseUrl string `mapstructure:"SHORT_LINK_BASE_URL"` // the public url of the redirect endpoint the codes are appended to, required
+}
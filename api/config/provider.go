@@ -4,4 +4,9 @@ package config
 
 type (
 	HttpProvider struct{}
+
+	// ProviderCallback the shared secret of the provider callbacks, the delivery reports and the inbound messages
+	ProviderCallback struct {
+		Secret string `mapstructure:"PROVIDER_CALLBACK_SECRET"` // the callbacks are refused if empty
+	}
 )
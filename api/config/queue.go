@@ -3,7 +3,11 @@ package config
 type Queue struct {
 	Host            string   `mapstructure:"QUEUE_HOST"`
 	Topics          []string `mapstructure:"QUEUE_TOPICS"`
+	Channels        []string `mapstructure:"QUEUE_CHANNELS"` // name:topic:partitions:priority:priceMultiplier:concurrency
 	RetryDelay      int      `mapstructure:"QUEUE_RETRY_DELAY_SEC"`
 	ConsumerReadTtl int      `mapstructure:"QUEUE_CONSUMER_READ_TTL_MS"`
 	FlushTtl        int      `mapstructure:"QUEUE_PRODUCER_FLUSH_TTL_MS"`
+	ScheduleTick    int      `mapstructure:"QUEUE_SCHEDULER_TICK_SEC"`
+	WebhookAttempts int      `mapstructure:"QUEUE_WEBHOOK_MAX_ATTEMPTS"`
+	WebhookTimeout  int      `mapstructure:"QUEUE_WEBHOOK_TIMEOUT_SEC"`
 }
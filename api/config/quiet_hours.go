@@ -0,0 +1,6 @@
+package config
+
+type QuietHours struct {
+	Start string `mapstructure:"QUIET_HOURS_START"` // HH:MM in the service timezone, 22:00 by default
+	End   string `mapstructure:"QUIET_HOURS_END"`   // HH:MM in the service timezone, 08:00 by default
+}
@@ -1,6 +1,7 @@
 package orm
 
 import (
+	"context"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
@@ -40,5 +41,11 @@ type (
 		Resolve(err error) error
 		// Tx returns the current transaction or the base db if no transaction is active.
 		Tx() gorm.DB
+		// Transaction runs the func in a transaction of its own, carried to the repositories by the context.
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+		// Detach the repositories called by the context use the base db, not the transaction begun by another caller.
+		Detach(ctx context.Context) context.Context
+		// TxOf returns the transaction carried by the context, otherwise the same as Tx.
+		TxOf(ctx context.Context) gorm.DB
 	}
 )
@@ -1,9 +1,13 @@
 package orm
 
 import (
+	"context"
 	"gorm.io/gorm"
 )
 
+// txCtxKey the context key of the transaction of a single caller
+type txCtxKey struct{}
+
 type transactional struct {
 	db *gorm.DB
 	tx *gorm.DB
@@ -56,3 +60,25 @@ func (u *transactional) Tx() gorm.DB {
 	}
 	return *u.db
 }
+
+// Transaction runs the func in a transaction of its own, unlike Begin it is safe for the concurrent callers
+// like the queue consumers. the func is rolled back on its error
+func (u *transactional) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txCtxKey{}, tx))
+	})
+}
+
+// Detach the background callers do not join the transaction begun by a request
+func (u *transactional) Detach(ctx context.Context) context.Context {
+	return context.WithValue(ctx, txCtxKey{}, u.db)
+}
+
+// TxOf returns the transaction carried by the context, otherwise the current transaction or the base db.
+func (u *transactional) TxOf(ctx context.Context) gorm.DB {
+	if tx, ok := ctx.Value(txCtxKey{}).(*gorm.DB); ok && tx != nil {
+		return *tx
+	}
+
+	return u.Tx()
+}
@@ -1,6 +1,9 @@
 package sms
 
 //go:generate mockgen -source=./contract.go -destination=./sms_mock.go -package=sms
+
+// ISmsProvider the successful Send result carries the provider message id under the `messageId` key.
+// the empty `from` sends the message through the provider default line
 type ISmsProvider interface {
-	Send(phone string, msg string) (map[string]interface{}, error)
+	Send(from string, phone string, msg string) (map[string]interface{}, error)
 }
@@ -2,6 +2,7 @@ package sms
 
 import (
 	"errors"
+	"github.com/google/uuid"
 	"go.uber.org/mock/gomock"
 	"math/rand"
 	"microservice/pkg/mock"
@@ -18,8 +19,8 @@ func initMock() *MockISmsProvider {
 	defer ctrl.Finish()
 
 	mocked := NewMockISmsProvider(ctrl)
-	mocked.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(phone string, message string) (map[string]interface{}, error) {
-		success := map[string]interface{}{"status": 200, "result": "sent"}
+	mocked.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(from string, phone string, message string) (map[string]interface{}, error) {
+		success := map[string]interface{}{"status": 200, "result": "sent", "messageId": uuid.NewString()}
 		failed := map[string]interface{}{"status": 400, "result": "failed"}
 
 		if rand.Intn(10) == 1 {
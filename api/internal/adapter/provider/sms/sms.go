@@ -6,7 +6,7 @@ type Provider struct {
 	AuthToken string
 }
 
-func (s *Provider) Send(phone string, msg string) (map[string]interface{}, error) {
+func (s *Provider) Send(from string, phone string, msg string) (map[string]interface{}, error) {
 	//TODO implement me
 	panic("implement me")
 }
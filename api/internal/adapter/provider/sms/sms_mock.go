@@ -40,16 +40,16 @@ func (m *MockISmsProvider) EXPECT() *MockISmsProviderMockRecorder {
 }
 
 // Send mocks base method.
-func (m *MockISmsProvider) Send(phone, msg string) (map[string]any, error) {
+func (m *MockISmsProvider) Send(from, phone, msg string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Send", phone, msg)
+	ret := m.ctrl.Call(m, "Send", from, phone, msg)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Send indicates an expected call of Send.
-func (mr *MockISmsProviderMockRecorder) Send(phone, msg any) *gomock.Call {
+func (mr *MockISmsProviderMockRecorder) Send(from, phone, msg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockISmsProvider)(nil).Send), phone, msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockISmsProvider)(nil).Send), from, phone, msg)
 }
@@ -3,48 +3,73 @@ package queue
 import (
 	"fmt"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"microservice/internal/domain"
 )
 
-func kafkaTopicsConfigs() []kafka.TopicSpecification {
-	return []kafka.TopicSpecification{
+func kafkaTopicsConfigs(channels *domain.Channels) []kafka.TopicSpecification {
+	specs := make([]kafka.TopicSpecification, 0)
+
+	// the channel topics, the partitions from the channel configuration
+	for _, ch := range channels.List() {
+		specs = append(specs, kafka.TopicSpecification{
+			Topic:             ch.Topic(),
+			NumPartitions:     ch.Partitions(),
+			ReplicationFactor: 1,
+			Config: map[string]string{
+				"retention.bytes":   "53687091200", // 50GB
+				"segment.bytes":     "536870912",   // 512MB
+				"max.message.bytes": "1000000",     // 1MB
+			},
+		})
+	}
+
+	return append(specs, []kafka.TopicSpecification{
 		{
-			Topic:             ProdTopic,
-			NumPartitions:     50,
+			Topic:             RetryTopic,
+			NumPartitions:     20,
 			ReplicationFactor: 1,
 			Config: map[string]string{
-				"retention.bytes":   "107374182400", // ~100GB
-				"segment.bytes":     "536870912",    // 512MB
-				"max.message.bytes": "1000000",      // 1MB
+				"retention.ms":  "86400000",  // 24h
+				"segment.bytes": "536870912", // 512MB
 			},
 		},
 		{
-			Topic:             ExpressTopic,
-			NumPartitions:     30,
+			Topic:             DlqTopic,
+			NumPartitions:     10,
 			ReplicationFactor: 1,
 			Config: map[string]string{
-				"retention.bytes": "53687091200", // 50GB
-				"segment.bytes":   "536870912",   // 512MB
+				"retention.ms":  "2592000000", // 30d
+				"segment.bytes": "536870912",  // 512MB
 			},
 		},
 		{
-			Topic:             RetryTopic,
-			NumPartitions:     20,
+			Topic:             WebhookTopic,
+			NumPartitions:     10,
 			ReplicationFactor: 1,
 			Config: map[string]string{
-				"retention.ms":  "86400000",  // 24h
+				"retention.ms":  "259200000", // 3d
 				"segment.bytes": "536870912", // 512MB
 			},
 		},
 		{
-			Topic:             DlqTopic,
+			Topic:             InboundTopic,
 			NumPartitions:     10,
 			ReplicationFactor: 1,
 			Config: map[string]string{
-				"retention.ms":  "2592000000", // 30d
-				"segment.bytes": "536870912",  // 512MB
+				"retention.ms":  "604800000", // 7d
+				"segment.bytes": "536870912", // 512MB
 			},
 		},
-	}
+		{
+			Topic:             UsageTopic,
+			NumPartitions:     10,
+			ReplicationFactor: 1,
+			Config: map[string]string{
+				"retention.ms":  "259200000", // 3d
+				"segment.bytes": "536870912", // 512MB
+			},
+		},
+	}...)
 }
 
 func kafkaProducerConfig(q *queue) *kafka.ConfigMap {
@@ -1,8 +1,9 @@
 package queue
 
 const (
-	ProdTopic    string = "event.prod"
-	ExpressTopic string = "event.express"
 	RetryTopic   string = "retry"
 	DlqTopic     string = "dlq"
+	WebhookTopic string = "webhook"
+	InboundTopic string = "inbound"
+	UsageTopic   string = "usage"
 )
@@ -3,21 +3,24 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"microservice/internal/domain"
 	"microservice/pkg/utils"
 	"time"
 )
 
-func (q *queue) productionTopicConsumer(ctx context.Context, handler chan struct{}) {
-	c := q.consumers[ProdTopic]
+// channelTopicConsumer consumes the topic of the channel, each of the channel consumers runs its own loop in the
+// channel consumer group
+func (q *queue) channelTopicConsumer(ctx context.Context, handler chan struct{}, ch domain.Channel, c *kafka.Consumer) {
+	if err := c.Subscribe(ch.Topic(), nil); err != nil {
+		q.lgr.Error("queue.consumer.subscribe.channel", zap.String("channel", ch.Name()), zap.Error(err))
 
-	if err := c.Subscribe(ProdTopic, nil); err != nil {
-		q.lgr.Error("queue.consumer.subscribe.prod", zap.Error(err))
-
-		utils.PrintStd(utils.StdPanic, "queue.consumer.subscribe.prod: %s", err.Error())
+		utils.PrintStd(utils.StdPanic, "queue.consumer.subscribe.channel: %s", err.Error())
 
 		// todo: set alert with prometheus
 		// todo: handle the db try counter and send to retry
@@ -27,75 +30,31 @@ func (q *queue) productionTopicConsumer(ctx context.Context, handler chan struct
 		select {
 		case <-handler:
 			if err := c.Close(); err != nil {
-				q.lgr.Error("queue.consumer.close", zap.String("topic", ProdTopic), zap.Error(err))
+				q.lgr.Error("queue.consumer.close", zap.String("topic", ch.Topic()), zap.Error(err))
 				return
 			}
 
-			q.lgr.Info("queue.consumer.close", zap.String("topic", ProdTopic))
+			q.lgr.Info("queue.consumer.close", zap.String("topic", ch.Topic()))
 			return
 		default:
 			msg, err := c.ReadMessage(time.Duration(q.config.ConsumerReadTtl))
 			if err != nil {
 				if kafkaErr, ok := err.(kafka.Error); ok == true && kafkaErr.Code() != kafka.ErrTimedOut {
-					q.lgr.Error("queue.consumer.prod.read", zap.Error(err))
+					q.lgr.Error("queue.consumer.channel.read", zap.String("channel", ch.Name()), zap.Error(err))
 					// todo: set alert with prometheus
 					break
 				}
 			}
 
 			if msg != nil {
-				err = q.consumeAndSendMessage(ctx, ProdTopic, msg)
-				if err = q.Produce(ctx, RetryTopic, string(msg.Key), msg.Value); err != nil {
-					//todo: set grafana/prometheus alarm
-					q.lgr.Error("queue.consumer.provider.reproduce",
-						zap.String("topic", ProdTopic),
-						zap.String("message.id", string(msg.Key)),
-						zap.Error(err),
-					)
-				}
-			}
-		}
-	}
-}
-
-func (q *queue) expressTopicConsumer(ctx context.Context, handler chan struct{}) {
-	c := q.consumers[ExpressTopic]
-
-	if err := c.Subscribe(ExpressTopic, nil); err != nil {
-		q.lgr.Error("queue.consumer.subscribe.express", zap.Error(err))
-
-		utils.PrintStd(utils.StdPanic, "queue.consumer.subscribe.express: %s", err.Error())
-
-		// todo: set alert with prometheus
-		// todo: handle the db try counter and send to retry
-	}
-
-	for {
-		select {
-		case <-handler:
-			if err := c.Close(); err != nil {
-				q.lgr.Error("queue.consumer.close", zap.String("topic", ExpressTopic), zap.Error(err))
-				return
-			}
-
-			q.lgr.Info("queue.consumer.close", zap.String("topic", ExpressTopic))
-			return
-		default:
-			msg, err := c.ReadMessage(time.Duration(q.config.ConsumerReadTtl))
-			if err != nil {
-				if kafkaErr, ok := err.(kafka.Error); ok == true && kafkaErr.Code() != kafka.ErrTimedOut {
-					q.lgr.Error("queue.consumer.prod.read", zap.Error(err))
-					// todo: set alert with prometheus
+				if err = q.consumeAndSendMessage(ctx, ch.Topic(), msg); err == nil {
 					break
 				}
-			}
 
-			if msg != nil {
-				err = q.consumeAndSendMessage(ctx, ExpressTopic, msg)
 				if err = q.Produce(ctx, RetryTopic, string(msg.Key), msg.Value); err != nil {
 					//todo: set grafana/prometheus alarm
 					q.lgr.Error("queue.consumer.provider.reproduce",
-						zap.String("topic", ExpressTopic),
+						zap.String("topic", ch.Topic()),
 						zap.String("message.id", string(msg.Key)),
 						zap.Error(err),
 					)
@@ -222,10 +181,10 @@ func (q *queue) dlqTopicConsumer(ctx context.Context, handler chan struct{}) {
 					break
 				}
 
-				err = q.updateStatus(ctx, value.MessageId, value.OutboxId, domain.MsgFailed, domain.OutboxFailed)
-				if err != nil {
+				if err = q.failAndRefund(ctx, value); err != nil {
+					//todo: set grafana/prometheus alarm
 					q.lgr.Error("queue.consumer.db.status", zap.String("topic", DlqTopic), zap.Error(err))
-					return
+					break
 				}
 			}
 		}
@@ -253,7 +212,22 @@ func (q *queue) consumeAndSendMessage(ctx context.Context, t string, msg *kafka.
 		attribute.String("message.mobile", value.Mobile),
 	))
 
-	err = q.updateStatus(ctx, value.MessageId, value.OutboxId, domain.MsgSending, domain.OutboxPublishing)
+	// the message which waited in the queue beyond its validity is worthless, it is expired without the provider call
+	if value.IsExpired(time.Now()) {
+		if err = q.expireAndRefund(ctx, value); err != nil {
+			q.lgr.Error("queue.consumer.db.expire",
+				zap.String("topic", t),
+				zap.String("message.id", string(msg.Key)),
+				zap.Error(err),
+			)
+			return
+		}
+
+		sp.AddEvent("db.status.expired")
+		return
+	}
+
+	err = q.updateStatus(ctx, value, domain.MsgSending, domain.OutboxPublishing)
 	if err != nil {
 		if err = q.Produce(ctx, RetryTopic, string(msg.Key), msg.Value); err != nil {
 			//todo: set grafana/prometheus alarm
@@ -269,7 +243,8 @@ func (q *queue) consumeAndSendMessage(ctx context.Context, t string, msg *kafka.
 
 	sp.AddEvent("db.status.sending")
 
-	if _, err = q.sms.Send(value.Mobile, value.MessageText); err != nil {
+	result, err := q.sms.Send(value.From, value.Mobile, value.MessageText)
+	if err != nil {
 		q.lgr.Error("queue.consumer.provider.send",
 			zap.String("trace.id", sp.SpanContext().TraceID().String()),
 			zap.String("topic", t),
@@ -283,35 +258,168 @@ func (q *queue) consumeAndSendMessage(ctx context.Context, t string, msg *kafka.
 
 	sp.AddEvent("provider.sent")
 
-	err = q.updateStatus(ctx, value.MessageId, value.OutboxId, domain.MsgSent, domain.OutboxPublished)
+	// the sent status and the provider message id are stored at once, the provider message id matches the later
+	// delivery reports (DLR) to the message. the accepted message is not sent again on the db failure
+	providerId, _ := result["messageId"].(string)
+	if dbErr := q.markSent(ctx, value, providerId); dbErr != nil {
+		//todo: prometheus/grafana alarm for sent but not reportable message
+		q.lgr.Error("queue.consumer.db.sent",
+			zap.String("topic", t),
+			zap.Uint("message.id", value.MessageId),
+			zap.Error(dbErr),
+		)
+	}
+
 	sp.AddEvent("db.status.sent")
 
 	return
 
 }
 
-func (q *queue) updateStatus(ctx context.Context, msgId, outboxId uint, msgSt domain.MessageStatus, outboxSt domain.OutboxStatus) (err error) {
-	db := q.sql.Tx()
-	tx := db.Begin()
+// markSent the message finalized by a delivery report arriving before the sent status is kept as it is
+func (q *queue) markSent(ctx context.Context, value domain.OutboxMessage, providerId string) (err error) {
+	var updated bool
+
+	err = q.sql.Transaction(ctx, func(ctx context.Context) (txErr error) {
+		updated, txErr = q.message.MarkSent(ctx, value.MessageId, providerId)
+		if txErr != nil {
+			return
+		}
+
+		// the outbox is published in either case, the provider has accepted the message
+		if txErr = q.outbox.UpdateStatus(ctx, value.OutboxId, string(domain.OutboxPublished)); txErr != nil || !updated {
+			return
+		}
+
+		txErr = q.message.CreateStatusHistory(ctx, []uint{value.MessageId}, string(domain.MsgSent))
+		return
+	})
+
+	if err != nil || !updated {
+		return
+	}
+
+	event := domain.NewWebhookEvent(domain.MsgSent)
+	event.FromOutboxMessage(value)
+	q.Notify(ctx, *event)
+
+	usage := domain.NewUsageEvent(value.TenantId, value.Channel, domain.MsgSent, 1)
+	usage.SetQueuedAt(value.QueuedAt)
+	q.Track(ctx, *usage)
+
+	return
+}
+
+// failAndRefund finalizes the undeliverable message as failed and gives the charged credit back
+func (q *queue) failAndRefund(ctx context.Context, value domain.OutboxMessage) error {
+	return q.finalizeAndRefund(ctx, value, domain.MsgFailed, domain.OutboxFailed)
+}
+
+// expireAndRefund finalizes the message which passed its validity period as expired and gives the charged credit back
+func (q *queue) expireAndRefund(ctx context.Context, value domain.OutboxMessage) error {
+	return q.finalizeAndRefund(ctx, value, domain.MsgExpired, domain.OutboxExpired)
+}
+
+// finalizeAndRefund the status guard and the deterministic refund id keep it exactly once, even if the record is
+// consumed again. the consumers run concurrently, so each call has a transaction of its own
+func (q *queue) finalizeAndRefund(ctx context.Context, value domain.OutboxMessage, msgSt domain.MessageStatus, outboxSt domain.OutboxStatus) (err error) {
+	var (
+		finalized bool
+		refunded  float64
+	)
+
+	final := []string{
+		string(domain.MsgSent),
+		string(domain.MsgDelivered),
+		string(domain.MsgFailed),
+		string(domain.MsgCanceled),
+		string(domain.MsgExpired),
+		string(domain.MsgRejected),
+	}
+
+	err = q.sql.Transaction(ctx, func(ctx context.Context) (txErr error) {
+		finalized, txErr = q.message.UpdateStatusUnless(ctx, value.MessageId, final, string(msgSt))
+		if txErr != nil || !finalized {
+			return
+		}
+
+		if txErr = q.message.CreateStatusHistory(ctx, []uint{value.MessageId}, string(msgSt)); txErr != nil {
+			return
+		}
+
+		if txErr = q.outbox.UpdateStatus(ctx, value.OutboxId, string(outboxSt)); txErr != nil {
+			return
+		}
+
+		message := domain.NewMessage()
+		message.SetID(value.MessageId)
+		message.SetMessageHash(value.MessageHash)
+
+		// the already refunded message is refunded by zero
+		refund, txErr := q.credit.Refund(ctx, *message)
+		if txErr != nil {
+			return
+		}
+
+		refunded = refund.Amount()
+		return
+	})
 
-	if err = q.message.UpdateStatus(ctx, msgId, string(msgSt)); err != nil {
-		_ = tx.Rollback()
-		//todo: prometheus/grafana alarm for sent but not updated status
-		q.lgr.Error("queue.consumer.prod.db.message",
-			zap.String("staus", string(msgSt)),
-			zap.Uint("message.id", msgId), zap.Error(err))
+	if err != nil {
+		q.lgr.Error("queue.consumer.finalize.tx", zap.String("status", string(msgSt)), zap.Error(err))
 		return
 	}
 
-	if err = q.outbox.UpdateStatus(ctx, outboxId, string(outboxSt)); err != nil {
-		_ = tx.Rollback()
-		//todo: prometheus/grafana alarm for sent but not updated status
-		q.lgr.Error("queue.consumer.prod.db.outbox",
-			zap.String("staus", string(outboxSt)),
-			zap.Uint("outbox.id", outboxId), zap.Error(err))
+	if finalized {
+		event := domain.NewWebhookEvent(msgSt)
+		event.FromOutboxMessage(value)
+		q.Notify(ctx, *event)
+
+		usage := domain.NewUsageEvent(value.TenantId, value.Channel, msgSt, 1)
+		usage.SetCredit(-refunded)
+		q.Track(ctx, *usage)
+	}
+
+	return
+}
+
+func (q *queue) updateStatus(ctx context.Context, value domain.OutboxMessage, msgSt domain.MessageStatus, outboxSt domain.OutboxStatus) (err error) {
+	msgId, outboxId := value.MessageId, value.OutboxId
+
+	err = q.sql.Transaction(ctx, func(ctx context.Context) (txErr error) {
+		if txErr = q.message.UpdateStatus(ctx, msgId, string(msgSt)); txErr != nil {
+			//todo: prometheus/grafana alarm for sent but not updated status
+			q.lgr.Error("queue.consumer.prod.db.message",
+				zap.String("staus", string(msgSt)),
+				zap.Uint("message.id", msgId), zap.Error(txErr))
+			return
+		}
+
+		if txErr = q.message.CreateStatusHistory(ctx, []uint{msgId}, string(msgSt)); txErr != nil {
+			q.lgr.Error("queue.consumer.prod.db.message.history",
+				zap.String("staus", string(msgSt)),
+				zap.Uint("message.id", msgId), zap.Error(txErr))
+			return
+		}
+
+		if txErr = q.outbox.UpdateStatus(ctx, outboxId, string(outboxSt)); txErr != nil {
+			//todo: prometheus/grafana alarm for sent but not updated status
+			q.lgr.Error("queue.consumer.prod.db.outbox",
+				zap.String("staus", string(outboxSt)),
+				zap.Uint("outbox.id", outboxId), zap.Error(txErr))
+			return
+		}
+
+		return
+	})
+
+	if err != nil {
 		return
 	}
 
-	_ = tx.Commit()
+	event := domain.NewWebhookEvent(msgSt)
+	event.FromOutboxMessage(value)
+	q.Notify(ctx, *event)
+
 	return
 }
@@ -3,10 +3,16 @@ package queue
 import (
 	"context"
 	"go.uber.org/fx"
+	"microservice/internal/domain"
 )
 
 type IQueue interface {
 	Init()
 	Produce(ctx context.Context, topic, key string, value []byte) error
+	Dispatch(ctx context.Context, channel, key string, value []byte) error
+	Channels() *domain.Channels
+	Notify(ctx context.Context, event domain.WebhookEvent)
+	Forward(ctx context.Context, inbound domain.InboundMessage)
+	Track(ctx context.Context, event domain.UsageEvent)
 	Fx(lc fx.Lifecycle, qfx QFx) IQueue
 }
@@ -0,0 +1,68 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+	"microservice/internal/domain"
+	"microservice/pkg/utils"
+	"time"
+)
+
+// Forward publishes the received inbound message, its consumers fan it out to the tenant (the webhook for now)
+func (q *queue) Forward(ctx context.Context, inbound domain.InboundMessage) {
+	if err := q.Produce(ctx, InboundTopic, inbound.InboundUuid.String(), inbound.Json()); err != nil {
+		//todo: set grafana/prometheus alarm
+		q.lgr.Error("queue.inbound.produce",
+			zap.Uint("inbound.id", inbound.InboundId),
+			zap.Uint("tenant.id", inbound.TenantId),
+			zap.Error(err),
+		)
+	}
+}
+
+func (q *queue) inboundTopicConsumer(ctx context.Context, handler chan struct{}) {
+	c := q.consumers[InboundTopic]
+
+	if err := c.Subscribe(InboundTopic, nil); err != nil {
+		q.lgr.Error("queue.consumer.subscribe.inbound", zap.Error(err))
+
+		utils.PrintStd(utils.StdPanic, "queue.consumer.subscribe.inbound: %s", err.Error())
+
+		// todo: set alert with prometheus
+	}
+
+	for {
+		select {
+		case <-handler:
+			if err := c.Close(); err != nil {
+				q.lgr.Error("queue.consumer.close", zap.String("topic", InboundTopic), zap.Error(err))
+				return
+			}
+
+			q.lgr.Info("queue.consumer.close", zap.String("topic", InboundTopic))
+			return
+		default:
+			msg, err := c.ReadMessage(time.Duration(q.config.ConsumerReadTtl))
+			if err != nil {
+				if kafkaErr, ok := err.(kafka.Error); ok == true && kafkaErr.Code() != kafka.ErrTimedOut {
+					q.lgr.Error("queue.consumer.inbound.read", zap.Error(err))
+					// todo: set alert with prometheus
+					break
+				}
+			}
+
+			if msg != nil {
+				var value domain.InboundMessage
+				if err = json.Unmarshal(msg.Value, &value); err != nil {
+					q.lgr.Error("queue.consumer.parse", zap.String("topic", InboundTopic), zap.Error(err))
+					break
+				}
+
+				// the webhook consumer handles the tenants without webhook, the retries and the delivery logs
+				q.Notify(ctx, *domain.NewWebhookInboundEvent(value))
+			}
+		}
+	}
+}
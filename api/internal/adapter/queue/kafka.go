@@ -2,6 +2,7 @@ package queue
 
 import (
 	"context"
+	"fmt"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -11,10 +12,13 @@ import (
 	"microservice/internal/adapter/provider/sms"
 	"microservice/internal/adapter/registry"
 	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
 	"microservice/internal/modules/port"
 	"microservice/pkg/meta"
 	"microservice/pkg/meta/status"
 	"microservice/pkg/utils"
+	"microservice/pkg/validator"
+	"net/http"
 	"time"
 )
 
@@ -27,17 +31,29 @@ type (
 		Sql         orm.ISqlTx
 		Message     port.IMessageRepository
 		Outbox      port.IOutboxRepository
+		Tenant      port.ITenantRepository
+		Webhook     port.IWebhookRepository
+		Credit      port.ICreditUsecase
+		Report      port.IReportRepository
 	}
 	queue struct {
 		config    config.Queue
 		producer  *kafka.Producer
 		consumers map[string]*kafka.Consumer
-		lgr       logger.ILogger
-		trc       trace.ITracer
-		sms       sms.ISmsProvider
-		sql       orm.ISqlTx
-		message   port.IMessageRepository
-		outbox    port.IOutboxRepository
+		channels  *domain.Channels
+		// channelConsumers the consumers of each channel topic, as many as the channel concurrency
+		channelConsumers map[string][]*kafka.Consumer
+		lgr              logger.ILogger
+		trc              trace.ITracer
+		sms              sms.ISmsProvider
+		sql              orm.ISqlTx
+		message          port.IMessageRepository
+		outbox           port.IOutboxRepository
+		tenant           port.ITenantRepository
+		webhook          port.IWebhookRepository
+		credit           port.ICreditUsecase
+		report           port.IReportRepository
+		client           *http.Client
 	}
 )
 
@@ -47,6 +63,19 @@ func New(registry registry.IRegistry) IQueue {
 		utils.PrintStd(utils.StdPanic, "queue", "config parse err: %s", err)
 	}
 
+	definitions := q.config.Channels
+	if len(definitions) == 0 {
+		definitions = domain.DefaultChannels
+	}
+
+	channels, err := domain.NewChannels(definitions)
+	if err != nil {
+		utils.PrintStd(utils.StdPanic, "queue", "channels parse err: %s", err)
+	}
+
+	q.channels = channels
+	validator.RegisterChannels(channels.Names()...)
+
 	return q
 }
 
@@ -84,14 +113,14 @@ func (q *queue) Init() {
 			existingTopics[t.Topic] = true
 		}
 
-		for _, t := range kafkaTopicsConfigs() {
+		for _, t := range kafkaTopicsConfigs(q.channels) {
 			b := existingTopics[t.Topic]
 			if b == false {
 				newTopicsConf = append(newTopicsConf, t)
 			}
 		}
 	} else {
-		newTopicsConf = kafkaTopicsConfigs()
+		newTopicsConf = kafkaTopicsConfigs(q.channels)
 	}
 
 	if len(newTopicsConf) > 0 {
@@ -132,6 +161,11 @@ func (q *queue) Init() {
 	q.consumers = make(map[string]*kafka.Consumer)
 
 	for _, gp := range q.config.Topics {
+		// the channel topics are consumed by the channel consumers
+		if _, ok := q.channels.Get(gp); ok {
+			continue
+		}
+
 		consumer, cErr := kafka.NewConsumer(kafkaConsumerConfig(q, gp))
 		if cErr != nil {
 			utils.PrintStd(utils.StdLog, "queue", "failed to create consumer: %s", cErr.Error())
@@ -142,6 +176,21 @@ func (q *queue) Init() {
 		q.consumers[gp] = consumer
 	}
 
+	q.channelConsumers = make(map[string][]*kafka.Consumer)
+
+	for _, ch := range q.channels.List() {
+		for i := 0; i < ch.Concurrency(); i++ {
+			consumer, cErr := kafka.NewConsumer(kafkaConsumerConfig(q, ch.Name()))
+			if cErr != nil {
+				utils.PrintStd(utils.StdLog, "queue", "failed to create consumer: %s", cErr.Error())
+				errCount++
+				break
+			}
+
+			q.channelConsumers[ch.Name()] = append(q.channelConsumers[ch.Name()], consumer)
+		}
+	}
+
 	if errCount > 0 {
 		utils.PrintStd(utils.StdPanic, "queue", "consumer init failed")
 	}
@@ -169,6 +218,22 @@ func (q *queue) Produce(ctx context.Context, topic, key string, value []byte) (e
 	return
 }
 
+// Dispatch produces the message to the topic of the channel
+func (q *queue) Dispatch(ctx context.Context, channel, key string, value []byte) (err error) {
+	ch, ok := q.channels.Get(channel)
+	if !ok {
+		err = meta.ServiceErr(status.Failed, fmt.Errorf("%w: %q", domain.ErrInvalidChannel, channel))
+		return
+	}
+
+	return q.Produce(ctx, ch.Topic(), key, value)
+}
+
+// Channels the configured message channels
+func (q *queue) Channels() *domain.Channels {
+	return q.channels
+}
+
 func (q *queue) Fx(lc fx.Lifecycle, qfx QFx) IQueue {
 	topicHdl := make(chan struct{})
 
@@ -180,15 +245,21 @@ func (q *queue) Fx(lc fx.Lifecycle, qfx QFx) IQueue {
 			q.sms = qfx.SmsProvider
 			q.message = qfx.Message
 			q.outbox = qfx.Outbox
+			q.tenant = qfx.Tenant
+			q.webhook = qfx.Webhook
+			q.credit = qfx.Credit
+			q.report = qfx.Report
+			q.client = webhookClient(q.config)
 
-			utils.PrintStd(utils.StdLog, "queue", "initiated")
+			// the consumers never join the transaction of a request, they begin their own when needed
+			ctx = q.sql.Detach(ctx)
 
-			if c, ok := q.consumers[ProdTopic]; ok && c != nil {
-				go q.productionTopicConsumer(ctx, topicHdl)
-			}
+			utils.PrintStd(utils.StdLog, "queue", "initiated")
 
-			if c, ok := q.consumers[ExpressTopic]; ok && c != nil {
-				go q.expressTopicConsumer(ctx, topicHdl)
+			for _, ch := range q.channels.List() {
+				for _, c := range q.channelConsumers[ch.Name()] {
+					go q.channelTopicConsumer(ctx, topicHdl, ch, c)
+				}
 			}
 
 			if c, ok := q.consumers[RetryTopic]; ok && c != nil {
@@ -199,6 +270,21 @@ func (q *queue) Fx(lc fx.Lifecycle, qfx QFx) IQueue {
 				go q.dlqTopicConsumer(ctx, topicHdl)
 			}
 
+			if c, ok := q.consumers[WebhookTopic]; ok && c != nil {
+				go q.webhookTopicConsumer(ctx, topicHdl)
+				go q.webhookRetriesDispatcher(ctx, topicHdl)
+			}
+
+			if c, ok := q.consumers[InboundTopic]; ok && c != nil {
+				go q.inboundTopicConsumer(ctx, topicHdl)
+			}
+
+			if c, ok := q.consumers[UsageTopic]; ok && c != nil {
+				go q.usageTopicConsumer(ctx, topicHdl)
+			}
+
+			go q.scheduledMessagesDispatcher(ctx, topicHdl)
+
 			return
 		},
 		OnStop: func(ctx context.Context) (err error) {
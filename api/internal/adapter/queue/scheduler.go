@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"go.uber.org/zap"
+	"microservice/internal/domain"
+	"time"
+)
+
+const scheduledBatchSize = 500
+
+// scheduledMessagesDispatcher releases the due scheduled messages to their channel topic on every tick
+func (q *queue) scheduledMessagesDispatcher(ctx context.Context, handler chan struct{}) {
+	tick := time.Duration(q.config.ScheduleTick) * time.Second
+	if tick == 0 {
+		tick = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-handler:
+			q.lgr.Info("queue.scheduler.close")
+			return
+		case <-ticker.C:
+			q.dispatchScheduled(ctx)
+		}
+	}
+}
+
+func (q *queue) dispatchScheduled(ctx context.Context) {
+	for {
+		held := 0
+
+		messages, err := q.message.ReleaseScheduled(ctx, time.Now().UTC(), scheduledBatchSize)
+		if err != nil {
+			q.lgr.Error("queue.scheduler.release", zap.Error(err))
+			return
+		}
+
+		released := make([]uint, 0, len(messages.List()))
+
+		for _, message := range messages.List() {
+			outbox := message.Outbox()
+
+			var value domain.OutboxMessage
+			if err = json.Unmarshal(outbox.Payload(), &value); err != nil {
+				q.lgr.Error("queue.scheduler.parse", zap.Uint("message.id", message.ID()), zap.Error(err))
+				continue
+			}
+
+			value.SetOutboxID(outbox.ID())
+			value.SetQueuedAt(time.Now().UTC())
+			value.Status = string(domain.MsgQueued)
+
+			if err = q.Dispatch(ctx, message.Channel(), message.MessageHash(), value.Json()); err != nil {
+				//todo: set grafana/prometheus alarm
+				q.lgr.Error("queue.scheduler.produce", zap.Uint("message.id", message.ID()), zap.Error(err))
+
+				// hold the message again to be released by the next tick
+				held++
+				if err = q.message.UpdateStatus(ctx, message.ID(), string(domain.MsgScheduled)); err != nil {
+					q.lgr.Error("queue.scheduler.db.status", zap.Uint("message.id", message.ID()), zap.Error(err))
+				}
+
+				continue
+			}
+
+			released = append(released, message.ID())
+
+			event := domain.NewWebhookEvent(domain.MsgQueued)
+			event.FromMessage(message)
+			q.Notify(ctx, *event)
+
+			q.Track(ctx, *domain.NewUsageEvent(message.TenantID(), message.Channel(), domain.MsgQueued, 1))
+		}
+
+		if err = q.message.CreateStatusHistory(ctx, released, string(domain.MsgQueued)); err != nil {
+			q.lgr.Error("queue.scheduler.db.history", zap.Error(err))
+		}
+
+		if held > 0 || len(messages.List()) < scheduledBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+	"microservice/internal/domain"
+	"microservice/pkg/utils"
+	"time"
+)
+
+// Track publishes the usage change of the tenant, the usage consumer rolls it up for the reports
+func (q *queue) Track(ctx context.Context, event domain.UsageEvent) {
+	key := fmt.Sprintf("%d", event.TenantId) // the tenant usage is kept in a single partition
+	if err := q.Produce(ctx, UsageTopic, key, event.Json()); err != nil {
+		//todo: set grafana/prometheus alarm
+		q.lgr.Error("queue.usage.produce",
+			zap.Uint("tenant.id", event.TenantId),
+			zap.String("status", event.Status),
+			zap.Int64("messages", event.Messages),
+			zap.Error(err),
+		)
+	}
+}
+
+func (q *queue) usageTopicConsumer(ctx context.Context, handler chan struct{}) {
+	c := q.consumers[UsageTopic]
+
+	if err := c.Subscribe(UsageTopic, nil); err != nil {
+		q.lgr.Error("queue.consumer.subscribe.usage", zap.Error(err))
+
+		utils.PrintStd(utils.StdPanic, "queue.consumer.subscribe.usage: %s", err.Error())
+
+		// todo: set alert with prometheus
+	}
+
+	for {
+		select {
+		case <-handler:
+			if err := c.Close(); err != nil {
+				q.lgr.Error("queue.consumer.close", zap.String("topic", UsageTopic), zap.Error(err))
+				return
+			}
+
+			q.lgr.Info("queue.consumer.close", zap.String("topic", UsageTopic))
+			return
+		default:
+			msg, err := c.ReadMessage(time.Duration(q.config.ConsumerReadTtl))
+			if err != nil {
+				if kafkaErr, ok := err.(kafka.Error); ok == true && kafkaErr.Code() != kafka.ErrTimedOut {
+					q.lgr.Error("queue.consumer.usage.read", zap.Error(err))
+					// todo: set alert with prometheus
+					break
+				}
+			}
+
+			if msg != nil {
+				var value domain.UsageEvent
+				if err = json.Unmarshal(msg.Value, &value); err != nil {
+					q.lgr.Error("queue.consumer.parse", zap.String("topic", UsageTopic), zap.Error(err))
+					break
+				}
+
+				rollup := domain.NewUsageRollup()
+				rollup.FromUsageEvent(value)
+
+				if err = q.report.Increment(ctx, *rollup); err != nil {
+					//todo: set grafana/prometheus alarm, the report misses the usage
+					q.lgr.Error("queue.consumer.db.usage",
+						zap.Uint("tenant.id", value.TenantId),
+						zap.String("status", value.Status),
+						zap.Int64("messages", value.Messages),
+						zap.Error(err),
+					)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.uber.org/zap"
+	"microservice/config"
+	"microservice/internal/domain"
+	"microservice/pkg/utils"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	webhookDefaultAttempts = 6
+	webhookDefaultTimeout  = 5 * time.Second
+	// webhookBackoffBase the first retry delay, doubled on every next attempt
+	webhookBackoffBase = 2 * time.Second
+	// webhookRetriesBatchSize the retries released to the webhook topic in one transaction
+	webhookRetriesBatchSize = 500
+)
+
+// Notify queues the message status change for the tenant webhook. the tenants without webhook are skipped by the consumer
+func (q *queue) Notify(ctx context.Context, event domain.WebhookEvent) {
+	if err := q.Produce(ctx, WebhookTopic, event.EventId.String(), event.Json()); err != nil {
+		//todo: set grafana/prometheus alarm
+		q.lgr.Error("queue.webhook.produce",
+			zap.Uint("message.id", event.MessageId),
+			zap.String("status", event.Status),
+			zap.Error(err),
+		)
+	}
+}
+
+func (q *queue) webhookTopicConsumer(ctx context.Context, handler chan struct{}) {
+	c := q.consumers[WebhookTopic]
+
+	if err := c.Subscribe(WebhookTopic, nil); err != nil {
+		q.lgr.Error("queue.consumer.subscribe.webhook", zap.Error(err))
+
+		utils.PrintStd(utils.StdPanic, "queue.consumer.subscribe.webhook: %s", err.Error())
+
+		// todo: set alert with prometheus
+	}
+
+	for {
+		select {
+		case <-handler:
+			if err := c.Close(); err != nil {
+				q.lgr.Error("queue.consumer.close", zap.String("topic", WebhookTopic), zap.Error(err))
+				return
+			}
+
+			q.lgr.Info("queue.consumer.close", zap.String("topic", WebhookTopic))
+			return
+		default:
+			msg, err := c.ReadMessage(time.Duration(q.config.ConsumerReadTtl))
+			if err != nil {
+				if kafkaErr, ok := err.(kafka.Error); ok == true && kafkaErr.Code() != kafka.ErrTimedOut {
+					q.lgr.Error("queue.consumer.webhook.read", zap.Error(err))
+					// todo: set alert with prometheus
+					break
+				}
+			}
+
+			if msg == nil {
+				break
+			}
+
+			q.consumeAndPostWebhook(ctx, msg)
+		}
+	}
+}
+
+// webhookRetriesDispatcher releases the due webhook retries back to the webhook topic on every tick, the failing
+// endpoints never hold the webhook topic partitions back
+func (q *queue) webhookRetriesDispatcher(ctx context.Context, handler chan struct{}) {
+	tick := time.Duration(q.config.ScheduleTick) * time.Second
+	if tick == 0 {
+		tick = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-handler:
+			q.lgr.Info("queue.webhook.retries.close")
+			return
+		case <-ticker.C:
+			q.dispatchWebhookRetries(ctx)
+		}
+	}
+}
+
+func (q *queue) dispatchWebhookRetries(ctx context.Context) {
+	for {
+		released := 0
+
+		// the retries are removed only when all of them are produced, otherwise the next tick releases them again
+		err := q.sql.Transaction(ctx, func(ctx context.Context) (txErr error) {
+			events, txErr := q.webhook.ReleaseRetries(ctx, time.Now().UTC(), webhookRetriesBatchSize)
+			if txErr != nil {
+				return
+			}
+
+			for _, event := range events {
+				if txErr = q.Produce(ctx, WebhookTopic, event.EventId.String(), event.Json()); txErr != nil {
+					return
+				}
+			}
+
+			released = len(events)
+			return
+		})
+
+		if err != nil {
+			//todo: set grafana/prometheus alarm
+			q.lgr.Error("queue.webhook.retries.release", zap.Error(err))
+			return
+		}
+
+		if released < webhookRetriesBatchSize {
+			return
+		}
+	}
+}
+
+// consumeAndPostWebhook posts the event, the failed one is held in the retries until its backoff passes
+func (q *queue) consumeAndPostWebhook(ctx context.Context, msg *kafka.Message) {
+	var event domain.WebhookEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		q.lgr.Error("queue.consumer.parse", zap.String("topic", WebhookTopic), zap.Error(err))
+		return
+	}
+
+	tenant, err := q.tenant.GetByID(ctx, event.TenantId)
+	if err != nil {
+		q.lgr.Error("queue.consumer.webhook.tenant", zap.Uint("tenant.id", event.TenantId), zap.Error(err))
+		return
+	}
+
+	webhook := tenant.Webhook()
+	if !webhook.Enabled() {
+		return
+	}
+
+	event.Attempt++
+
+	delivery := q.postWebhook(ctx, webhook, event)
+	if _, err = q.webhook.CreateDelivery(ctx, delivery); err != nil {
+		q.lgr.Error("queue.consumer.db.webhook.delivery",
+			zap.String("event.id", event.EventId.String()),
+			zap.Int("attempt", event.Attempt),
+			zap.Error(err),
+		)
+	}
+
+	if delivery.Succeeded() {
+		return
+	}
+
+	if event.Attempt >= webhookAttempts(q.config) {
+		//todo: set grafana/prometheus alarm
+		q.lgr.Error("queue.consumer.webhook.exhausted",
+			zap.String("event.id", event.EventId.String()),
+			zap.Uint("tenant.id", event.TenantId),
+		)
+		return
+	}
+
+	event.NextAttemptAt = time.Now().UTC().Add(webhookBackoff(event.Attempt))
+
+	if err = q.webhook.CreateRetry(ctx, event); err != nil {
+		//todo: set grafana/prometheus alarm
+		q.lgr.Error("queue.consumer.db.webhook.retry",
+			zap.String("event.id", event.EventId.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// postWebhook posts the signed event payload and reports the attempt result
+func (q *queue) postWebhook(ctx context.Context, webhook domain.TenantWebhook, event domain.WebhookEvent) domain.WebhookDelivery {
+	delivery := domain.NewWebhookDelivery(event, webhook.Url())
+
+	// the webhooks registered before https was required are not posted
+	if !strings.HasPrefix(webhook.Url(), "https://") {
+		delivery.SetErr("the webhook url is not https")
+		return *delivery
+	}
+
+	body := event.Payload()
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url(), bytes.NewReader(body))
+	if err != nil {
+		delivery.SetErr(err.Error())
+		return *delivery
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X.WEBHOOK.EVENT", event.EventType())
+	req.Header.Set("X.WEBHOOK.ID", event.EventId.String())
+	req.Header.Set("X.WEBHOOK.TIMESTAMP", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X.WEBHOOK.SIGNATURE", utils.WebhookSignature(webhook.Secret(), timestamp, body))
+
+	start := time.Now()
+	resp, err := q.client.Do(req)
+	delivery.SetDuration(time.Since(start))
+
+	if err != nil {
+		delivery.SetErr(err.Error())
+		return *delivery
+	}
+
+	_ = resp.Body.Close()
+
+	delivery.SetResponseCode(resp.StatusCode)
+	delivery.SetSucceeded(resp.StatusCode >= 200 && resp.StatusCode < 300)
+
+	if !delivery.Succeeded() {
+		delivery.SetErr(fmt.Sprintf("unexpected response status %d", resp.StatusCode))
+	}
+
+	return *delivery
+}
+
+// HELPERS
+
+func webhookBackoff(attempt int) time.Duration {
+	return webhookBackoffBase * time.Duration(1<<(attempt-1))
+}
+
+func webhookAttempts(c config.Queue) int {
+	if c.WebhookAttempts > 0 {
+		return c.WebhookAttempts
+	}
+
+	return webhookDefaultAttempts
+}
+
+// webhookClient posts to the public https addresses only, the redirects are not followed and no proxy is used
+func webhookClient(c config.Queue) *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout(c), Control: utils.WebhookDialControl}
+
+	return &http.Client{
+		Timeout:   webhookTimeout(c),
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: webhookTimeout(c)},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func webhookTimeout(c config.Queue) time.Duration {
+	if c.WebhookTimeout > 0 {
+		return time.Duration(c.WebhookTimeout) * time.Second
+	}
+
+	return webhookDefaultTimeout
+}
@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -58,13 +62,77 @@ func (bd *Base) SetDeletedAt(t time.Time) {
 //
 
 type BaseList struct {
-	total int64
+	total      int64
+	nextCursor ListCursor
 }
 
 func (bl *BaseList) Total() int64 { return bl.total }
 
 func (bl *BaseList) SetTotal(total int64) { bl.total = total }
 
+// NextCursor the position of the last item of the page, zero for the last page
+func (bl *BaseList) NextCursor() ListCursor { return bl.nextCursor }
+
+func (bl *BaseList) SetNextCursor(nextCursor ListCursor) { bl.nextCursor = nextCursor }
+
+// ListCursor the keyset position of a list item, the `(created_at, id)` of the item. the id is kept as text to support
+// the non-integer primary keys
+type ListCursor struct {
+	createdAt time.Time
+	key       string
+}
+
+var ErrInvalidCursor = errors.New("invalid list cursor")
+
+func NewListCursor(createdAt time.Time, key string) ListCursor {
+	return ListCursor{createdAt: createdAt, key: key}
+}
+
+// DecodeListCursor parses the opaque cursor of the list responses
+func DecodeListCursor(cursor string) (res ListCursor, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		err = ErrInvalidCursor
+		return
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		err = ErrInvalidCursor
+		return
+	}
+
+	micro, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		err = ErrInvalidCursor
+		return
+	}
+
+	res = NewListCursor(time.UnixMicro(micro).UTC(), parts[1])
+	return
+}
+
+func (lc ListCursor) CreatedAt() time.Time { return lc.createdAt }
+
+func (lc ListCursor) Key() string { return lc.key }
+
+// ID the key of the integer primary keys (zero if the key is not an integer)
+func (lc ListCursor) ID() uint {
+	id, _ := strconv.ParseUint(lc.key, 10, 64)
+	return uint(id)
+}
+
+func (lc ListCursor) IsZero() bool { return len(lc.key) == 0 }
+
+// Encode the opaque form of the cursor, the db timestamps are kept in microseconds
+func (lc ListCursor) Encode() string {
+	if lc.IsZero() {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d|%s", lc.createdAt.UnixMicro(), lc.key)))
+}
+
 // collection default query params
 
 type ReqBaseQryParam struct {
@@ -76,6 +144,9 @@ type ReqBaseQryParam struct {
 	search string
 	relId  uint
 	items  []uuid.UUID
+	// cursor the keyset position to continue the list after it, instead of the page offset
+	cursor    ListCursor
+	skipTotal bool
 }
 
 func (bc *ReqBaseQryParam) Page() int {
@@ -153,6 +224,25 @@ func (bc *ReqBaseQryParam) SetItems(items ...uuid.UUID) {
 	bc.items = items
 }
 
+func (bc *ReqBaseQryParam) Cursor() ListCursor {
+	return bc.cursor
+}
+
+// SetCursor the list is sorted by `created_at` in the cursor mode
+func (bc *ReqBaseQryParam) SetCursor(cursor ListCursor) {
+	bc.cursor = cursor
+	bc.sort = ""
+}
+
+// SkipTotal reports whether the total count of the list is not needed
+func (bc *ReqBaseQryParam) SkipTotal() bool {
+	return bc.skipTotal
+}
+
+func (bc *ReqBaseQryParam) SetSkipTotal(skipTotal bool) {
+	bc.skipTotal = skipTotal
+}
+
 //
 
 func (bc *ReqBaseQryParam) Offset() int {
@@ -160,9 +250,27 @@ func (bc *ReqBaseQryParam) Offset() int {
 }
 
 func (bc *ReqBaseQryParam) SortOrder() string {
+	if bc.Keyset() {
+		return fmt.Sprintf("created_at %s, id %s", bc.Order(), bc.Order())
+	}
+
 	return fmt.Sprintf("%s %s", bc.Sort(), bc.Order())
 }
 
+// Keyset reports whether the list order is stable by `(created_at, id)`, which the cursors are made of
+func (bc *ReqBaseQryParam) Keyset() bool {
+	return bc.Sort() == "created_at"
+}
+
+// KeysetCond the condition of the items after the cursor, the cursor created_at and id are its args
+func (bc *ReqBaseQryParam) KeysetCond() string {
+	if strings.EqualFold(bc.Order(), "asc") {
+		return "(created_at, id) > (?, ?)"
+	}
+
+	return "(created_at, id) < (?, ?)"
+}
+
 // General Purpose
 
 type BaseRelation struct {
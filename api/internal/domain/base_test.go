@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDecodeListCursor(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 123456000, time.UTC)
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+		want   ListCursor
+		err    error
+	}{
+		{name: "integer key", cursor: NewListCursor(createdAt, "42").Encode(), want: NewListCursor(createdAt, "42")},
+		{name: "text key", cursor: NewListCursor(createdAt, "abc123").Encode(), want: NewListCursor(createdAt, "abc123")},
+		{name: "key with separator", cursor: encode("1714559400123456|a|b"), want: NewListCursor(createdAt, "a|b")},
+		{name: "not base64", cursor: "%%%", err: ErrInvalidCursor},
+		{name: "no separator", cursor: encode("1714559400123456"), err: ErrInvalidCursor},
+		{name: "empty key", cursor: encode("1714559400123456|"), err: ErrInvalidCursor},
+		{name: "not a timestamp", cursor: encode("yesterday|42"), err: ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeListCursor(tt.cursor)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("DecodeListCursor(%q) err = %v, want %v", tt.cursor, err, tt.err)
+			}
+
+			if !got.CreatedAt().Equal(tt.want.CreatedAt()) || got.Key() != tt.want.Key() {
+				t.Errorf("DecodeListCursor(%q) = %v %q, want %v %q", tt.cursor, got.CreatedAt(), got.Key(), tt.want.CreatedAt(), tt.want.Key())
+			}
+		})
+	}
+}
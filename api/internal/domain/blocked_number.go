@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"microservice/internal/model"
+)
+
+type (
+	BlockReason   string
+	BlockedNumber struct {
+		Base
+		tenantId uint
+		mobile   string
+		reason   string
+	}
+
+	BlockedNumberList struct {
+		BaseList
+		list []BlockedNumber
+	}
+)
+
+const (
+	BlockManual BlockReason = "manual"
+	// BlockOptOut the recipient has replied by a STOP keyword
+	BlockOptOut BlockReason = "opt_out"
+)
+
+// GlobalBlocklist the tenant id of the global block list, applied to all tenants
+const GlobalBlocklist uint = 0
+
+func NewBlockedNumber() *BlockedNumber {
+	return &BlockedNumber{}
+}
+
+// TenantID the owner tenant of the blocked number (GlobalBlocklist for the global list)
+func (b *BlockedNumber) TenantID() uint {
+	return b.tenantId
+}
+
+func (b *BlockedNumber) SetTenantID(tenantId uint) {
+	b.tenantId = tenantId
+}
+
+func (b *BlockedNumber) Mobile() string {
+	return b.mobile
+}
+
+func (b *BlockedNumber) SetMobile(mobile string) {
+	b.mobile = mobile
+}
+
+func (b *BlockedNumber) Reason() string {
+	return b.reason
+}
+
+func (b *BlockedNumber) SetReason(reason string) {
+	b.reason = reason
+}
+
+// IsGlobal reports whether the number is blocked for all tenants
+func (b *BlockedNumber) IsGlobal() bool {
+	return b.tenantId == GlobalBlocklist
+}
+
+//
+
+func (b *BlockedNumber) FromDB(src model.BlockedNumbers) BlockedNumber {
+	// base
+	b.SetID(src.ID)
+	b.SetUUID(src.Uuid)
+	b.SetCreatedAt(src.CreatedAt)
+	b.SetUpdatedAt(src.UpdatedAt)
+	b.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	b.SetTenantID(uint(src.TenantID.Int64))
+	b.SetMobile(src.Mobile)
+	b.SetReason(src.Reason)
+
+	return *b
+}
+
+func (b *BlockedNumber) ToDB() model.BlockedNumbers {
+	return model.BlockedNumbers{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        b.ID(),
+				CreatedAt: b.CreatedAt(),
+				UpdatedAt: b.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(
+					sql.NullTime{
+						Time: b.DeletedAt(),
+						Valid: func() bool {
+							if b.DeletedAt().IsZero() {
+								return false
+							}
+							return true
+						}(),
+					},
+				),
+			},
+			Uuid: b.UUID(),
+		},
+		TenantID: sql.NullInt64{
+			Int64: int64(b.TenantID()),
+			Valid: !b.IsGlobal(),
+		},
+		Mobile: b.Mobile(),
+		Reason: b.Reason(),
+	}
+}
+
+//
+
+func NewBlockedNumberList() *BlockedNumberList { return &BlockedNumberList{} }
+
+func (ul *BlockedNumberList) List() []BlockedNumber { return ul.list }
+
+func (ul *BlockedNumberList) SetList(list []BlockedNumber) { ul.list = list }
+
+func (ul *BlockedNumberList) ListFromDB(src []model.BlockedNumbers) BlockedNumberList {
+	ul.list = make([]BlockedNumber, 0)
+
+	total := len(src)
+	if ul.total == 0 && total > 0 {
+		ul.total = int64(total)
+	}
+
+	if ul.total == 0 {
+		return *ul
+	}
+
+	for _, item := range src {
+		ul.list = append(ul.list, NewBlockedNumber().FromDB(item))
+	}
+
+	return *ul
+}
+
+//
+
+type BlockedNumberListReqQryParam struct {
+	ReqBaseQryParam
+	tenantId uint
+}
+
+func NewBlockedNumberListReqQryParam() *BlockedNumberListReqQryParam {
+	return &BlockedNumberListReqQryParam{}
+}
+
+// TenantId the list owner (GlobalBlocklist for the global list)
+func (b *BlockedNumberListReqQryParam) TenantId() uint {
+	return b.tenantId
+}
+
+func (b *BlockedNumberListReqQryParam) SetTenantId(tenantId uint) {
+	b.tenantId = tenantId
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Channel the message channel, produced to its own topic and consumed by its own consumers
+	Channel struct {
+		name            string
+		topic           string
+		partitions      int
+		priority        int
+		priceMultiplier float64
+		concurrency     int
+	}
+
+	// Channels the registry of the configured channels, in the configuration order
+	Channels struct {
+		list   []Channel
+		byName map[string]Channel
+	}
+)
+
+// DefaultChannels the channels of the service when none is configured, in the QUEUE_CHANNELS format
+var DefaultChannels = []string{
+	"event.prod:event.prod:50:0:1:1",
+	"event.express:event.express:30:1:1:1",
+}
+
+var ErrInvalidChannel = errors.New("invalid channel")
+
+// ParseChannel parses the `name:topic:partitions:priority:priceMultiplier:concurrency` channel definition, the
+// topic is the channel name if empty
+func ParseChannel(definition string) (res Channel, err error) {
+	fields := strings.Split(strings.TrimSpace(definition), ":")
+	if len(fields) != 6 || len(fields[0]) == 0 {
+		err = fmt.Errorf("%w: %q", ErrInvalidChannel, definition)
+		return
+	}
+
+	res.name, res.topic = fields[0], fields[1]
+	if len(res.topic) == 0 {
+		res.topic = res.name
+	}
+
+	numbers := make([]int, 0, 3)
+	for _, field := range []string{fields[2], fields[3], fields[5]} {
+		number, cErr := strconv.Atoi(field)
+		if cErr != nil || number < 0 {
+			err = fmt.Errorf("%w: %q", ErrInvalidChannel, definition)
+			return
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	res.partitions, res.priority, res.concurrency = numbers[0], numbers[1], numbers[2]
+
+	if res.priceMultiplier, err = strconv.ParseFloat(fields[4], 64); err != nil || res.priceMultiplier <= 0 {
+		err = fmt.Errorf("%w: %q", ErrInvalidChannel, definition)
+		return
+	}
+
+	if res.partitions == 0 || res.concurrency == 0 {
+		err = fmt.Errorf("%w: %q", ErrInvalidChannel, definition)
+		return
+	}
+
+	return
+}
+
+func (c Channel) Name() string { return c.name }
+
+func (c Channel) Topic() string { return c.topic }
+
+// Partitions the partition count of the topic on its creation
+func (c Channel) Partitions() int { return c.partitions }
+
+// Priority the higher is the more urgent, the zero priority channels are held in the quiet hours
+func (c Channel) Priority() int { return c.priority }
+
+// PriceMultiplier the factor of the rate card price
+func (c Channel) PriceMultiplier() float64 { return c.priceMultiplier }
+
+// Concurrency the count of the consumers of the topic
+func (c Channel) Concurrency() int { return c.concurrency }
+
+// IsUrgent reports whether the channel messages are sent even in the quiet hours
+func (c Channel) IsUrgent() bool { return c.priority > 0 }
+
+// Price the unit price of the channel, rounded to the stored precision
+func (c Channel) Price(unitPrice float64) float64 {
+	return math.Round(unitPrice*c.priceMultiplier*10000) / 10000
+}
+
+//
+
+// NewChannels the channel names and topics are unique
+func NewChannels(definitions []string) (*Channels, error) {
+	channels := &Channels{byName: make(map[string]Channel)}
+	topics := make(map[string]bool)
+
+	for _, definition := range definitions {
+		if len(strings.TrimSpace(definition)) == 0 {
+			continue
+		}
+
+		channel, err := ParseChannel(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, exists := channels.byName[channel.name]; exists || topics[channel.topic] {
+			return nil, fmt.Errorf("%w: duplicate %q", ErrInvalidChannel, channel.name)
+		}
+
+		topics[channel.topic] = true
+		channels.byName[channel.name] = channel
+		channels.list = append(channels.list, channel)
+	}
+
+	if len(channels.list) == 0 {
+		return nil, fmt.Errorf("%w: no channel", ErrInvalidChannel)
+	}
+
+	return channels, nil
+}
+
+func (cs *Channels) Get(name string) (Channel, bool) {
+	channel, ok := cs.byName[name]
+	return channel, ok
+}
+
+func (cs *Channels) List() []Channel { return cs.list }
+
+func (cs *Channels) Names() []string {
+	names := make([]string, 0, len(cs.list))
+	for _, channel := range cs.list {
+		names = append(names, channel.name)
+	}
+
+	return names
+}
+
+// Default the first configured channel, its rate cards price the channels without their own
+func (cs *Channels) Default() Channel { return cs.list[0] }
+
+// Urgent the channel of the highest priority, the first one of the equals
+func (cs *Channels) Urgent() Channel {
+	list := make([]Channel, len(cs.list))
+	copy(list, cs.list)
+
+	sort.SliceStable(list, func(i, j int) bool { return list[i].priority > list[j].priority })
+	return list[0]
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseChannel(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		want       Channel
+		err        error
+	}{
+		{
+			name:       "full definition",
+			definition: "event.prod:event.prod:50:0:1:1",
+			want:       Channel{name: "event.prod", topic: "event.prod", partitions: 50, priority: 0, priceMultiplier: 1, concurrency: 1},
+		},
+		{
+			name:       "empty topic is the name",
+			definition: "otp::10:2:1.5:4",
+			want:       Channel{name: "otp", topic: "otp", partitions: 10, priority: 2, priceMultiplier: 1.5, concurrency: 4},
+		},
+		{
+			name:       "surrounding spaces",
+			definition: " bulk:bulk.topic:5:0:0.8:2 ",
+			want:       Channel{name: "bulk", topic: "bulk.topic", partitions: 5, priority: 0, priceMultiplier: 0.8, concurrency: 2},
+		},
+		{name: "missing fields", definition: "event.prod:event.prod:50:0:1", err: ErrInvalidChannel},
+		{name: "extra fields", definition: "event.prod:event.prod:50:0:1:1:1", err: ErrInvalidChannel},
+		{name: "empty name", definition: ":topic:50:0:1:1", err: ErrInvalidChannel},
+		{name: "non numeric partitions", definition: "a:a:many:0:1:1", err: ErrInvalidChannel},
+		{name: "negative priority", definition: "a:a:1:-1:1:1", err: ErrInvalidChannel},
+		{name: "zero partitions", definition: "a:a:0:0:1:1", err: ErrInvalidChannel},
+		{name: "zero concurrency", definition: "a:a:1:0:1:0", err: ErrInvalidChannel},
+		{name: "zero price multiplier", definition: "a:a:1:0:0:1", err: ErrInvalidChannel},
+		{name: "non numeric price multiplier", definition: "a:a:1:0:x:1", err: ErrInvalidChannel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChannel(tt.definition)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseChannel(%q) err = %v, want %v", tt.definition, err, tt.err)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("ParseChannel(%q) = %+v, want %+v", tt.definition, got, tt.want)
+			}
+		})
+	}
+}
@@ -65,9 +65,11 @@ func (c *Credit) SetTransactions(src []Transaction) {
 }
 
 func (c *Credit) SetTransactionList(src TransactionList) {
-	if src.Total() > 0 {
+	// the cursor pages skip the total, so the list itself tells whether there is a page
+	if len(src.List()) > 0 {
 		c.transactions.SetList(src.List())
 		c.transactions.SetTotal(src.Total())
+		c.transactions.SetNextCursor(src.NextCursor())
 	}
 }
 
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"microservice/internal/model"
+	"time"
+)
+
+type (
+	ExportResource string
+	ExportFormat   string
+	ExportStatus   string
+
+	// ExportJob the background export of a tenant list, its file is downloadable after the completion
+	ExportJob struct {
+		Base
+		tenantId   uint
+		resource   ExportResource
+		format     ExportFormat
+		filters    string
+		status     string
+		rows       int64
+		filePath   string
+		err        string
+		finishedAt time.Time
+		// messageQry and transactionQry the list filters of the resource, they are not persisted
+		messageQry     MessageListReqQryParam
+		transactionQry TransactionListReqQryParam
+	}
+)
+
+const (
+	ExportMessages     ExportResource = "messages"
+	ExportTransactions ExportResource = "transactions"
+)
+
+const (
+	ExportCsv   ExportFormat = "csv"
+	ExportJsonl ExportFormat = "jsonl"
+)
+
+const (
+	ExportPending   ExportStatus = "pending"
+	ExportRunning   ExportStatus = "running"
+	ExportCompleted ExportStatus = "completed"
+	ExportFailed    ExportStatus = "failed"
+	// ExportExpired the file of the completed job is removed after its ttl
+	ExportExpired ExportStatus = "expired"
+)
+
+func NewExportJob() *ExportJob {
+	return &ExportJob{}
+}
+
+func (e *ExportJob) TenantID() uint {
+	return e.tenantId
+}
+
+func (e *ExportJob) SetTenantID(tenantId uint) {
+	e.tenantId = tenantId
+}
+
+func (e *ExportJob) Resource() ExportResource {
+	return e.resource
+}
+
+func (e *ExportJob) SetResource(resource ExportResource) {
+	e.resource = resource
+}
+
+func (e *ExportJob) Format() ExportFormat {
+	return e.format
+}
+
+func (e *ExportJob) SetFormat(format ExportFormat) {
+	e.format = format
+}
+
+// Filters the raw query of the list filters, kept for the reference
+func (e *ExportJob) Filters() string {
+	return e.filters
+}
+
+func (e *ExportJob) SetFilters(filters string) {
+	e.filters = filters
+}
+
+func (e *ExportJob) Status() string {
+	return e.status
+}
+
+func (e *ExportJob) SetStatus(status string) {
+	e.status = status
+}
+
+// Rows the count of the exported rows
+func (e *ExportJob) Rows() int64 {
+	return e.rows
+}
+
+func (e *ExportJob) SetRows(rows int64) {
+	e.rows = rows
+}
+
+func (e *ExportJob) FilePath() string {
+	return e.filePath
+}
+
+func (e *ExportJob) SetFilePath(filePath string) {
+	e.filePath = filePath
+}
+
+// Err the failure reason of the job
+func (e *ExportJob) Err() string {
+	return e.err
+}
+
+func (e *ExportJob) SetErr(err string) {
+	e.err = err
+}
+
+func (e *ExportJob) FinishedAt() time.Time {
+	return e.finishedAt
+}
+
+func (e *ExportJob) SetFinishedAt(finishedAt time.Time) {
+	e.finishedAt = finishedAt
+}
+
+// IsDownloadable reports whether the job file is ready
+func (e *ExportJob) IsDownloadable() bool {
+	return e.status == string(ExportCompleted) && len(e.filePath) > 0
+}
+
+func (e *ExportJob) MessageQry() MessageListReqQryParam {
+	return e.messageQry
+}
+
+func (e *ExportJob) SetMessageQry(messageQry MessageListReqQryParam) {
+	e.messageQry = messageQry
+}
+
+func (e *ExportJob) TransactionQry() TransactionListReqQryParam {
+	return e.transactionQry
+}
+
+func (e *ExportJob) SetTransactionQry(transactionQry TransactionListReqQryParam) {
+	e.transactionQry = transactionQry
+}
+
+// FileName the download name of the job file
+func (e *ExportJob) FileName() string {
+	return string(e.resource) + "-" + e.UUID().String() + "." + string(e.format)
+}
+
+//
+
+func (e *ExportJob) FromDB(src model.ExportJobs) ExportJob {
+	// base
+	e.SetID(src.ID)
+	e.SetUUID(src.Uuid)
+	e.SetCreatedAt(src.CreatedAt)
+	e.SetUpdatedAt(src.UpdatedAt)
+	e.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	e.SetTenantID(src.TenantID)
+	e.SetResource(ExportResource(src.Resource))
+	e.SetFormat(ExportFormat(src.Format))
+	e.SetFilters(src.Filters.String)
+	e.SetStatus(src.Status)
+	e.SetRows(src.Rows)
+	e.SetFilePath(src.FilePath.String)
+	e.SetErr(src.Error.String)
+
+	if src.FinishedAt.Valid {
+		e.SetFinishedAt(src.FinishedAt.Time)
+	}
+
+	return *e
+}
+
+func (e *ExportJob) ToDB() model.ExportJobs {
+	return model.ExportJobs{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        e.ID(),
+				CreatedAt: e.CreatedAt(),
+				UpdatedAt: e.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(
+					sql.NullTime{
+						Time: e.DeletedAt(),
+						Valid: func() bool {
+							if e.DeletedAt().IsZero() {
+								return false
+							}
+							return true
+						}(),
+					},
+				),
+			},
+			Uuid: e.UUID(),
+		},
+		TenantID: e.TenantID(),
+		Resource: string(e.Resource()),
+		Format:   string(e.Format()),
+		Filters: sql.NullString{
+			String: e.Filters(),
+			Valid:  len(e.Filters()) > 0,
+		},
+		Status: e.Status(),
+		Rows:   e.Rows(),
+		FilePath: sql.NullString{
+			String: e.FilePath(),
+			Valid:  len(e.FilePath()) > 0,
+		},
+		Error: sql.NullString{
+			String: e.Err(),
+			Valid:  len(e.Err()) > 0,
+		},
+		FinishedAt: sql.NullTime{
+			Time:  e.FinishedAt(),
+			Valid: !e.FinishedAt().IsZero(),
+		},
+	}
+}
@@ -2,8 +2,10 @@ package domain
 
 import (
 	"database/sql"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"microservice/internal/model"
+	"time"
 )
 
 type (
@@ -12,11 +14,39 @@ type (
 		Base
 		channel     string
 		tenantId    uint
+		tenant      Tenant
 		mobile      string
 		messageText string
 		messageHash string
 		status      string
+		segments    int
+		encoding    string
+		jobId       uuid.UUID
+		sendAt      time.Time
+		validUntil  time.Time
+		template    Template
+		variables   map[string]string
+		providerId  string
+		dlrState    string
+		dlrAt       time.Time
+		sender      string
+		clientRef   string
+		operator    Operator
+		policy      PolicyVerdict
+		trackLinks  bool
+		shortLinks  []ShortLink
+		clicks      int64
+		price       float64
 		outbox      Outbox
+		history     []MessageStatusChange
+		// idempotencyKey the client key of the send request, it is not persisted
+		idempotencyKey string
+	}
+
+	// MessageStatusChange a single transition of the message status timeline
+	MessageStatusChange struct {
+		status    string
+		createdAt time.Time
 	}
 
 	MessageList struct {
@@ -31,6 +61,11 @@ const (
 	MsgSent      MessageStatus = "sent"
 	MsgDelivered MessageStatus = "delivered"
 	MsgFailed    MessageStatus = "failed"
+	MsgScheduled MessageStatus = "scheduled"
+	MsgCanceled  MessageStatus = "canceled"
+	MsgExpired   MessageStatus = "expired" // the validity period passed before the message is sent
+	MsgHeld      MessageStatus = "held"    // the content policy holds the message for the admin review
+	MsgRejected  MessageStatus = "rejected"
 )
 
 func NewMessage() *Message {
@@ -85,6 +120,213 @@ func (m *Message) SetStatus(status string) {
 	m.status = status
 }
 
+// Segments the count of the SMS parts needed to send the message text
+func (m *Message) Segments() int {
+	return m.segments
+}
+
+func (m *Message) SetSegments(segments int) {
+	m.segments = segments
+}
+
+// Encoding the message text encoding, `GSM-7` or `UCS-2`
+func (m *Message) Encoding() string {
+	return m.encoding
+}
+
+func (m *Message) SetEncoding(encoding string) {
+	m.encoding = encoding
+}
+
+// JobID the bulk send job which the message belongs to (uuid.Nil for single messages)
+func (m *Message) JobID() uuid.UUID {
+	return m.jobId
+}
+
+func (m *Message) SetJobID(jobId uuid.UUID) {
+	m.jobId = jobId
+}
+
+// SendAt the scheduled time to send the message (zero for immediate messages)
+func (m *Message) SendAt() time.Time {
+	return m.sendAt
+}
+
+func (m *Message) SetSendAt(sendAt time.Time) {
+	m.sendAt = sendAt
+}
+
+// IsScheduled reports whether the message has to be held until its send time
+func (m *Message) IsScheduled() bool {
+	return m.sendAt.After(time.Now())
+}
+
+// ValidUntil the time after which the message is worthless and not sent anymore (zero for no expiry)
+func (m *Message) ValidUntil() time.Time {
+	return m.validUntil
+}
+
+func (m *Message) SetValidUntil(validUntil time.Time) {
+	m.validUntil = validUntil
+}
+
+// Template the tenant template which the message text is rendered from (zero for raw text messages)
+func (m *Message) Template() Template {
+	return m.template
+}
+
+func (m *Message) SetTemplate(template Template) {
+	m.template = template
+}
+
+// UsesTemplate reports whether the message text has to be rendered from a template
+func (m *Message) UsesTemplate() bool {
+	return m.template.ID() != 0 || m.template.UUID() != uuid.Nil
+}
+
+// Variables the values of the template placeholders
+func (m *Message) Variables() map[string]string {
+	return m.variables
+}
+
+func (m *Message) SetVariables(variables map[string]string) {
+	m.variables = variables
+}
+
+// ProviderMessageID the message id which the SMS provider has returned on sending
+func (m *Message) ProviderMessageID() string {
+	return m.providerId
+}
+
+func (m *Message) SetProviderMessageID(providerId string) {
+	m.providerId = providerId
+}
+
+// DlrState the raw delivery state reported by the SMS provider
+func (m *Message) DlrState() string {
+	return m.dlrState
+}
+
+func (m *Message) SetDlrState(dlrState string) {
+	m.dlrState = dlrState
+}
+
+// DlrAt the time the SMS provider has reported the final delivery state
+func (m *Message) DlrAt() time.Time {
+	return m.dlrAt
+}
+
+func (m *Message) SetDlrAt(dlrAt time.Time) {
+	m.dlrAt = dlrAt
+}
+
+// Sender the approved sender id or line number of the tenant (empty for the provider default line)
+func (m *Message) Sender() string {
+	return m.sender
+}
+
+func (m *Message) SetSender(sender string) {
+	m.sender = sender
+}
+
+// ClientRef the tenant own reference of the message
+func (m *Message) ClientRef() string {
+	return m.clientRef
+}
+
+func (m *Message) SetClientRef(clientRef string) {
+	m.clientRef = clientRef
+}
+
+// Operator the recipient operator, the message is priced by its rate card
+func (m *Message) Operator() Operator {
+	return m.operator
+}
+
+func (m *Message) SetOperator(operator Operator) {
+	m.operator = operator
+}
+
+// Tenant the owner tenant, loaded for the admin lists
+func (m *Message) Tenant() Tenant {
+	return m.tenant
+}
+
+func (m *Message) SetTenant(tenant Tenant) {
+	m.tenant = tenant
+}
+
+// Policy the content policy action taken on the message, the hold or the flag along with the matched rule
+func (m *Message) Policy() PolicyVerdict {
+	return m.policy
+}
+
+func (m *Message) SetPolicy(policy PolicyVerdict) {
+	m.policy = policy
+}
+
+// TrackLinks the links of the message text are replaced by the short links, and their clicks are counted
+func (m *Message) TrackLinks() bool {
+	return m.trackLinks
+}
+
+func (m *Message) SetTrackLinks(trackLinks bool) {
+	m.trackLinks = trackLinks
+}
+
+// ShortLinks the short links of the message text, created along with the message
+func (m *Message) ShortLinks() []ShortLink {
+	return m.shortLinks
+}
+
+func (m *Message) SetShortLinks(shortLinks []ShortLink) {
+	m.shortLinks = shortLinks
+}
+
+// Clicks the count of the short link clicks of the message
+func (m *Message) Clicks() int64 {
+	return m.clicks
+}
+
+func (m *Message) SetClicks(clicks int64) {
+	m.clicks = clicks
+}
+
+// Price the credit charged for the message
+func (m *Message) Price() float64 {
+	return m.price
+}
+
+func (m *Message) SetPrice(price float64) {
+	m.price = price
+}
+
+func (m *Message) Outbox() Outbox {
+	return m.outbox
+}
+
+func (m *Message) SetOutbox(outbox Outbox) {
+	m.outbox = outbox
+}
+
+// History the status timeline of the message, in order of occurrence
+func (m *Message) History() []MessageStatusChange {
+	return m.history
+}
+
+func (m *Message) SetHistory(history []MessageStatusChange) {
+	m.history = history
+}
+
+// IdempotencyKey the client key which replays the former accepted message on the retried requests
+func (m *Message) IdempotencyKey() string {
+	return m.idempotencyKey
+}
+
+func (m *Message) SetIdempotencyKey(idempotencyKey string) {
+	m.idempotencyKey = idempotencyKey
+}
+
 //
 
 func (m *Message) FromDB(src model.Messages) Message {
@@ -100,9 +342,72 @@ func (m *Message) FromDB(src model.Messages) Message {
 	m.SetMessageText(src.MessageText)
 	m.SetMessageHash(src.MessageHash)
 	m.SetStatus(src.Status)
+	m.SetSegments(src.Segments)
+	m.SetEncoding(src.Encoding)
+	m.SetTrackLinks(src.TrackLinks)
+
+	if src.JobUuid.Valid {
+		m.SetJobID(src.JobUuid.UUID)
+	}
+
+	if src.SendAt.Valid {
+		m.SetSendAt(src.SendAt.Time)
+	}
+
+	if src.ValidUntil.Valid {
+		m.SetValidUntil(src.ValidUntil.Time)
+	}
+
+	if src.ProviderMessageID.Valid {
+		m.SetProviderMessageID(src.ProviderMessageID.String)
+	}
+
+	if src.DlrState.Valid {
+		m.SetDlrState(src.DlrState.String)
+	}
+
+	if src.DlrAt.Valid {
+		m.SetDlrAt(src.DlrAt.Time)
+	}
+
+	if src.Sender.Valid {
+		m.SetSender(src.Sender.String)
+	}
+
+	if src.ClientRef.Valid {
+		m.SetClientRef(src.ClientRef.String)
+	}
+
+	if src.Operator.Valid {
+		m.SetOperator(Operator(src.Operator.String))
+	}
+
+	if src.PolicyAction.Valid {
+		m.SetPolicy(PolicyVerdict{action: PolicyAction(src.PolicyAction.String), reason: src.PolicyReason.String})
+	}
+
+	if src.Tenant.ID != 0 {
+		m.SetTenant(NewTenant().FromDB(src.Tenant))
+	}
+
+	if src.TemplateID.Valid {
+		template := NewTemplate()
+		template.SetID(uint(src.TemplateID.Int64))
+		m.SetTemplate(*template)
+	}
 
 	if src.Outbox.ID != 0 {
 		m.SetChannel(src.Outbox.EventType)
+		m.SetOutbox(NewOutbox().FromDB(src.Outbox))
+	}
+
+	if len(src.History) > 0 {
+		history := make([]MessageStatusChange, 0, len(src.History))
+		for _, item := range src.History {
+			history = append(history, MessageStatusChange{status: item.Status, createdAt: item.CreatedAt})
+		}
+
+		m.SetHistory(history)
 	}
 
 	return *m
@@ -134,11 +439,68 @@ func (m *Message) ToDB() model.Messages {
 		MessageText: m.MessageText(),
 		MessageHash: m.MessageHash(),
 		Status:      m.Status(),
+		Segments:    m.Segments(),
+		Encoding:    m.Encoding(),
+		JobUuid: uuid.NullUUID{
+			UUID:  m.JobID(),
+			Valid: m.JobID() != uuid.Nil,
+		},
+		SendAt: sql.NullTime{
+			Time:  m.SendAt(),
+			Valid: !m.SendAt().IsZero(),
+		},
+		ValidUntil: sql.NullTime{
+			Time:  m.ValidUntil(),
+			Valid: !m.ValidUntil().IsZero(),
+		},
+		TemplateID: sql.NullInt64{
+			Int64: int64(m.template.ID()),
+			Valid: m.template.ID() != 0,
+		},
+		ProviderMessageID: sql.NullString{
+			String: m.ProviderMessageID(),
+			Valid:  len(m.ProviderMessageID()) > 0,
+		},
+		DlrState: sql.NullString{
+			String: m.DlrState(),
+			Valid:  len(m.DlrState()) > 0,
+		},
+		DlrAt: sql.NullTime{
+			Time:  m.DlrAt(),
+			Valid: !m.DlrAt().IsZero(),
+		},
+		Sender: sql.NullString{
+			String: m.Sender(),
+			Valid:  len(m.Sender()) > 0,
+		},
+		ClientRef: sql.NullString{
+			String: m.ClientRef(),
+			Valid:  len(m.ClientRef()) > 0,
+		},
+		Operator: sql.NullString{
+			String: string(m.Operator()),
+			Valid:  len(m.Operator()) > 0,
+		},
+		PolicyAction: sql.NullString{
+			String: string(m.policy.Action()),
+			Valid:  !m.policy.Allowed(),
+		},
+		PolicyReason: sql.NullString{
+			String: m.policy.Reason(),
+			Valid:  len(m.policy.Reason()) > 0,
+		},
+		TrackLinks: m.TrackLinks(),
 	}
 }
 
 //
 
+func (sc MessageStatusChange) Status() string { return sc.status }
+
+func (sc MessageStatusChange) CreatedAt() time.Time { return sc.createdAt }
+
+//
+
 func NewMessageList() *MessageList { return &MessageList{} }
 
 func (ul *MessageList) List() []Message { return ul.list }
@@ -182,7 +544,15 @@ func (ul *MessageList) ListFromDB(src []model.Messages) MessageList {
 
 type MessageListReqQryParam struct {
 	ReqBaseQryParam
-	tenantId uint
+	tenantId     uint
+	status       string
+	channel      string
+	mobile       string
+	mobilePrefix string
+	policyAction PolicyAction
+	createdFrom  time.Time
+	createdTo    time.Time
+	clientRef    string
 }
 
 func NewMessageListReqQryParam() *MessageListReqQryParam {
@@ -196,3 +566,72 @@ func (m *MessageListReqQryParam) TenantId() uint {
 func (m *MessageListReqQryParam) SetTenantId(tenantId uint) {
 	m.tenantId = tenantId
 }
+
+func (m *MessageListReqQryParam) Status() string {
+	return m.status
+}
+
+func (m *MessageListReqQryParam) SetStatus(status string) {
+	m.status = status
+}
+
+func (m *MessageListReqQryParam) Channel() string {
+	return m.channel
+}
+
+func (m *MessageListReqQryParam) SetChannel(channel string) {
+	m.channel = channel
+}
+
+// Mobile the exact recipient mobile
+func (m *MessageListReqQryParam) Mobile() string {
+	return m.mobile
+}
+
+func (m *MessageListReqQryParam) SetMobile(mobile string) {
+	m.mobile = mobile
+}
+
+// MobilePrefix the leading digits of the recipient mobile, like an operator prefix
+func (m *MessageListReqQryParam) MobilePrefix() string {
+	return m.mobilePrefix
+}
+
+func (m *MessageListReqQryParam) SetMobilePrefix(mobilePrefix string) {
+	m.mobilePrefix = mobilePrefix
+}
+
+// PolicyAction the messages held or flagged by the content policy
+func (m *MessageListReqQryParam) PolicyAction() PolicyAction {
+	return m.policyAction
+}
+
+func (m *MessageListReqQryParam) SetPolicyAction(policyAction PolicyAction) {
+	m.policyAction = policyAction
+}
+
+// CreatedFrom the inclusive start of the creation time range (zero for no bound)
+func (m *MessageListReqQryParam) CreatedFrom() time.Time {
+	return m.createdFrom
+}
+
+func (m *MessageListReqQryParam) SetCreatedFrom(createdFrom time.Time) {
+	m.createdFrom = createdFrom
+}
+
+// CreatedTo the exclusive end of the creation time range (zero for no bound)
+func (m *MessageListReqQryParam) CreatedTo() time.Time {
+	return m.createdTo
+}
+
+func (m *MessageListReqQryParam) SetCreatedTo(createdTo time.Time) {
+	m.createdTo = createdTo
+}
+
+func (m *MessageListReqQryParam) ClientRef() string {
+	return m.clientRef
+}
+
+func (m *MessageListReqQryParam) SetClientRef(clientRef string) {
+	m.clientRef = clientRef
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+type (
+	MessageBulkResult string
+
+	MessageBulk struct {
+		jobId       uuid.UUID
+		tenantId    uint
+		channel     string
+		messageText string
+		segments    int
+		encoding    string
+		recipients  []string
+		items       []MessageBulkItem
+		progress    map[string]int64
+	}
+
+	MessageBulkItem struct {
+		mobile  string
+		result  MessageBulkResult
+		message Message
+	}
+)
+
+const (
+	BulkAccepted      MessageBulkResult = "accepted"
+	BulkInvalidNumber MessageBulkResult = "invalid_number"
+	BulkDuplicate     MessageBulkResult = "duplicate"
+	BulkBlocked       MessageBulkResult = "blocked"
+)
+
+func NewMessageBulk() *MessageBulk {
+	return &MessageBulk{}
+}
+
+func (mb *MessageBulk) JobID() uuid.UUID {
+	return mb.jobId
+}
+
+func (mb *MessageBulk) SetJobID(jobId uuid.UUID) {
+	mb.jobId = jobId
+}
+
+func (mb *MessageBulk) TenantID() uint {
+	return mb.tenantId
+}
+
+func (mb *MessageBulk) SetTenantID(tenantId uint) {
+	mb.tenantId = tenantId
+}
+
+func (mb *MessageBulk) Channel() string {
+	return mb.channel
+}
+
+func (mb *MessageBulk) SetChannel(channel string) {
+	mb.channel = channel
+}
+
+func (mb *MessageBulk) MessageText() string {
+	return mb.messageText
+}
+
+func (mb *MessageBulk) SetMessageText(messageText string) {
+	mb.messageText = messageText
+}
+
+func (mb *MessageBulk) Segments() int {
+	return mb.segments
+}
+
+func (mb *MessageBulk) SetSegments(segments int) {
+	mb.segments = segments
+}
+
+func (mb *MessageBulk) Encoding() string {
+	return mb.encoding
+}
+
+func (mb *MessageBulk) SetEncoding(encoding string) {
+	mb.encoding = encoding
+}
+
+func (mb *MessageBulk) Recipients() []string {
+	return mb.recipients
+}
+
+func (mb *MessageBulk) SetRecipients(recipients []string) {
+	mb.recipients = recipients
+}
+
+func (mb *MessageBulk) Items() []MessageBulkItem {
+	return mb.items
+}
+
+func (mb *MessageBulk) SetItems(items []MessageBulkItem) {
+	mb.items = items
+}
+
+func (mb *MessageBulk) AddItem(item MessageBulkItem) {
+	mb.items = append(mb.items, item)
+}
+
+// Accepted returns the count of the recipients which are accepted to be sent
+func (mb *MessageBulk) Accepted() int {
+	count := 0
+	for _, item := range mb.items {
+		if item.Result() == BulkAccepted {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Progress the job messages count per message status
+func (mb *MessageBulk) Progress() map[string]int64 {
+	return mb.progress
+}
+
+func (mb *MessageBulk) SetProgress(progress map[string]int64) {
+	mb.progress = progress
+}
+
+// Message builds the single message of the bulk for the given recipient
+func (mb *MessageBulk) Message(mobile string) Message {
+	m := NewMessage()
+	m.SetTenantID(mb.TenantID())
+	m.SetChannel(mb.Channel())
+	m.SetMobile(mobile)
+	m.SetMessageText(mb.MessageText())
+	m.SetSegments(mb.Segments())
+	m.SetEncoding(mb.Encoding())
+	m.SetJobID(mb.JobID())
+	return *m
+}
+
+//
+
+func NewMessageBulkItem(mobile string, result MessageBulkResult) *MessageBulkItem {
+	return &MessageBulkItem{mobile: mobile, result: result}
+}
+
+func (mi *MessageBulkItem) Mobile() string {
+	return mi.mobile
+}
+
+func (mi *MessageBulkItem) Result() MessageBulkResult {
+	return mi.result
+}
+
+func (mi *MessageBulkItem) Message() Message {
+	return mi.message
+}
+
+func (mi *MessageBulkItem) SetMessage(message Message) {
+	mi.message = message
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MessageDlr the delivery report of a sent message, received from the SMS provider
+type MessageDlr struct {
+	providerId string
+	state      string
+	reportedAt time.Time
+}
+
+// dlrStates maps the provider delivery states onto the final message statuses.
+// the intermediate states (like `ENROUTE` or `ACCEPTD`) are not listed and do not change the message
+var dlrStates = map[string]MessageStatus{
+	"DELIVRD":     MsgDelivered,
+	"DELIVERED":   MsgDelivered,
+	"UNDELIV":     MsgFailed,
+	"UNDELIVERED": MsgFailed,
+	"REJECTD":     MsgFailed,
+	"REJECTED":    MsgFailed,
+	"EXPIRED":     MsgFailed,
+	"DELETED":     MsgFailed,
+	"FAILED":      MsgFailed,
+}
+
+func NewMessageDlr() *MessageDlr {
+	return &MessageDlr{}
+}
+
+func (d *MessageDlr) ProviderMessageID() string {
+	return d.providerId
+}
+
+func (d *MessageDlr) SetProviderMessageID(providerId string) {
+	d.providerId = providerId
+}
+
+func (d *MessageDlr) State() string {
+	return d.state
+}
+
+func (d *MessageDlr) SetState(state string) {
+	d.state = strings.ToUpper(strings.TrimSpace(state))
+}
+
+func (d *MessageDlr) ReportedAt() time.Time {
+	return d.reportedAt
+}
+
+func (d *MessageDlr) SetReportedAt(reportedAt time.Time) {
+	d.reportedAt = reportedAt
+}
+
+// Status the final message status of the reported state. `ok` is false for the intermediate states
+func (d *MessageDlr) Status() (status MessageStatus, ok bool) {
+	status, ok = dlrStates[d.state]
+	return
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"microservice/internal/model"
+	"strings"
+	"time"
+)
+
+type (
+	// MessageInbound the mobile originated message, received from the SMS provider
+	MessageInbound struct {
+		Base
+		tenantId    uint
+		mobile      string
+		receiver    string
+		messageText string
+		receivedAt  time.Time
+		optedOut    bool
+	}
+
+	MessageInboundList struct {
+		BaseList
+		list []MessageInbound
+	}
+)
+
+// optOutKeywords the recipient replies which block the tenant messages
+var optOutKeywords = map[string]bool{
+	"STOP":        true,
+	"STOPALL":     true,
+	"UNSUBSCRIBE": true,
+	"END":         true,
+	"لغو":         true,
+	"توقف":        true,
+}
+
+func NewMessageInbound() *MessageInbound {
+	return &MessageInbound{}
+}
+
+// TenantID the tenant which the message is replied to
+func (m *MessageInbound) TenantID() uint {
+	return m.tenantId
+}
+
+func (m *MessageInbound) SetTenantID(tenantId uint) {
+	m.tenantId = tenantId
+}
+
+// Mobile the sender mobile
+func (m *MessageInbound) Mobile() string {
+	return m.mobile
+}
+
+func (m *MessageInbound) SetMobile(mobile string) {
+	m.mobile = mobile
+}
+
+// Receiver the destination line of the message, the approved sender of the owning tenant
+func (m *MessageInbound) Receiver() string {
+	return m.receiver
+}
+
+func (m *MessageInbound) SetReceiver(receiver string) {
+	m.receiver = receiver
+}
+
+func (m *MessageInbound) MessageText() string {
+	return m.messageText
+}
+
+func (m *MessageInbound) SetMessageText(messageText string) {
+	m.messageText = messageText
+}
+
+func (m *MessageInbound) ReceivedAt() time.Time {
+	return m.receivedAt
+}
+
+func (m *MessageInbound) SetReceivedAt(receivedAt time.Time) {
+	m.receivedAt = receivedAt
+}
+
+// OptedOut reports whether the mobile is blocked by the message
+func (m *MessageInbound) OptedOut() bool {
+	return m.optedOut
+}
+
+func (m *MessageInbound) SetOptedOut(optedOut bool) {
+	m.optedOut = optedOut
+}
+
+// IsOptOut reports whether the message text is an opt-out keyword, like `STOP`
+func (m *MessageInbound) IsOptOut() bool {
+	return optOutKeywords[strings.ToUpper(strings.TrimSpace(m.messageText))]
+}
+
+//
+
+func (m *MessageInbound) FromDB(src model.InboundMessages) MessageInbound {
+	// base
+	m.SetID(src.ID)
+	m.SetUUID(src.Uuid)
+	m.SetCreatedAt(src.CreatedAt)
+	m.SetUpdatedAt(src.UpdatedAt)
+	m.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	m.SetTenantID(src.TenantID)
+	m.SetMobile(src.Mobile)
+	m.SetReceiver(src.Receiver.String)
+	m.SetMessageText(src.MessageText)
+	m.SetOptedOut(src.OptedOut)
+	m.SetReceivedAt(src.ReceivedAt)
+
+	return *m
+}
+
+func (m *MessageInbound) ToDB() model.InboundMessages {
+	return model.InboundMessages{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        m.ID(),
+				CreatedAt: m.CreatedAt(),
+				UpdatedAt: m.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(
+					sql.NullTime{
+						Time: m.DeletedAt(),
+						Valid: func() bool {
+							if m.DeletedAt().IsZero() {
+								return false
+							}
+							return true
+						}(),
+					},
+				),
+			},
+			Uuid: m.UUID(),
+		},
+		TenantID: m.TenantID(),
+		Mobile:   m.Mobile(),
+		Receiver: sql.NullString{
+			String: m.Receiver(),
+			Valid:  len(m.Receiver()) > 0,
+		},
+		MessageText: m.MessageText(),
+		OptedOut:    m.OptedOut(),
+		ReceivedAt:  m.ReceivedAt(),
+	}
+}
+
+//
+
+func NewMessageInboundList() *MessageInboundList { return &MessageInboundList{} }
+
+func (ul *MessageInboundList) List() []MessageInbound { return ul.list }
+
+func (ul *MessageInboundList) SetList(list []MessageInbound) { ul.list = list }
+
+func (ul *MessageInboundList) ListFromDB(src []model.InboundMessages) MessageInboundList {
+	ul.list = make([]MessageInbound, 0)
+
+	total := len(src)
+	if ul.total == 0 && total > 0 {
+		ul.total = int64(total)
+	}
+
+	if ul.total == 0 {
+		return *ul
+	}
+
+	for _, item := range src {
+		ul.list = append(ul.list, NewMessageInbound().FromDB(item))
+	}
+
+	return *ul
+}
+
+//
+
+type MessageInboundListReqQryParam struct {
+	ReqBaseQryParam
+	tenantId uint
+}
+
+func NewMessageInboundListReqQryParam() *MessageInboundListReqQryParam {
+	return &MessageInboundListReqQryParam{}
+}
+
+func (m *MessageInboundListReqQryParam) TenantId() uint {
+	return m.tenantId
+}
+
+func (m *MessageInboundListReqQryParam) SetTenantId(tenantId uint) {
+	m.tenantId = tenantId
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+)
+
+type (
+	// Otp a one-time password sent to the mobile of the tenant user
+	Otp struct {
+		tenantId uint
+		mobile   string
+		code     string
+		message  Message
+		expireAt time.Time
+	}
+)
+
+func NewOtp() *Otp {
+	return &Otp{}
+}
+
+func (o *Otp) TenantID() uint {
+	return o.tenantId
+}
+
+func (o *Otp) SetTenantID(tenantId uint) {
+	o.tenantId = tenantId
+}
+
+func (o *Otp) Mobile() string {
+	return o.mobile
+}
+
+func (o *Otp) SetMobile(mobile string) {
+	o.mobile = mobile
+}
+
+// Code the plain code, it is only held in memory to be sent or verified
+func (o *Otp) Code() string {
+	return o.code
+}
+
+func (o *Otp) SetCode(code string) {
+	o.code = code
+}
+
+// Message the SMS which carries the code
+func (o *Otp) Message() Message {
+	return o.message
+}
+
+func (o *Otp) SetMessage(message Message) {
+	o.message = message
+}
+
+func (o *Otp) ExpireAt() time.Time {
+	return o.expireAt
+}
+
+func (o *Otp) SetExpireAt(expireAt time.Time) {
+	o.expireAt = expireAt
+}
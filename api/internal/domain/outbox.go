@@ -34,6 +34,7 @@ const (
 	OutboxPublishing OutboxStatus = "publishing"
 	OutboxPublished  OutboxStatus = "published"
 	OutboxFailed     OutboxStatus = "failed"
+	OutboxExpired    OutboxStatus = "expired"
 )
 
 func NewOutbox() *Outbox {
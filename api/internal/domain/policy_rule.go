@@ -0,0 +1,384 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"microservice/internal/model"
+	"microservice/pkg/validator"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	PolicyRuleType string
+	PolicyAction   string
+	// PolicyRule the content rule of the messages, evaluated on sending
+	PolicyRule struct {
+		Base
+		tenantId    uint
+		tenant      Tenant
+		ruleType    PolicyRuleType
+		value       string
+		action      PolicyAction
+		description string
+	}
+
+	// PolicyVerdict the strictest action of the matched rules, the empty action allows the message
+	PolicyVerdict struct {
+		action PolicyAction
+		reason string
+	}
+
+	PolicyRuleList struct {
+		BaseList
+		list []PolicyRule
+	}
+)
+
+const (
+	RuleKeyword PolicyRuleType = "keyword" // the case-insensitive keyword in the text
+	RuleRegex   PolicyRuleType = "regex"   // the pattern matched in the text
+	// RuleDomainAllowlist the comma separated domains, the links to any other domain match the rule
+	RuleDomainAllowlist PolicyRuleType = "domain_allowlist"
+	// RuleMaxLinks the link count, the messages of more links match the rule
+	RuleMaxLinks PolicyRuleType = "max_links"
+)
+
+const (
+	PolicyReject PolicyAction = "reject" // the message is not accepted
+	PolicyHold   PolicyAction = "hold"   // the message is charged and held for the admin review
+	PolicyFlag   PolicyAction = "flag"   // the message is sent and flagged for the later audit
+)
+
+// GlobalPolicy the tenant id of the global rules, applied to all tenants
+const GlobalPolicy uint = 0
+
+var ErrInvalidPolicyRule = errors.New("invalid policy rule")
+
+// policySeverity the stricter action wins when several rules match
+var policySeverity = map[PolicyAction]int{PolicyFlag: 1, PolicyHold: 2, PolicyReject: 3}
+
+// rulePatterns the compiled patterns of the regex rules by their value, each pattern is compiled once
+var rulePatterns sync.Map
+
+func NewPolicyRule() *PolicyRule {
+	return &PolicyRule{}
+}
+
+// TenantID the owner tenant of the rule (GlobalPolicy for the global rules)
+func (p *PolicyRule) TenantID() uint {
+	return p.tenantId
+}
+
+func (p *PolicyRule) SetTenantID(tenantId uint) {
+	p.tenantId = tenantId
+}
+
+// Tenant the owner tenant requested by its uuid, resolved to the tenant id
+func (p *PolicyRule) Tenant() Tenant {
+	return p.tenant
+}
+
+func (p *PolicyRule) SetTenant(tenant Tenant) {
+	p.tenant = tenant
+}
+
+func (p *PolicyRule) Type() PolicyRuleType {
+	return p.ruleType
+}
+
+func (p *PolicyRule) SetType(ruleType PolicyRuleType) {
+	p.ruleType = ruleType
+}
+
+func (p *PolicyRule) Value() string {
+	return p.value
+}
+
+func (p *PolicyRule) SetValue(value string) {
+	p.value = value
+}
+
+func (p *PolicyRule) Action() PolicyAction {
+	return p.action
+}
+
+func (p *PolicyRule) SetAction(action PolicyAction) {
+	p.action = action
+}
+
+func (p *PolicyRule) Description() string {
+	return p.description
+}
+
+func (p *PolicyRule) SetDescription(description string) {
+	p.description = description
+}
+
+// IsGlobal reports whether the rule is applied to all tenants
+func (p *PolicyRule) IsGlobal() bool {
+	return p.tenantId == GlobalPolicy
+}
+
+// Reason the description of the rule, or else its type and value
+func (p *PolicyRule) Reason() string {
+	if len(p.description) > 0 {
+		return p.description
+	}
+
+	return fmt.Sprintf("%s: %s", p.ruleType, p.value)
+}
+
+// Validate the regex pattern has to compile, the max links has to be a count and the allowlist has to name domains
+func (p *PolicyRule) Validate() error {
+	switch p.ruleType {
+	case RuleRegex:
+		if _, err := regexp.Compile(p.value); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidPolicyRule, err)
+		}
+	case RuleMaxLinks:
+		if n, err := strconv.Atoi(p.value); err != nil || n < 0 {
+			return fmt.Errorf("%w: %q is not a count", ErrInvalidPolicyRule, p.value)
+		}
+	case RuleDomainAllowlist:
+		if len(p.domains()) == 0 {
+			return fmt.Errorf("%w: no domain", ErrInvalidPolicyRule)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether the text violates the rule
+func (p *PolicyRule) Matches(text string) bool {
+	switch p.ruleType {
+	case RuleKeyword:
+		return len(p.value) > 0 && strings.Contains(strings.ToLower(text), strings.ToLower(p.value))
+	case RuleRegex:
+		pattern, err := rulePattern(p.value)
+		return err == nil && pattern.MatchString(text)
+	case RuleMaxLinks:
+		limit, err := strconv.Atoi(p.value)
+		return err == nil && len(ExtractLinks(text)) > limit
+	case RuleDomainAllowlist:
+		allowed := p.domains()
+		for _, link := range ExtractLinks(text) {
+			if !domainAllowed(LinkDomain(link), allowed) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// rulePattern the compiled pattern of the regex rule, compiled on its first evaluation
+func rulePattern(value string) (*regexp.Regexp, error) {
+	if pattern, ok := rulePatterns.Load(value); ok {
+		return pattern.(*regexp.Regexp), nil
+	}
+
+	pattern, err := regexp.Compile(value)
+	if err != nil {
+		return nil, err
+	}
+
+	rulePatterns.Store(value, pattern)
+	return pattern, nil
+}
+
+func (p *PolicyRule) domains() []string {
+	domains := make([]string, 0)
+	for _, domain := range strings.Split(p.value, ",") {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); len(domain) > 0 {
+			domains = append(domains, domain)
+		}
+	}
+
+	return domains
+}
+
+//
+
+func (p *PolicyRule) FromDB(src model.PolicyRules) PolicyRule {
+	// base
+	p.SetID(src.ID)
+	p.SetUUID(src.Uuid)
+	p.SetCreatedAt(src.CreatedAt)
+	p.SetUpdatedAt(src.UpdatedAt)
+	p.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	p.SetTenantID(uint(src.TenantID.Int64))
+	p.SetType(PolicyRuleType(src.Type))
+	p.SetValue(src.Value)
+	p.SetAction(PolicyAction(src.Action))
+
+	if src.Description.Valid {
+		p.SetDescription(src.Description.String)
+	}
+
+	return *p
+}
+
+func (p *PolicyRule) ToDB() model.PolicyRules {
+	return model.PolicyRules{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        p.ID(),
+				CreatedAt: p.CreatedAt(),
+				UpdatedAt: p.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(
+					sql.NullTime{
+						Time: p.DeletedAt(),
+						Valid: func() bool {
+							if p.DeletedAt().IsZero() {
+								return false
+							}
+							return true
+						}(),
+					},
+				),
+			},
+			Uuid: p.UUID(),
+		},
+		TenantID: sql.NullInt64{
+			Int64: int64(p.TenantID()),
+			Valid: !p.IsGlobal(),
+		},
+		Type:   string(p.Type()),
+		Value:  p.Value(),
+		Action: string(p.Action()),
+		Description: sql.NullString{
+			String: p.Description(),
+			Valid:  len(p.Description()) > 0,
+		},
+	}
+}
+
+//
+
+// EvaluatePolicy the verdict of the strictest matched rule, the first one of the equals
+func EvaluatePolicy(rules []PolicyRule, text string) (res PolicyVerdict) {
+	for _, rule := range rules {
+		if policySeverity[rule.Action()] <= policySeverity[res.action] || !rule.Matches(text) {
+			continue
+		}
+
+		res = PolicyVerdict{action: rule.Action(), reason: rule.Reason()}
+	}
+
+	return
+}
+
+func (v PolicyVerdict) Action() PolicyAction { return v.action }
+
+func (v PolicyVerdict) Reason() string { return v.reason }
+
+// Allowed reports whether the message is sent without any action
+func (v PolicyVerdict) Allowed() bool { return len(v.action) == 0 }
+
+// ExtractLinks the links of the text, in order of occurrence, without the punctuations ending the sentence
+func ExtractLinks(text string) []string {
+	links := validator.LinkPattern.FindAllString(text, -1)
+	for i, link := range links {
+		links[i] = strings.TrimRight(link, linkTrailing)
+	}
+
+	return links
+}
+
+// LinkDomain the lower-cased host of the link, without the port
+func LinkDomain(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
+
+// domainAllowed the subdomains of an allowed domain are allowed too
+func domainAllowed(domain string, allowed []string) bool {
+	for _, item := range allowed {
+		if domain == item || strings.HasSuffix(domain, "."+item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//
+
+func NewPolicyRuleList() *PolicyRuleList { return &PolicyRuleList{} }
+
+func (ul *PolicyRuleList) List() []PolicyRule { return ul.list }
+
+func (ul *PolicyRuleList) SetList(list []PolicyRule) { ul.list = list }
+
+func (ul *PolicyRuleList) ListFromDB(src []model.PolicyRules) PolicyRuleList {
+	ul.list = make([]PolicyRule, 0)
+
+	total := len(src)
+	if ul.total == 0 && total > 0 {
+		ul.total = int64(total)
+	}
+
+	if ul.total == 0 {
+		return *ul
+	}
+
+	for _, item := range src {
+		ul.list = append(ul.list, NewPolicyRule().FromDB(item))
+	}
+
+	return *ul
+}
+
+//
+
+type PolicyRuleListReqQryParam struct {
+	ReqBaseQryParam
+	tenantId uint
+	tenant   Tenant
+	ruleType PolicyRuleType
+}
+
+func NewPolicyRuleListReqQryParam() *PolicyRuleListReqQryParam {
+	return &PolicyRuleListReqQryParam{}
+}
+
+// TenantId the rules owner (GlobalPolicy for the global rules)
+func (p *PolicyRuleListReqQryParam) TenantId() uint {
+	return p.tenantId
+}
+
+func (p *PolicyRuleListReqQryParam) SetTenantId(tenantId uint) {
+	p.tenantId = tenantId
+}
+
+// Tenant the rules owner requested by its uuid, resolved to the tenant id
+func (p *PolicyRuleListReqQryParam) Tenant() Tenant {
+	return p.tenant
+}
+
+func (p *PolicyRuleListReqQryParam) SetTenant(tenant Tenant) {
+	p.tenant = tenant
+}
+
+func (p *PolicyRuleListReqQryParam) Type() PolicyRuleType {
+	return p.ruleType
+}
+
+func (p *PolicyRuleListReqQryParam) SetType(ruleType PolicyRuleType) {
+	p.ruleType = ruleType
+}
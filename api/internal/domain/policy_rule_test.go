@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluatePolicy(t *testing.T) {
+	rule := func(ruleType PolicyRuleType, value string, action PolicyAction) PolicyRule {
+		r := NewPolicyRule()
+		r.SetType(ruleType)
+		r.SetValue(value)
+		r.SetAction(action)
+		return *r
+	}
+
+	tests := []struct {
+		name   string
+		rules  []PolicyRule
+		text   string
+		action PolicyAction
+		reason string
+	}{
+		{name: "no rules", text: "hello", action: ""},
+		{
+			name:   "keyword is case insensitive",
+			rules:  []PolicyRule{rule(RuleKeyword, "Lottery", PolicyHold)},
+			text:   "you won the LOTTERY",
+			action: PolicyHold,
+			reason: "keyword: Lottery",
+		},
+		{
+			name:  "keyword not matched",
+			rules: []PolicyRule{rule(RuleKeyword, "lottery", PolicyHold)},
+			text:  "your code is 1234",
+		},
+		{
+			name:   "regex",
+			rules:  []PolicyRule{rule(RuleRegex, `\bcard\s*\d{4}`, PolicyReject)},
+			text:   "send your card 1234 now",
+			action: PolicyReject,
+			reason: `regex: \bcard\s*\d{4}`,
+		},
+		{
+			name:  "invalid regex never matches",
+			rules: []PolicyRule{rule(RuleRegex, `(`, PolicyReject)},
+			text:  "(",
+		},
+		{
+			name:   "bare domain out of the allowlist",
+			rules:  []PolicyRule{rule(RuleDomainAllowlist, "example.com, shop.ir", PolicyReject)},
+			text:   "login at evil.com/login",
+			action: PolicyReject,
+			reason: "domain_allowlist: example.com, shop.ir",
+		},
+		{
+			name:  "subdomain of the allowlist",
+			rules: []PolicyRule{rule(RuleDomainAllowlist, "example.com", PolicyReject)},
+			text:  "see https://www.example.com/offer, and https://m.example.com.",
+		},
+		{
+			name:  "max links not exceeded",
+			rules: []PolicyRule{rule(RuleMaxLinks, "2", PolicyFlag)},
+			text:  "a.com and www.b.com",
+		},
+		{
+			name:   "max links exceeded",
+			rules:  []PolicyRule{rule(RuleMaxLinks, "1", PolicyFlag)},
+			text:   "a.com and www.b.com",
+			action: PolicyFlag,
+			reason: "max_links: 1",
+		},
+		{
+			name: "strictest action wins",
+			rules: []PolicyRule{
+				rule(RuleKeyword, "offer", PolicyFlag),
+				rule(RuleKeyword, "free", PolicyReject),
+				rule(RuleKeyword, "now", PolicyHold),
+			},
+			text:   "free offer now",
+			action: PolicyReject,
+			reason: "keyword: free",
+		},
+		{
+			name: "first of the equals wins",
+			rules: []PolicyRule{
+				rule(RuleKeyword, "offer", PolicyHold),
+				rule(RuleKeyword, "free", PolicyHold),
+			},
+			text:   "free offer",
+			action: PolicyHold,
+			reason: "keyword: offer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluatePolicy(tt.rules, tt.text)
+			if got.Action() != tt.action || got.Reason() != tt.reason {
+				t.Errorf("EvaluatePolicy() = %q, %q, want %q, %q", got.Action(), got.Reason(), tt.action, tt.reason)
+			}
+
+			if got.Allowed() != (len(tt.action) == 0) {
+				t.Errorf("Allowed() = %v, want %v", got.Allowed(), len(tt.action) == 0)
+			}
+		})
+	}
+}
+
+func TestExtractLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "no links", text: "your code is 1234", want: []string{}},
+		{name: "scheme", text: "open https://example.com/a?b=1.", want: []string{"https://example.com/a?b=1"}},
+		{name: "www", text: "visit www.example.com, now", want: []string{"www.example.com"}},
+		{name: "bare domain with path", text: "login at evil.com/login!", want: []string{"evil.com/login"}},
+		{name: "numbers are not domains", text: "pay 3.50 by v1.2", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractLinks(tt.text)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractLinks(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"microservice/internal/model"
+	"sort"
+)
+
+type (
+	PricingPlanType string
+
+	// PricingPlan the discount of the tenant on the rate card prices
+	PricingPlan struct {
+		Base
+		name        string
+		planType    PricingPlanType
+		discount    float64
+		description string
+		tiers       []PricingTier
+	}
+
+	// PricingTier the discount of the tiered plan from the monthly volume on
+	PricingTier struct {
+		minVolume int64
+		discount  float64
+	}
+
+	PricingPlanList struct {
+		BaseList
+		list []PricingPlan
+	}
+)
+
+const (
+	PlanStandard PricingPlanType = "standard" // the rate card prices
+	PlanTiered   PricingPlanType = "tiered"   // the discount of the reached monthly volume tier
+	PlanCustom   PricingPlanType = "custom"   // the flat discount of the contract
+)
+
+func NewPricingPlan() *PricingPlan {
+	return &PricingPlan{planType: PlanStandard}
+}
+
+func (p *PricingPlan) Name() string {
+	return p.name
+}
+
+func (p *PricingPlan) SetName(name string) {
+	p.name = name
+}
+
+func (p *PricingPlan) Type() PricingPlanType {
+	return p.planType
+}
+
+func (p *PricingPlan) SetType(planType PricingPlanType) {
+	p.planType = planType
+}
+
+// Discount the percent of the custom plan
+func (p *PricingPlan) Discount() float64 {
+	return p.discount
+}
+
+func (p *PricingPlan) SetDiscount(discount float64) {
+	p.discount = discount
+}
+
+func (p *PricingPlan) Description() string {
+	return p.description
+}
+
+func (p *PricingPlan) SetDescription(description string) {
+	p.description = description
+}
+
+// Tiers the volume tiers of the tiered plan, in order of the volume
+func (p *PricingPlan) Tiers() []PricingTier {
+	return p.tiers
+}
+
+func (p *PricingPlan) SetTiers(tiers []PricingTier) {
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].minVolume < tiers[j].minVolume
+	})
+
+	p.tiers = tiers
+}
+
+// EffectiveDiscount the discount percent of the plan for the monthly volume the tenant has sent so far
+func (p *PricingPlan) EffectiveDiscount(volume int64) float64 {
+	switch p.planType {
+	case PlanCustom:
+		return p.discount
+	case PlanTiered:
+		discount := 0.0
+		for _, tier := range p.tiers {
+			if volume < tier.minVolume {
+				break
+			}
+
+			discount = tier.discount
+		}
+
+		return discount
+	default:
+		return 0
+	}
+}
+
+//
+
+func NewPricingTier(minVolume int64, discount float64) *PricingTier {
+	return &PricingTier{minVolume: minVolume, discount: discount}
+}
+
+func (t PricingTier) MinVolume() int64 { return t.minVolume }
+
+func (t PricingTier) Discount() float64 { return t.discount }
+
+//
+
+func (p *PricingPlan) FromDB(src model.PricingPlans) PricingPlan {
+	// base
+	p.SetID(src.ID)
+	p.SetUUID(src.Uuid)
+	p.SetCreatedAt(src.CreatedAt)
+	p.SetUpdatedAt(src.UpdatedAt)
+	p.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	p.SetName(src.Name)
+	p.SetType(PricingPlanType(src.Type))
+	p.SetDiscount(src.Discount)
+
+	if src.Description.Valid {
+		p.SetDescription(src.Description.String)
+	}
+
+	tiers := make([]PricingTier, 0, len(src.Tiers))
+	for _, tier := range src.Tiers {
+		tiers = append(tiers, *NewPricingTier(tier.MinVolume, tier.Discount))
+	}
+
+	p.SetTiers(tiers)
+	return *p
+}
+
+func (p *PricingPlan) ToDB() model.PricingPlans {
+	tiers := make([]model.PricingPlanTiers, 0, len(p.tiers))
+	for _, tier := range p.tiers {
+		tiers = append(tiers, model.PricingPlanTiers{
+			PricingPlanID: p.ID(),
+			MinVolume:     tier.minVolume,
+			Discount:      tier.discount,
+		})
+	}
+
+	return model.PricingPlans{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        p.ID(),
+				CreatedAt: p.CreatedAt(),
+				UpdatedAt: p.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(sql.NullTime{Time: p.DeletedAt(), Valid: !p.DeletedAt().IsZero()}),
+			},
+			Uuid: p.UUID(),
+		},
+		Name:     p.Name(),
+		Type:     string(p.Type()),
+		Discount: p.Discount(),
+		Description: sql.NullString{
+			String: p.Description(),
+			Valid:  len(p.Description()) > 0,
+		},
+		Tiers: tiers,
+	}
+}
+
+//
+
+func NewPricingPlanList() *PricingPlanList { return &PricingPlanList{} }
+
+func (ul *PricingPlanList) List() []PricingPlan { return ul.list }
+
+func (ul *PricingPlanList) SetList(list []PricingPlan) { ul.list = list }
+
+func (ul *PricingPlanList) ListFromDB(src []model.PricingPlans) PricingPlanList {
+	ul.list = make([]PricingPlan, 0)
+
+	total := len(src)
+	if ul.total == 0 && total > 0 {
+		ul.total = int64(total)
+	}
+
+	if ul.total == 0 {
+		return *ul
+	}
+
+	for _, item := range src {
+		ul.list = append(ul.list, NewPricingPlan().FromDB(item))
+	}
+
+	return *ul
+}
+
+//
+
+type PricingPlanListReqQryParam struct {
+	ReqBaseQryParam
+	planType string
+}
+
+func NewPricingPlanListReqQryParam() *PricingPlanListReqQryParam {
+	return &PricingPlanListReqQryParam{}
+}
+
+func (p *PricingPlanListReqQryParam) Type() string {
+	return p.planType
+}
+
+func (p *PricingPlanListReqQryParam) SetType(planType string) {
+	p.planType = planType
+}
+
+//
+
+// PricingAssignment the plan of the tenant, the nil plan uuid unassigns the plan
+type PricingAssignment struct {
+	tenant Tenant
+	plan   PricingPlan
+}
+
+func NewPricingAssignment() *PricingAssignment {
+	return &PricingAssignment{}
+}
+
+func (a *PricingAssignment) Tenant() Tenant {
+	return a.tenant
+}
+
+func (a *PricingAssignment) SetTenant(tenant Tenant) {
+	a.tenant = tenant
+}
+
+func (a *PricingAssignment) Plan() PricingPlan {
+	return a.plan
+}
+
+func (a *PricingAssignment) SetPlan(plan PricingPlan) {
+	a.plan = plan
+}
@@ -1,16 +1,24 @@
 package domain
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
 
 type OutboxMessage struct {
-	TenantId    uint   `json:"tenantId"`
-	MessageId   uint   `json:"messageId"`
-	OutboxId    uint   `json:"outboxId"`
-	Channel     string `json:"channel"`
-	Mobile      string `json:"mobile"`
-	MessageText string `json:"messageText"`
-	MessageHash string `json:"messageHash"`
-	Status      string `json:"status"`
+	TenantId    uint      `json:"tenantId"`
+	MessageId   uint      `json:"messageId"`
+	MessageUuid uuid.UUID `json:"messageUuid"`
+	OutboxId    uint      `json:"outboxId"`
+	Channel     string    `json:"channel"`
+	Mobile      string    `json:"mobile"`
+	MessageText string    `json:"messageText"`
+	MessageHash string    `json:"messageHash"`
+	Status      string    `json:"status"`
+	From        string    `json:"from,omitempty"` // the approved sender, the provider default line if empty
+	QueuedAt    time.Time `json:"queuedAt,omitempty"`
+	ValidUntil  time.Time `json:"validUntil,omitempty"` // the message is expired after, zero for no expiry
 }
 
 func NewOutboxMessage() *OutboxMessage {
@@ -20,17 +28,105 @@ func NewOutboxMessage() *OutboxMessage {
 func (om *OutboxMessage) FromMessage(msg Message) {
 	om.TenantId = msg.TenantID()
 	om.MessageId = msg.ID()
+	om.MessageUuid = msg.UUID()
 	om.Channel = msg.Channel()
 	om.Mobile = msg.Mobile()
 	om.MessageText = msg.MessageText()
 	om.MessageHash = msg.MessageHash()
 	om.Status = msg.Status()
+	om.From = msg.Sender()
+	om.ValidUntil = msg.ValidUntil()
 }
 
 func (om *OutboxMessage) SetOutboxID(id uint) {
 	om.OutboxId = id
 }
+
+// SetQueuedAt the time the message is handed to the queue, the scheduled messages are queued on their release
+func (om *OutboxMessage) SetQueuedAt(t time.Time) {
+	om.QueuedAt = t
+}
+
+// IsExpired reports whether the validity period of the message passed, the expired messages are not sent
+func (om *OutboxMessage) IsExpired(now time.Time) bool {
+	return !om.ValidUntil.IsZero() && now.After(om.ValidUntil)
+}
+
 func (om *OutboxMessage) Json() []byte {
 	payload, _ := json.Marshal(om)
 	return payload
 }
+
+// InboundMessage the received mobile originated message, published for the consumers of the tenant
+type InboundMessage struct {
+	TenantId    uint      `json:"tenantId"`
+	InboundId   uint      `json:"inboundId"`
+	InboundUuid uuid.UUID `json:"inboundUuid"`
+	Mobile      string    `json:"mobile"`
+	Receiver    string    `json:"receiver"`
+	MessageText string    `json:"messageText"`
+	ReceivedAt  time.Time `json:"receivedAt"`
+}
+
+func NewInboundMessage() *InboundMessage {
+	return &InboundMessage{}
+}
+
+func (im *InboundMessage) FromMessageInbound(msg MessageInbound) {
+	im.TenantId = msg.TenantID()
+	im.InboundId = msg.ID()
+	im.InboundUuid = msg.UUID()
+	im.Mobile = msg.Mobile()
+	im.Receiver = msg.Receiver()
+	im.MessageText = msg.MessageText()
+	im.ReceivedAt = msg.ReceivedAt()
+}
+
+func (im *InboundMessage) Json() []byte {
+	payload, _ := json.Marshal(im)
+	return payload
+}
+
+// UsageEvent a change of the tenant usage, published by the message flow and rolled up by the usage consumer
+type UsageEvent struct {
+	TenantId  uint      `json:"tenantId"`
+	Channel   string    `json:"channel"`
+	Status    string    `json:"status"`
+	Messages  int64     `json:"messages"`
+	Credit    float64   `json:"credit,omitempty"`    // the charged amount, negative for the refunds
+	Clicks    int64     `json:"clicks,omitempty"`    // the short link clicks of the messages
+	LatencyMs int64     `json:"latencyMs,omitempty"` // the duration from queued to sent
+	At        time.Time `json:"at"`
+}
+
+func NewUsageEvent(tenantId uint, channel string, status MessageStatus, messages int64) *UsageEvent {
+	return &UsageEvent{
+		TenantId: tenantId,
+		Channel:  channel,
+		Status:   string(status),
+		Messages: messages,
+		At:       time.Now().UTC(),
+	}
+}
+
+func (ue *UsageEvent) SetCredit(credit float64) {
+	ue.Credit = credit
+}
+
+func (ue *UsageEvent) SetClicks(clicks int64) {
+	ue.Clicks = clicks
+}
+
+// SetQueuedAt evaluates the queued to sent latency of the sent message
+func (ue *UsageEvent) SetQueuedAt(queuedAt time.Time) {
+	if queuedAt.IsZero() || queuedAt.After(ue.At) {
+		return
+	}
+
+	ue.LatencyMs = ue.At.Sub(queuedAt).Milliseconds()
+}
+
+func (ue *UsageEvent) Json() []byte {
+	payload, _ := json.Marshal(ue)
+	return payload
+}
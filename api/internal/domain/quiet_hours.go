@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// QuietHoursLayout the HH:MM layout of the quiet hours bounds
+const QuietHoursLayout = "15:04"
+
+var ErrInvalidQuietHours = errors.New("invalid quiet hours")
+
+// QuietHours the daily window in which the non-urgent messages are held, the window may pass midnight
+// (e.g. 22:00 to 08:00). the equal bounds mean no quiet hours
+type QuietHours struct {
+	start    int // minutes since midnight
+	end      int
+	location *time.Location
+}
+
+func NewQuietHours(start, end, timezone string) (*QuietHours, error) {
+	startAt, err := time.Parse(QuietHoursLayout, start)
+	if err != nil {
+		return nil, ErrInvalidQuietHours
+	}
+
+	endAt, err := time.Parse(QuietHoursLayout, end)
+	if err != nil {
+		return nil, ErrInvalidQuietHours
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidQuietHours
+	}
+
+	return &QuietHours{
+		start:    startAt.Hour()*60 + startAt.Minute(),
+		end:      endAt.Hour()*60 + endAt.Minute(),
+		location: location,
+	}, nil
+}
+
+// OpensAt the end of the window if the time falls in the quiet hours, the messages are held until then
+func (qh *QuietHours) OpensAt(t time.Time) (time.Time, bool) {
+	if qh.start == qh.end {
+		return t, false
+	}
+
+	local := t.In(qh.location)
+	minute := local.Hour()*60 + local.Minute()
+
+	opensAt := func(days int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+days, qh.end/60, qh.end%60, 0, 0, qh.location).UTC()
+	}
+
+	if qh.start < qh.end { // within the same day
+		if minute >= qh.start && minute < qh.end {
+			return opensAt(0), true
+		}
+
+		return t, false
+	}
+
+	switch {
+	case minute >= qh.start: // before midnight
+		return opensAt(1), true
+	case minute < qh.end: // after midnight
+		return opensAt(0), true
+	default:
+		return t, false
+	}
+}
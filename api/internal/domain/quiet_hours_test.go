@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQuietHoursOpensAt(t *testing.T) {
+	at := func(value string) time.Time {
+		res, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatalf("time.Parse(%q) err = %v", value, err)
+		}
+
+		return res
+	}
+
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		timezone string
+		t        string
+		want     string
+		quiet    bool
+	}{
+		{name: "same day before", start: "12:00", end: "14:00", timezone: "UTC", t: "2024-05-01T11:59:00Z", want: "2024-05-01T11:59:00Z"},
+		{name: "same day start", start: "12:00", end: "14:00", timezone: "UTC", t: "2024-05-01T12:00:00Z", want: "2024-05-01T14:00:00Z", quiet: true},
+		{name: "same day end", start: "12:00", end: "14:00", timezone: "UTC", t: "2024-05-01T14:00:00Z", want: "2024-05-01T14:00:00Z"},
+		{name: "overnight before midnight", start: "22:00", end: "08:00", timezone: "UTC", t: "2024-05-01T23:30:00Z", want: "2024-05-02T08:00:00Z", quiet: true},
+		{name: "overnight after midnight", start: "22:00", end: "08:00", timezone: "UTC", t: "2024-05-02T03:00:00Z", want: "2024-05-02T08:00:00Z", quiet: true},
+		{name: "overnight daytime", start: "22:00", end: "08:00", timezone: "UTC", t: "2024-05-02T12:00:00Z", want: "2024-05-02T12:00:00Z"},
+		{name: "overnight month end", start: "22:00", end: "08:00", timezone: "UTC", t: "2024-05-31T22:00:00Z", want: "2024-06-01T08:00:00Z", quiet: true},
+		{name: "equal bounds", start: "08:00", end: "08:00", timezone: "UTC", t: "2024-05-01T08:00:00Z", want: "2024-05-01T08:00:00Z"},
+		{name: "tenant timezone", start: "22:00", end: "08:00", timezone: "Asia/Tehran", t: "2024-05-01T19:00:00Z", want: "2024-05-02T04:30:00Z", quiet: true},
+		{name: "tenant timezone daytime", start: "22:00", end: "08:00", timezone: "Asia/Tehran", t: "2024-05-01T17:00:00Z", want: "2024-05-01T17:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qh, err := NewQuietHours(tt.start, tt.end, tt.timezone)
+			if err != nil {
+				t.Fatalf("NewQuietHours() err = %v", err)
+			}
+
+			got, quiet := qh.OpensAt(at(tt.t))
+			if quiet != tt.quiet || !got.Equal(at(tt.want)) {
+				t.Errorf("OpensAt(%s) = %s, %v, want %s, %v", tt.t, got.Format(time.RFC3339), quiet, tt.want, tt.quiet)
+			}
+		})
+	}
+}
+
+func TestNewQuietHours(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		timezone string
+	}{
+		{name: "invalid start", start: "25:00", end: "08:00", timezone: "UTC"},
+		{name: "invalid end", start: "22:00", end: "8", timezone: "UTC"},
+		{name: "invalid timezone", start: "22:00", end: "08:00", timezone: "Mars/Olympus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewQuietHours(tt.start, tt.end, tt.timezone); !errors.Is(err, ErrInvalidQuietHours) {
+				t.Errorf("NewQuietHours() err = %v, want %v", err, ErrInvalidQuietHours)
+			}
+		})
+	}
+}
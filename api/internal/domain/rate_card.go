@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"math"
+	"microservice/internal/model"
+	"strings"
+)
+
+type (
+	Operator string
+
+	// RateCard the price of a single SMS segment sent to the operator through the channel
+	RateCard struct {
+		Base
+		operator Operator
+		channel  string
+		price    float64
+	}
+)
+
+const (
+	OperatorMci           Operator = "mci"
+	OperatorIrancell      Operator = "irancell"
+	OperatorRightel       Operator = "rightel"
+	OperatorInternational Operator = "international"
+)
+
+// operatorPrefixes the Iranian mobile ranges (E.164) of each operator
+var operatorPrefixes = map[Operator][]string{
+	OperatorMci: {
+		"+98910", "+98911", "+98912", "+98913", "+98914", "+98915", "+98916", "+98917", "+98918", "+98919",
+		"+98990", "+98991", "+98992", "+98993", "+98994",
+	},
+	OperatorIrancell: {
+		"+98900", "+98901", "+98902", "+98903", "+98904", "+98905",
+		"+98930", "+98933", "+98935", "+98936", "+98937", "+98938", "+98939", "+98941",
+	},
+	OperatorRightel: {
+		"+98920", "+98921", "+98922",
+	},
+}
+
+// DetectOperator the operator of the E.164 mobile by its prefix. the non Iranian numbers are international,
+// the Iranian ranges out of the known ones (the MVNOs) are carried and priced by MCI
+func DetectOperator(mobile string) Operator {
+	if !strings.HasPrefix(mobile, "+98") {
+		return OperatorInternational
+	}
+
+	for operator, prefixes := range operatorPrefixes {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(mobile, prefix) {
+				return operator
+			}
+		}
+	}
+
+	return OperatorMci
+}
+
+func NewRateCard() *RateCard {
+	return &RateCard{}
+}
+
+func (r *RateCard) Operator() Operator {
+	return r.operator
+}
+
+func (r *RateCard) SetOperator(operator Operator) {
+	r.operator = operator
+}
+
+func (r *RateCard) Channel() string {
+	return r.channel
+}
+
+func (r *RateCard) SetChannel(channel string) {
+	r.channel = channel
+}
+
+// Price the price of a single segment
+func (r *RateCard) Price() float64 {
+	return r.price
+}
+
+func (r *RateCard) SetPrice(price float64) {
+	r.price = price
+}
+
+// UnitPrice the price of a single segment after the discount percent, rounded to the stored precision
+func (r *RateCard) UnitPrice(discount float64) float64 {
+	return math.Round(r.price*(100-discount)/100*10000) / 10000
+}
+
+//
+
+func (r *RateCard) FromDB(src model.RateCards) {
+	// base
+	r.SetID(src.ID)
+	r.SetUUID(src.Uuid)
+	r.SetCreatedAt(src.CreatedAt)
+	r.SetUpdatedAt(src.UpdatedAt)
+	r.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	r.SetOperator(Operator(src.Operator))
+	r.SetChannel(src.Channel)
+	r.SetPrice(src.Price)
+}
+
+func (r *RateCard) ToDB() model.RateCards {
+	return model.RateCards{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        r.ID(),
+				CreatedAt: r.CreatedAt(),
+				UpdatedAt: r.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(sql.NullTime{Time: r.DeletedAt(), Valid: !r.DeletedAt().IsZero()}),
+			},
+			Uuid: r.UUID(),
+		},
+		Operator: string(r.Operator()),
+		Channel:  r.Channel(),
+		Price:    r.Price(),
+	}
+}
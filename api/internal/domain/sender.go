@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"microservice/internal/model"
+	"time"
+)
+
+type (
+	SenderStatus string
+	// Sender the sender id or the dedicated line number of the tenant, usable after the admin approval
+	Sender struct {
+		Base
+		tenantId    uint
+		tenant      Tenant
+		sender      string
+		status      string
+		description string
+		reviewedAt  time.Time
+	}
+
+	SenderList struct {
+		BaseList
+		list []Sender
+	}
+)
+
+const (
+	SenderPending  SenderStatus = "pending"
+	SenderApproved SenderStatus = "approved"
+	SenderRejected SenderStatus = "rejected"
+)
+
+func NewSender() *Sender {
+	return &Sender{}
+}
+
+func (s *Sender) TenantID() uint {
+	return s.tenantId
+}
+
+func (s *Sender) SetTenantID(tenantId uint) {
+	s.tenantId = tenantId
+}
+
+func (s *Sender) Tenant() Tenant {
+	return s.tenant
+}
+
+func (s *Sender) SetTenant(tenant Tenant) {
+	s.tenant = tenant
+}
+
+// Sender the sender id, like `R1Cloud`, or the line number, like `98100020003000`
+func (s *Sender) Sender() string {
+	return s.sender
+}
+
+func (s *Sender) SetSender(sender string) {
+	s.sender = sender
+}
+
+func (s *Sender) Status() string {
+	return s.status
+}
+
+func (s *Sender) SetStatus(status string) {
+	s.status = status
+}
+
+func (s *Sender) Description() string {
+	return s.description
+}
+
+func (s *Sender) SetDescription(description string) {
+	s.description = description
+}
+
+// ReviewedAt the time of the admin approval or rejection
+func (s *Sender) ReviewedAt() time.Time {
+	return s.reviewedAt
+}
+
+func (s *Sender) SetReviewedAt(reviewedAt time.Time) {
+	s.reviewedAt = reviewedAt
+}
+
+//
+
+func (s *Sender) FromDB(src model.Senders) Sender {
+	// base
+	s.SetID(src.ID)
+	s.SetUUID(src.Uuid)
+	s.SetCreatedAt(src.CreatedAt)
+	s.SetUpdatedAt(src.UpdatedAt)
+	s.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	s.SetTenantID(src.TenantID)
+	s.SetSender(src.Sender)
+	s.SetStatus(src.Status)
+	s.SetDescription(src.Description.String)
+
+	if src.ReviewedAt.Valid {
+		s.SetReviewedAt(src.ReviewedAt.Time)
+	}
+	// relations
+	if src.Tenant.ID != 0 {
+		s.SetTenant(NewTenant().FromDB(src.Tenant))
+	}
+
+	return *s
+}
+
+func (s *Sender) ToDB() model.Senders {
+	return model.Senders{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        s.ID(),
+				CreatedAt: s.CreatedAt(),
+				UpdatedAt: s.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(
+					sql.NullTime{
+						Time: s.DeletedAt(),
+						Valid: func() bool {
+							if s.DeletedAt().IsZero() {
+								return false
+							}
+							return true
+						}(),
+					},
+				),
+			},
+			Uuid: s.UUID(),
+		},
+		TenantID: s.TenantID(),
+		Sender:   s.Sender(),
+		Status:   s.Status(),
+		Description: sql.NullString{
+			String: s.Description(),
+			Valid:  len(s.Description()) > 0,
+		},
+		ReviewedAt: sql.NullTime{
+			Time:  s.ReviewedAt(),
+			Valid: !s.ReviewedAt().IsZero(),
+		},
+	}
+}
+
+//
+
+func NewSenderList() *SenderList { return &SenderList{} }
+
+func (ul *SenderList) List() []Sender { return ul.list }
+
+func (ul *SenderList) SetList(list []Sender) { ul.list = list }
+
+func (ul *SenderList) ListFromDB(src []model.Senders) SenderList {
+	ul.list = make([]Sender, 0)
+
+	total := len(src)
+	if ul.total == 0 && total > 0 {
+		ul.total = int64(total)
+	}
+
+	if ul.total == 0 {
+		return *ul
+	}
+
+	for _, item := range src {
+		ul.list = append(ul.list, NewSender().FromDB(item))
+	}
+
+	return *ul
+}
+
+//
+
+type SenderListReqQryParam struct {
+	ReqBaseQryParam
+	tenantId uint
+	status   string
+}
+
+func NewSenderListReqQryParam() *SenderListReqQryParam {
+	return &SenderListReqQryParam{}
+}
+
+// TenantId the list owner, zero lists the senders of all tenants
+func (s *SenderListReqQryParam) TenantId() uint {
+	return s.tenantId
+}
+
+func (s *SenderListReqQryParam) SetTenantId(tenantId uint) {
+	s.tenantId = tenantId
+}
+
+func (s *SenderListReqQryParam) Status() string {
+	return s.status
+}
+
+func (s *SenderListReqQryParam) SetStatus(status string) {
+	s.status = status
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"microservice/internal/model"
+	"microservice/pkg/validator"
+	"strings"
+	"time"
+)
+
+type (
+	// ShortLink a link of the tracked message replaced by its code, the clicks are redirected to the target
+	ShortLink struct {
+		id          uint
+		code        string
+		tenantId    uint
+		messageId   uint
+		messageUuid uuid.UUID
+		channel     string
+		targetUrl   string
+		createdAt   time.Time
+	}
+
+	// LinkClick a single click of the short link, counted against the message
+	LinkClick struct {
+		shortLinkId uint
+		messageId   uint
+		clickedAt   time.Time
+	}
+)
+
+const (
+	// ShortLinkCodeLength the length of the base62 code of the short links
+	ShortLinkCodeLength = 7
+	// LinkClicked the usage status of the short link clicks
+	LinkClicked MessageStatus = "clicked"
+)
+
+// linkTrailing the punctuations ending the sentence are not part of the link
+const linkTrailing = ".,;:!?)"
+
+func NewShortLink() *ShortLink {
+	return &ShortLink{}
+}
+
+func (s *ShortLink) ID() uint {
+	return s.id
+}
+
+func (s *ShortLink) Code() string {
+	return s.code
+}
+
+func (s *ShortLink) SetCode(code string) {
+	s.code = code
+}
+
+func (s *ShortLink) TenantID() uint {
+	return s.tenantId
+}
+
+func (s *ShortLink) SetTenantID(tenantId uint) {
+	s.tenantId = tenantId
+}
+
+func (s *ShortLink) MessageID() uint {
+	return s.messageId
+}
+
+func (s *ShortLink) SetMessageID(messageId uint) {
+	s.messageId = messageId
+}
+
+// MessageUUID the message of the link, loaded on the redirect
+func (s *ShortLink) MessageUUID() uuid.UUID {
+	return s.messageUuid
+}
+
+// Channel the channel of the message, the clicks are reported by it
+func (s *ShortLink) Channel() string {
+	return s.channel
+}
+
+// TargetUrl the original link, with the http scheme if it had none
+func (s *ShortLink) TargetUrl() string {
+	return s.targetUrl
+}
+
+func (s *ShortLink) SetTargetUrl(targetUrl string) {
+	// the www links have no scheme, the redirect of them would be relative to the short link
+	lower := strings.ToLower(targetUrl)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		targetUrl = "http://" + targetUrl
+	}
+
+	s.targetUrl = targetUrl
+}
+
+func (s *ShortLink) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// Url the short link of the code under the base url of the redirect endpoint
+func (s *ShortLink) Url(baseUrl string) string {
+	return strings.TrimRight(baseUrl, "/") + "/" + s.code
+}
+
+func (s *ShortLink) FromDB(src model.ShortLinks) ShortLink {
+	s.id = src.ID
+	s.code = src.Code
+	s.tenantId = src.TenantID
+	s.messageId = src.MessageID
+	s.SetTargetUrl(src.TargetUrl)
+	s.createdAt = src.CreatedAt
+
+	if src.Message.ID != 0 {
+		s.messageUuid = src.Message.Uuid
+		s.channel = src.Message.Outbox.EventType
+	}
+
+	return *s
+}
+
+func (s *ShortLink) ToDB() model.ShortLinks {
+	return model.ShortLinks{
+		ID:        s.id,
+		Code:      s.code,
+		TenantID:  s.tenantId,
+		MessageID: s.messageId,
+		TargetUrl: s.targetUrl,
+	}
+}
+
+// ShortenLinks replaces the links of the text by the short links under the base url, the repeated link shares its code.
+// the codes are generated by the given func, the message of the links is set once it is created
+func ShortenLinks(text, baseUrl string, code func() string) (string, []ShortLink) {
+	links := make([]ShortLink, 0)
+	codes := make(map[string]string)
+
+	shortened := validator.LinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		link := strings.TrimRight(match, linkTrailing)
+		trailing := match[len(link):]
+
+		if len(link) == 0 {
+			return match
+		}
+
+		if _, ok := codes[link]; !ok {
+			sl := NewShortLink()
+			sl.SetCode(code())
+			sl.SetTargetUrl(link)
+
+			codes[link] = sl.Url(baseUrl)
+			links = append(links, *sl)
+		}
+
+		return codes[link] + trailing
+	})
+
+	return shortened, links
+}
+
+//
+
+func NewLinkClick(link ShortLink) *LinkClick {
+	return &LinkClick{
+		shortLinkId: link.ID(),
+		messageId:   link.MessageID(),
+		clickedAt:   time.Now().UTC(),
+	}
+}
+
+func (c *LinkClick) ShortLinkID() uint {
+	return c.shortLinkId
+}
+
+func (c *LinkClick) MessageID() uint {
+	return c.messageId
+}
+
+func (c *LinkClick) ClickedAt() time.Time {
+	return c.clickedAt
+}
+
+func (c *LinkClick) ToDB() model.LinkClicks {
+	return model.LinkClicks{
+		ShortLinkID: c.shortLinkId,
+		MessageID:   c.messageId,
+		ClickedAt:   c.clickedAt,
+	}
+}
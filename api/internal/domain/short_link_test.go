@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestShortenLinks(t *testing.T) {
+	const baseUrl = "https://s.example.ir/l/"
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		targets []string
+	}{
+		{name: "no links", text: "your code is 1234", want: "your code is 1234", targets: []string{}},
+		{
+			name:    "scheme kept",
+			text:    "open https://shop.ir/a?b=1 now",
+			want:    "open https://s.example.ir/l/c1 now",
+			targets: []string{"https://shop.ir/a?b=1"},
+		},
+		{
+			name:    "www gets the http scheme",
+			text:    "visit www.shop.ir.",
+			want:    "visit https://s.example.ir/l/c1.",
+			targets: []string{"http://www.shop.ir"},
+		},
+		{
+			name:    "bare domain gets the http scheme",
+			text:    "see shop.ir/offer, today",
+			want:    "see https://s.example.ir/l/c1, today",
+			targets: []string{"http://shop.ir/offer"},
+		},
+		{
+			name:    "www with a scheme in the query",
+			text:    "www.shop.ir/?r=https://x.ir",
+			want:    "https://s.example.ir/l/c1",
+			targets: []string{"http://www.shop.ir/?r=https://x.ir"},
+		},
+		{
+			name:    "repeated link shares its code",
+			text:    "https://a.ir and https://b.ir and https://a.ir!",
+			want:    "https://s.example.ir/l/c1 and https://s.example.ir/l/c2 and https://s.example.ir/l/c1!",
+			targets: []string{"https://a.ir", "https://b.ir"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := 0
+			code := func() string {
+				n++
+				return fmt.Sprintf("c%d", n)
+			}
+
+			got, links := ShortenLinks(tt.text, baseUrl, code)
+			if got != tt.want {
+				t.Errorf("ShortenLinks(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+
+			targets := make([]string, 0, len(links))
+			for _, link := range links {
+				targets = append(targets, link.TargetUrl())
+			}
+
+			if !reflect.DeepEqual(targets, tt.targets) {
+				t.Errorf("ShortenLinks(%q) targets = %q, want %q", tt.text, targets, tt.targets)
+			}
+		})
+	}
+}
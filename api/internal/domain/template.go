@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"database/sql"
+	"gorm.io/gorm"
+	"microservice/internal/model"
+	"regexp"
+	"strings"
+)
+
+type (
+	Template struct {
+		Base
+		tenantId uint
+		name     string
+		body     string
+	}
+
+	TemplateList struct {
+		BaseList
+		list []Template
+	}
+)
+
+// TemplatePlaceholder matches the template variables, like `{{code}}`
+var TemplatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+func NewTemplate() *Template {
+	return &Template{}
+}
+
+func (t *Template) TenantID() uint {
+	return t.tenantId
+}
+
+func (t *Template) SetTenantID(tenantId uint) {
+	t.tenantId = tenantId
+}
+
+func (t *Template) Name() string {
+	return t.name
+}
+
+func (t *Template) SetName(name string) {
+	t.name = name
+}
+
+func (t *Template) Body() string {
+	return t.body
+}
+
+func (t *Template) SetBody(body string) {
+	t.body = body
+}
+
+// Placeholders the unique variable names of the template body
+func (t *Template) Placeholders() []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range TemplatePlaceholder.FindAllStringSubmatch(t.body, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+
+	return names
+}
+
+// Render replaces the placeholders by the variables. the missing variables are returned without rendering
+func (t *Template) Render(variables map[string]string) (text string, missing []string) {
+	for _, name := range t.Placeholders() {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return
+	}
+
+	text = TemplatePlaceholder.ReplaceAllStringFunc(t.body, func(placeholder string) string {
+		name := TemplatePlaceholder.FindStringSubmatch(placeholder)[1]
+		return variables[name]
+	})
+
+	text = strings.TrimSpace(text)
+	return
+}
+
+//
+
+func (t *Template) FromDB(src model.Templates) Template {
+	// base
+	t.SetID(src.ID)
+	t.SetUUID(src.Uuid)
+	t.SetCreatedAt(src.CreatedAt)
+	t.SetUpdatedAt(src.UpdatedAt)
+	t.SetDeletedAt(src.DeletedAt.Time)
+	//fields
+	t.SetTenantID(src.TenantID)
+	t.SetName(src.Name)
+	t.SetBody(src.Body)
+
+	return *t
+}
+
+func (t *Template) ToDB() model.Templates {
+	return model.Templates{
+		BaseSql: model.BaseSql{
+			Model: gorm.Model{
+				ID:        t.ID(),
+				CreatedAt: t.CreatedAt(),
+				UpdatedAt: t.UpdatedAt(),
+				DeletedAt: gorm.DeletedAt(
+					sql.NullTime{
+						Time: t.DeletedAt(),
+						Valid: func() bool {
+							if t.DeletedAt().IsZero() {
+								return false
+							}
+							return true
+						}(),
+					},
+				),
+			},
+			Uuid: t.UUID(),
+		},
+		TenantID: t.TenantID(),
+		Name:     t.Name(),
+		Body:     t.Body(),
+	}
+}
+
+//
+
+func NewTemplateList() *TemplateList { return &TemplateList{} }
+
+func (ul *TemplateList) List() []Template { return ul.list }
+
+func (ul *TemplateList) SetList(list []Template) { ul.list = list }
+
+func (ul *TemplateList) ListToDB() []model.Templates {
+	template := make([]model.Templates, 0)
+
+	if len(ul.list) == 0 {
+		return template
+	}
+
+	for _, item := range ul.list {
+		template = append(template, item.ToDB())
+	}
+
+	return template
+}
+
+func (ul *TemplateList) ListFromDB(src []model.Templates) TemplateList {
+	ul.list = make([]Template, 0)
+
+	total := len(src)
+	if ul.total == 0 && total > 0 {
+		ul.total = int64(total)
+	}
+
+	if ul.total == 0 {
+		return *ul
+	}
+
+	for _, item := range src {
+		ul.list = append(ul.list, NewTemplate().FromDB(item))
+	}
+
+	return *ul
+}
+
+//
+
+type TemplateListReqQryParam struct {
+	ReqBaseQryParam
+	tenantId uint
+}
+
+func NewTemplateListReqQryParam() *TemplateListReqQryParam {
+	return &TemplateListReqQryParam{}
+}
+
+func (t *TemplateListReqQryParam) TenantId() uint {
+	return t.tenantId
+}
+
+func (t *TemplateListReqQryParam) SetTenantId(tenantId uint) {
+	t.tenantId = tenantId
+}
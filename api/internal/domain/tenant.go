@@ -4,15 +4,33 @@ import (
 	"database/sql"
 	"gorm.io/gorm"
 	"microservice/internal/model"
+	"time"
 )
 
 type (
 	Tenant struct {
 		Base
-		username   string
-		tenantName string
-		active     bool
-		credit     Credit
+		username    string
+		tenantName  string
+		active      bool
+		webhook     TenantWebhook
+		dedupWindow time.Duration
+		quietHours  TenantQuietHours
+		credit      Credit
+	}
+
+	// TenantQuietHours the tenant own quiet hours of the non-urgent channels, the empty values fall back to the service
+	// defaults
+	TenantQuietHours struct {
+		start    string
+		end      string
+		timezone string
+	}
+
+	// TenantWebhook the callback which receives the message status changes, signed by the secret
+	TenantWebhook struct {
+		url    string
+		secret string
 	}
 
 	TenantList struct {
@@ -49,6 +67,31 @@ func (t *Tenant) SetActive(active bool) {
 	t.active = active
 }
 
+func (t *Tenant) Webhook() TenantWebhook {
+	return t.webhook
+}
+
+func (t *Tenant) SetWebhook(webhook TenantWebhook) {
+	t.webhook = webhook
+}
+
+// DedupWindow the same message to the same mobile is rejected within the window. zero disables the deduplication
+func (t *Tenant) DedupWindow() time.Duration {
+	return t.dedupWindow
+}
+
+func (t *Tenant) SetDedupWindow(dedupWindow time.Duration) {
+	t.dedupWindow = dedupWindow
+}
+
+func (t *Tenant) QuietHours() TenantQuietHours {
+	return t.quietHours
+}
+
+func (t *Tenant) SetQuietHours(quietHours TenantQuietHours) {
+	t.quietHours = quietHours
+}
+
 //
 
 func (t *Tenant) Credit() Credit {
@@ -72,6 +115,13 @@ func (t *Tenant) FromDB(src model.Tenants) Tenant {
 	t.SetUsername(src.Username)
 	t.SetTenantName(src.TenantName)
 	t.SetActive(src.Active)
+	t.SetWebhook(TenantWebhook{url: src.WebhookUrl.String, secret: src.WebhookSecret.String})
+	t.SetDedupWindow(time.Duration(src.DedupWindow) * time.Second)
+	t.SetQuietHours(TenantQuietHours{
+		start:    src.QuietHoursStart.String,
+		end:      src.QuietHoursEnd.String,
+		timezone: src.QuietHoursTimezone.String,
+	})
 	// relations
 	if src.Credit.ID != 0 {
 		c := NewCredit().FromDB(src.Credit)
@@ -105,11 +155,45 @@ func (t *Tenant) ToDB() model.Tenants {
 		Username:   t.Username(),
 		TenantName: t.TenantName(),
 		Active:     t.Active(),
+		WebhookUrl: sql.NullString{
+			String: t.webhook.url,
+			Valid:  len(t.webhook.url) > 0,
+		},
+		WebhookSecret: sql.NullString{
+			String: t.webhook.secret,
+			Valid:  len(t.webhook.secret) > 0,
+		},
+		DedupWindow: int(t.DedupWindow().Seconds()),
+		QuietHoursStart: sql.NullString{
+			String: t.quietHours.start,
+			Valid:  len(t.quietHours.start) > 0,
+		},
+		QuietHoursEnd: sql.NullString{
+			String: t.quietHours.end,
+			Valid:  len(t.quietHours.end) > 0,
+		},
+		QuietHoursTimezone: sql.NullString{
+			String: t.quietHours.timezone,
+			Valid:  len(t.quietHours.timezone) > 0,
+		},
 	}
 }
 
 //
 
+func NewTenantWebhook(url, secret string) TenantWebhook {
+	return TenantWebhook{url: url, secret: secret}
+}
+
+func (w TenantWebhook) Url() string { return w.url }
+
+func (w TenantWebhook) Secret() string { return w.secret }
+
+// Enabled reports whether the tenant has configured a webhook
+func (w TenantWebhook) Enabled() bool { return len(w.url) > 0 && len(w.secret) > 0 }
+
+//
+
 func NewTenantList() *TenantList { return &TenantList{} }
 
 func (ul *TenantList) List() []Tenant { return ul.list }
@@ -158,3 +242,18 @@ type TenantListReqQryParam struct {
 func NewTenantListReqQryParam() *TenantListReqQryParam {
 	return &TenantListReqQryParam{}
 }
+
+//
+
+func NewTenantQuietHours(start, end, timezone string) TenantQuietHours {
+	return TenantQuietHours{start: start, end: end, timezone: timezone}
+}
+
+// Start HH:MM, the service default if empty
+func (q TenantQuietHours) Start() string { return q.start }
+
+// End HH:MM, the service default if empty
+func (q TenantQuietHours) End() string { return q.end }
+
+// Timezone the IANA timezone of the bounds, the service timezone if empty
+func (q TenantQuietHours) Timezone() string { return q.timezone }
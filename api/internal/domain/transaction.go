@@ -1,16 +1,22 @@
 package domain
 
 import (
+	"database/sql"
 	"microservice/internal/model"
 	"time"
 )
 
 type (
+	TransactionType string
+
 	Transaction struct {
 		id            []byte
 		creditId      uint
 		amount        float64
+		txType        TransactionType
 		messageHashId []byte
+		operator      Operator
+		unitPrice     float64
 		createdAt     time.Time
 	}
 
@@ -20,6 +26,12 @@ type (
 	}
 )
 
+const (
+	TxDeposit TransactionType = "deposit" // credit increased by the tenant
+	TxCharge  TransactionType = "charge"  // credit decreased per sent message
+	TxRefund  TransactionType = "refund"  // charged credit of an unsent message compensated
+)
+
 func NewTransaction() *Transaction {
 	return &Transaction{}
 }
@@ -48,6 +60,19 @@ func (t *Transaction) SetAmount(amount float64) {
 	t.amount = amount
 }
 
+func (t *Transaction) Type() TransactionType {
+	return t.txType
+}
+
+func (t *Transaction) SetType(txType TransactionType) {
+	t.txType = txType
+}
+
+// Incremented reports whether the transaction increased the credit balance
+func (t *Transaction) Incremented() bool {
+	return t.txType != TxCharge
+}
+
 func (t *Transaction) MessageHashID() []byte {
 	return t.messageHashId
 }
@@ -56,6 +81,24 @@ func (t *Transaction) SetMessageHashID(messageHashId []byte) {
 	t.messageHashId = messageHashId
 }
 
+// Operator the recipient operator of the charged message
+func (t *Transaction) Operator() Operator {
+	return t.operator
+}
+
+func (t *Transaction) SetOperator(operator Operator) {
+	t.operator = operator
+}
+
+// UnitPrice the effective price of a single segment at the charge time, the discounts applied
+func (t *Transaction) UnitPrice() float64 {
+	return t.unitPrice
+}
+
+func (t *Transaction) SetUnitPrice(unitPrice float64) {
+	t.unitPrice = unitPrice
+}
+
 func (t *Transaction) CreatedAt() time.Time {
 	return t.createdAt
 }
@@ -73,11 +116,20 @@ func (t *Transaction) FromDB(src model.CreditTransactions) Transaction {
 	//fields
 	t.SetCreditID(src.CreditID)
 	t.SetAmount(src.Amount)
+	t.SetType(TransactionType(src.Type))
 
 	if src.MessageHashID != nil {
 		t.SetMessageHashID(src.MessageHashID)
 	}
 
+	if src.Operator.Valid {
+		t.SetOperator(Operator(src.Operator.String))
+	}
+
+	if src.UnitPrice.Valid {
+		t.SetUnitPrice(src.UnitPrice.Float64)
+	}
+
 	return *t
 }
 
@@ -86,8 +138,17 @@ func (t *Transaction) ToDB() model.CreditTransactions {
 		ID:            t.ID(),
 		CreditID:      t.CreditID(),
 		Amount:        t.Amount(),
+		Type:          string(t.Type()),
 		MessageHashID: t.MessageHashID(),
-		CreatedAt:     t.CreatedAt(),
+		Operator: sql.NullString{
+			String: string(t.Operator()),
+			Valid:  len(t.Operator()) > 0,
+		},
+		UnitPrice: sql.NullFloat64{
+			Float64: t.UnitPrice(),
+			Valid:   t.UnitPrice() > 0,
+		},
+		CreatedAt: t.CreatedAt(),
 	}
 }
 
@@ -136,8 +197,37 @@ func (ul *TransactionList) ListFromDB(src []model.CreditTransactions) Transactio
 
 type TransactionListReqQryParam struct {
 	ReqBaseQryParam
+	txType      TransactionType
+	createdFrom time.Time
+	createdTo   time.Time
 }
 
 func NewTransactionListReqQryParam() *TransactionListReqQryParam {
 	return &TransactionListReqQryParam{}
 }
+
+func (t *TransactionListReqQryParam) Type() TransactionType {
+	return t.txType
+}
+
+func (t *TransactionListReqQryParam) SetType(txType TransactionType) {
+	t.txType = txType
+}
+
+// CreatedFrom the inclusive start of the creation time range (zero for no bound)
+func (t *TransactionListReqQryParam) CreatedFrom() time.Time {
+	return t.createdFrom
+}
+
+func (t *TransactionListReqQryParam) SetCreatedFrom(createdFrom time.Time) {
+	t.createdFrom = createdFrom
+}
+
+// CreatedTo the exclusive end of the creation time range (zero for no bound)
+func (t *TransactionListReqQryParam) CreatedTo() time.Time {
+	return t.createdTo
+}
+
+func (t *TransactionListReqQryParam) SetCreatedTo(createdTo time.Time) {
+	t.createdTo = createdTo
+}
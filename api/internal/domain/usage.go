@@ -0,0 +1,367 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"microservice/internal/model"
+	"sort"
+	"time"
+)
+
+type (
+	UsageGranularity string
+
+	// UsageRollup the usage of the tenant within an hour bucket, per channel and status
+	UsageRollup struct {
+		tenantId     uint
+		bucketAt     time.Time
+		channel      string
+		status       string
+		messages     int64
+		credit       float64
+		latencyMs    int64
+		latencyCount int64
+		clicks       int64
+	}
+
+	// UsageBucket the aggregated usage of a day or an hour
+	UsageBucket struct {
+		at           time.Time
+		statuses     map[string]int64
+		channels     map[string]map[string]int64
+		credit       float64
+		latencyMs    int64
+		latencyCount int64
+		clicks       int64
+	}
+
+	UsageReport struct {
+		from        time.Time
+		to          time.Time
+		granularity UsageGranularity
+		total       UsageBucket
+		buckets     []UsageBucket
+	}
+
+	UsageReportReqQryParam struct {
+		tenantId    uint
+		tenantUuid  uuid.UUID
+		from        time.Time
+		to          time.Time
+		granularity UsageGranularity
+		channel     string
+	}
+)
+
+const (
+	UsageDaily  UsageGranularity = "day"
+	UsageHourly UsageGranularity = "hour"
+)
+
+const (
+	// UsageMaxDays the longest range of the daily report
+	UsageMaxDays = 366
+	// UsageMaxHours the longest range of the hourly report
+	UsageMaxHours = 7 * 24
+)
+
+// Truncate the start of the bucket which contains the time, the buckets are in UTC
+func (g UsageGranularity) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	if g == UsageHourly {
+		return t.Truncate(time.Hour)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (g UsageGranularity) Step() time.Duration {
+	if g == UsageHourly {
+		return time.Hour
+	}
+
+	return 24 * time.Hour
+}
+
+// DefaultRange the range of the report without the start
+func (g UsageGranularity) DefaultRange() time.Duration {
+	if g == UsageHourly {
+		return 24 * time.Hour
+	}
+
+	return 7 * 24 * time.Hour
+}
+
+// MaxRange the longest range of the report
+func (g UsageGranularity) MaxRange() time.Duration {
+	if g == UsageHourly {
+		return UsageMaxHours * time.Hour
+	}
+
+	return UsageMaxDays * 24 * time.Hour
+}
+
+//
+
+func NewUsageRollup() *UsageRollup {
+	return &UsageRollup{}
+}
+
+// FromUsageEvent the event is rolled up into its hour bucket
+func (u *UsageRollup) FromUsageEvent(e UsageEvent) {
+	u.tenantId = e.TenantId
+	u.bucketAt = UsageHourly.Truncate(e.At)
+	u.channel = e.Channel
+	u.status = e.Status
+	u.messages = e.Messages
+	u.credit = e.Credit
+	u.clicks = e.Clicks
+
+	if e.LatencyMs > 0 {
+		u.latencyMs = e.LatencyMs
+		u.latencyCount = e.Messages
+	}
+}
+
+func (u *UsageRollup) TenantID() uint {
+	return u.tenantId
+}
+
+func (u *UsageRollup) BucketAt() time.Time {
+	return u.bucketAt
+}
+
+func (u *UsageRollup) Channel() string {
+	return u.channel
+}
+
+func (u *UsageRollup) Status() string {
+	return u.status
+}
+
+func (u *UsageRollup) Messages() int64 {
+	return u.messages
+}
+
+func (u *UsageRollup) Credit() float64 {
+	return u.credit
+}
+
+// Clicks the short link clicks of the tenant messages
+func (u *UsageRollup) Clicks() int64 {
+	return u.clicks
+}
+
+func (u *UsageRollup) FromDB(m model.UsageRollups) {
+	u.tenantId = m.TenantID
+	u.bucketAt = m.BucketAt
+	u.channel = m.Channel
+	u.status = m.Status
+	u.messages = m.Messages
+	u.credit = m.CreditSpent
+	u.latencyMs = m.LatencyMs
+	u.latencyCount = m.LatencyCount
+	u.clicks = m.Clicks
+}
+
+func (u *UsageRollup) ToDB() model.UsageRollups {
+	return model.UsageRollups{
+		TenantID:     u.tenantId,
+		BucketAt:     u.bucketAt,
+		Channel:      u.channel,
+		Status:       u.status,
+		Messages:     u.messages,
+		CreditSpent:  u.credit,
+		LatencyMs:    u.latencyMs,
+		LatencyCount: u.latencyCount,
+		Clicks:       u.clicks,
+	}
+}
+
+//
+
+func NewUsageBucket(at time.Time) *UsageBucket {
+	return &UsageBucket{
+		at:       at,
+		statuses: make(map[string]int64),
+		channels: make(map[string]map[string]int64),
+	}
+}
+
+func (b *UsageBucket) At() time.Time {
+	return b.at
+}
+
+// Statuses the message count per status of all channels
+func (b *UsageBucket) Statuses() map[string]int64 {
+	return b.statuses
+}
+
+// Channels the message count per status of each channel
+func (b *UsageBucket) Channels() map[string]map[string]int64 {
+	return b.channels
+}
+
+func (b *UsageBucket) Credit() float64 {
+	return b.credit
+}
+
+// Clicks the short link clicks of the messages of all channels
+func (b *UsageBucket) Clicks() int64 {
+	return b.clicks
+}
+
+// AvgQueuedToSent the average duration from queued to sent, zero without any sent message
+func (b *UsageBucket) AvgQueuedToSent() time.Duration {
+	if b.latencyCount == 0 {
+		return 0
+	}
+
+	return time.Duration(b.latencyMs/b.latencyCount) * time.Millisecond
+}
+
+func (b *UsageBucket) add(r UsageRollup) {
+	if r.messages != 0 {
+		b.statuses[r.status] += r.messages
+
+		if _, ok := b.channels[r.channel]; !ok {
+			b.channels[r.channel] = make(map[string]int64)
+		}
+		b.channels[r.channel][r.status] += r.messages
+	}
+
+	b.credit += r.credit
+	b.latencyMs += r.latencyMs
+	b.latencyCount += r.latencyCount
+	b.clicks += r.clicks
+}
+
+//
+
+// NewUsageReport the report has a bucket for every day or hour of the range, the empty ones included
+func NewUsageReport(qry UsageReportReqQryParam) *UsageReport {
+	report := &UsageReport{
+		from:        qry.From(),
+		to:          qry.To(),
+		granularity: qry.Granularity(),
+		total:       *NewUsageBucket(qry.From()),
+		buckets:     make([]UsageBucket, 0),
+	}
+
+	for at := qry.From(); at.Before(qry.To()); at = at.Add(qry.Granularity().Step()) {
+		report.buckets = append(report.buckets, *NewUsageBucket(at))
+	}
+
+	return report
+}
+
+func (r *UsageReport) From() time.Time {
+	return r.from
+}
+
+func (r *UsageReport) To() time.Time {
+	return r.to
+}
+
+func (r *UsageReport) Granularity() UsageGranularity {
+	return r.granularity
+}
+
+func (r *UsageReport) Total() UsageBucket {
+	return r.total
+}
+
+func (r *UsageReport) Buckets() []UsageBucket {
+	return r.buckets
+}
+
+// ListFromDB the rollups are already aggregated by the report granularity
+func (r *UsageReport) ListFromDB(models []model.UsageRollups) {
+	for _, m := range models {
+		rollup := NewUsageRollup()
+		rollup.FromDB(m)
+
+		at := r.granularity.Truncate(rollup.BucketAt())
+		i := sort.Search(len(r.buckets), func(i int) bool {
+			return !r.buckets[i].at.Before(at)
+		})
+
+		if i < len(r.buckets) && r.buckets[i].at.Equal(at) {
+			r.buckets[i].add(*rollup)
+		}
+
+		r.total.add(*rollup)
+	}
+}
+
+//
+
+func NewUsageReportReqQryParam() *UsageReportReqQryParam {
+	return &UsageReportReqQryParam{granularity: UsageDaily}
+}
+
+// TenantId the zero id is the report of all tenants
+func (q *UsageReportReqQryParam) TenantId() uint {
+	return q.tenantId
+}
+
+func (q *UsageReportReqQryParam) SetTenantId(tenantId uint) {
+	q.tenantId = tenantId
+}
+
+// TenantUuid the tenant filter of the admin report
+func (q *UsageReportReqQryParam) TenantUuid() uuid.UUID {
+	return q.tenantUuid
+}
+
+func (q *UsageReportReqQryParam) SetTenantUuid(tenantUuid uuid.UUID) {
+	q.tenantUuid = tenantUuid
+}
+
+func (q *UsageReportReqQryParam) From() time.Time {
+	return q.from
+}
+
+func (q *UsageReportReqQryParam) To() time.Time {
+	return q.to
+}
+
+// SetRange aligns the range to the buckets, the end is exclusive and the zero end is now
+func (q *UsageReportReqQryParam) SetRange(from, to time.Time) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	q.to = q.granularity.Truncate(to)
+	if !q.to.Equal(to.UTC()) {
+		q.to = q.to.Add(q.granularity.Step()) // the partial last bucket is included
+	}
+
+	if from.IsZero() {
+		from = q.to.Add(-q.granularity.DefaultRange())
+	}
+
+	q.from = q.granularity.Truncate(from)
+}
+
+func (q *UsageReportReqQryParam) Granularity() UsageGranularity {
+	return q.granularity
+}
+
+func (q *UsageReportReqQryParam) SetGranularity(granularity UsageGranularity) {
+	if len(granularity) > 0 {
+		q.granularity = granularity
+	}
+}
+
+func (q *UsageReportReqQryParam) Channel() string {
+	return q.channel
+}
+
+func (q *UsageReportReqQryParam) SetChannel(channel string) {
+	q.channel = channel
+}
+
+// IsValid the range is not empty and not longer than the granularity allows
+func (q *UsageReportReqQryParam) IsValid() bool {
+	return q.from.Before(q.to) && q.to.Sub(q.from) <= q.granularity.MaxRange()
+}
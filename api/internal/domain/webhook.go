@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"microservice/internal/model"
+	"time"
+)
+
+const (
+	WebhookMessageStatusEvent  string = "message.status"
+	WebhookMessageInboundEvent string = "message.inbound"
+)
+
+type (
+	// WebhookEvent the queued status change of a message, to be posted to the tenant webhook
+	WebhookEvent struct {
+		EventId       uuid.UUID `json:"eventId"`
+		Event         string    `json:"event"`
+		TenantId      uint      `json:"tenantId"`
+		MessageId     uint      `json:"messageId"`
+		MessageUuid   uuid.UUID `json:"messageUuid"`
+		InboundId     uint      `json:"inboundId"`
+		InboundUuid   uuid.UUID `json:"inboundUuid"`
+		Mobile        string    `json:"mobile"`
+		Receiver      string    `json:"receiver"`
+		MessageText   string    `json:"messageText"`
+		Status        string    `json:"status"`
+		OccurredAt    time.Time `json:"occurredAt"`
+		Attempt       int       `json:"attempt"`
+		NextAttemptAt time.Time `json:"nextAttemptAt"`
+	}
+
+	// WebhookPayload the body of the webhook request, which is signed by the tenant webhook secret
+	WebhookPayload struct {
+		EventId     string `json:"eventId"`
+		Event       string `json:"event"`
+		MessageUuid string `json:"messageUuid,omitempty"`
+		InboundUuid string `json:"inboundUuid,omitempty"`
+		Mobile      string `json:"mobile"`
+		To          string `json:"to,omitempty"`
+		Message     string `json:"message,omitempty"`
+		Status      string `json:"status,omitempty"`
+		OccurredAt  string `json:"occurredAt"`
+	}
+
+	// WebhookDelivery a single attempt of posting a webhook event
+	WebhookDelivery struct {
+		id           uint
+		eventId      uuid.UUID
+		event        string
+		tenantId     uint
+		messageId    uint
+		inboundId    uint
+		status       string
+		url          string
+		attempt      int
+		responseCode int
+		err          string
+		succeeded    bool
+		duration     time.Duration
+		createdAt    time.Time
+	}
+)
+
+func NewWebhookEvent(status MessageStatus) *WebhookEvent {
+	return &WebhookEvent{
+		EventId:    uuid.New(),
+		Event:      WebhookMessageStatusEvent,
+		Status:     string(status),
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// NewWebhookInboundEvent the inbound message to be forwarded to the owning tenant webhook
+func NewWebhookInboundEvent(im InboundMessage) *WebhookEvent {
+	return &WebhookEvent{
+		EventId:     uuid.New(),
+		Event:       WebhookMessageInboundEvent,
+		TenantId:    im.TenantId,
+		InboundId:   im.InboundId,
+		InboundUuid: im.InboundUuid,
+		Mobile:      im.Mobile,
+		Receiver:    im.Receiver,
+		MessageText: im.MessageText,
+		OccurredAt:  im.ReceivedAt,
+	}
+}
+
+// EventType the webhook event name, the events queued before the inbound messages have no name and are status changes
+func (we *WebhookEvent) EventType() string {
+	if len(we.Event) == 0 {
+		return WebhookMessageStatusEvent
+	}
+
+	return we.Event
+}
+
+func (we *WebhookEvent) FromMessage(msg Message) {
+	we.TenantId = msg.TenantID()
+	we.MessageId = msg.ID()
+	we.MessageUuid = msg.UUID()
+	we.Mobile = msg.Mobile()
+}
+
+func (we *WebhookEvent) FromOutboxMessage(om OutboxMessage) {
+	we.TenantId = om.TenantId
+	we.MessageId = om.MessageId
+	we.MessageUuid = om.MessageUuid
+	we.Mobile = om.Mobile
+}
+
+func (we *WebhookEvent) Json() []byte {
+	value, _ := json.Marshal(we)
+	return value
+}
+
+func (we *WebhookEvent) Payload() []byte {
+	payload := WebhookPayload{
+		EventId:    we.EventId.String(),
+		Event:      we.EventType(),
+		Mobile:     we.Mobile,
+		Status:     we.Status,
+		OccurredAt: we.OccurredAt.Format(time.RFC3339),
+	}
+
+	if we.EventType() == WebhookMessageInboundEvent {
+		payload.InboundUuid = we.InboundUuid.String()
+		payload.To = we.Receiver
+		payload.Message = we.MessageText
+	} else {
+		payload.MessageUuid = we.MessageUuid.String()
+	}
+
+	value, _ := json.Marshal(payload)
+	return value
+}
+
+//
+
+func NewWebhookDelivery(event WebhookEvent, url string) *WebhookDelivery {
+	return &WebhookDelivery{
+		eventId:   event.EventId,
+		event:     event.EventType(),
+		tenantId:  event.TenantId,
+		messageId: event.MessageId,
+		inboundId: event.InboundId,
+		status:    event.Status,
+		url:       url,
+		attempt:   event.Attempt,
+	}
+}
+
+func (wd *WebhookDelivery) ID() uint { return wd.id }
+
+func (wd *WebhookDelivery) EventID() uuid.UUID { return wd.eventId }
+
+func (wd *WebhookDelivery) Event() string { return wd.event }
+
+func (wd *WebhookDelivery) Attempt() int { return wd.attempt }
+
+func (wd *WebhookDelivery) ResponseCode() int { return wd.responseCode }
+
+func (wd *WebhookDelivery) SetResponseCode(code int) { wd.responseCode = code }
+
+func (wd *WebhookDelivery) Err() string { return wd.err }
+
+func (wd *WebhookDelivery) SetErr(err string) { wd.err = err }
+
+func (wd *WebhookDelivery) Succeeded() bool { return wd.succeeded }
+
+func (wd *WebhookDelivery) SetSucceeded(succeeded bool) { wd.succeeded = succeeded }
+
+func (wd *WebhookDelivery) Duration() time.Duration { return wd.duration }
+
+func (wd *WebhookDelivery) SetDuration(duration time.Duration) { wd.duration = duration }
+
+func (wd *WebhookDelivery) CreatedAt() time.Time { return wd.createdAt }
+
+func (wd *WebhookDelivery) FromDB(src model.WebhookDeliveries) WebhookDelivery {
+	wd.id = src.ID
+	wd.eventId = src.EventUuid
+	wd.event = src.Event
+	wd.tenantId = src.TenantID
+	wd.messageId = uint(src.MessageID.Int64)
+	wd.inboundId = uint(src.InboundMessageID.Int64)
+	wd.status = src.Status.String
+	wd.url = src.Url
+	wd.attempt = src.Attempt
+	wd.responseCode = int(src.ResponseCode.Int32)
+	wd.err = src.Error.String
+	wd.succeeded = src.Succeeded
+	wd.duration = time.Duration(src.DurationMs) * time.Millisecond
+	wd.createdAt = src.CreatedAt
+
+	return *wd
+}
+
+func (wd *WebhookDelivery) ToDB() model.WebhookDeliveries {
+	return model.WebhookDeliveries{
+		ID:        wd.id,
+		EventUuid: wd.eventId,
+		Event:     wd.event,
+		TenantID:  wd.tenantId,
+		MessageID: sql.NullInt64{
+			Int64: int64(wd.messageId),
+			Valid: wd.messageId != 0,
+		},
+		InboundMessageID: sql.NullInt64{
+			Int64: int64(wd.inboundId),
+			Valid: wd.inboundId != 0,
+		},
+		Status: sql.NullString{
+			String: wd.status,
+			Valid:  len(wd.status) > 0,
+		},
+		Url:     wd.url,
+		Attempt: wd.attempt,
+		ResponseCode: sql.NullInt32{
+			Int32: int32(wd.responseCode),
+			Valid: wd.responseCode != 0,
+		},
+		Error: sql.NullString{
+			String: wd.err,
+			Valid:  len(wd.err) > 0,
+		},
+		Succeeded:  wd.succeeded,
+		DurationMs: wd.duration.Milliseconds(),
+		CreatedAt:  wd.createdAt,
+	}
+}
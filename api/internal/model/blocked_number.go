@@ -0,0 +1,14 @@
+package model
+
+import "database/sql"
+
+type BlockedNumbers struct {
+	BaseSql
+	TenantID sql.NullInt64 `json:"tenant_id"`
+	Mobile   string        `json:"mobile"`
+	Reason   string        `json:"reason"`
+}
+
+func NewBlockedNumber() *BlockedNumbers { return &BlockedNumbers{} }
+
+func (m *BlockedNumbers) TableName() string { return "blocked_numbers" }
@@ -0,0 +1,20 @@
+package model
+
+import "database/sql"
+
+type ExportJobs struct {
+	BaseSql
+	TenantID   uint           `json:"tenant_id"`
+	Resource   string         `json:"resource"`
+	Format     string         `json:"format"`
+	Filters    sql.NullString `json:"filters"`
+	Status     string         `json:"status"`
+	Rows       int64          `json:"rows"`
+	FilePath   sql.NullString `json:"file_path"`
+	Error      sql.NullString `json:"error"`
+	FinishedAt sql.NullTime   `json:"finished_at"`
+}
+
+func NewExportJob() *ExportJobs { return &ExportJobs{} }
+
+func (m *ExportJobs) TableName() string { return "export_jobs" }
@@ -1,13 +1,35 @@
 package model
 
+import (
+	"database/sql"
+	"github.com/google/uuid"
+)
+
 type Messages struct {
 	BaseSql
-	TenantID    uint     `json:"tenant_id"`
-	Mobile      string   `json:"mobile"`
-	MessageText string   `json:"message_text"`
-	MessageHash string   `json:"message_hash"`
-	Status      string   `json:"status"`
-	Outbox      Outboxes `json:"outbox,omitempty" gorm:"foreignKey:MessageID"`
+	TenantID          uint                   `json:"tenant_id"`
+	Mobile            string                 `json:"mobile"`
+	MessageText       string                 `json:"message_text"`
+	MessageHash       string                 `json:"message_hash"`
+	Status            string                 `json:"status"`
+	Segments          int                    `json:"segments"`
+	Encoding          string                 `json:"encoding"`
+	JobUuid           uuid.NullUUID          `json:"job_uuid"`
+	SendAt            sql.NullTime           `json:"send_at"`
+	ValidUntil        sql.NullTime           `json:"valid_until"`
+	TemplateID        sql.NullInt64          `json:"template_id"`
+	ProviderMessageID sql.NullString         `json:"provider_message_id"`
+	DlrState          sql.NullString         `json:"dlr_state"`
+	DlrAt             sql.NullTime           `json:"dlr_at"`
+	Sender            sql.NullString         `json:"sender"`
+	ClientRef         sql.NullString         `json:"client_ref"`
+	Operator          sql.NullString         `json:"operator"`
+	PolicyAction      sql.NullString         `json:"policy_action"`
+	PolicyReason      sql.NullString         `json:"policy_reason"`
+	TrackLinks        bool                   `json:"track_links"`
+	Tenant            Tenants                `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+	Outbox            Outboxes               `json:"outbox,omitempty" gorm:"foreignKey:MessageID"`
+	History           []MessageStatusHistory `json:"history,omitempty" gorm:"foreignKey:MessageID"`
 }
 
 func NewMessage() *Messages { return &Messages{} }
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type InboundMessages struct {
+	BaseSql
+	TenantID    uint           `json:"tenant_id"`
+	Mobile      string         `json:"mobile"`
+	Receiver    sql.NullString `json:"receiver"`
+	MessageText string         `json:"message_text"`
+	OptedOut    bool           `json:"opted_out"`
+	ReceivedAt  time.Time      `json:"received_at"`
+}
+
+func NewInboundMessage() *InboundMessages { return &InboundMessages{} }
+
+func (m *InboundMessages) TableName() string { return "inbound_messages" }
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type MessageStatusHistory struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	MessageID uint      `json:"message_id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewMessageStatusHistory() *MessageStatusHistory { return &MessageStatusHistory{} }
+
+func (m *MessageStatusHistory) TableName() string { return "message_status_history" }
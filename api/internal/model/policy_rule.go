@@ -0,0 +1,16 @@
+package model
+
+import "database/sql"
+
+type PolicyRules struct {
+	BaseSql
+	TenantID    sql.NullInt64  `json:"tenant_id"`
+	Type        string         `json:"type"`
+	Value       string         `json:"value"`
+	Action      string         `json:"action"`
+	Description sql.NullString `json:"description"`
+}
+
+func NewPolicyRule() *PolicyRules { return &PolicyRules{} }
+
+func (m *PolicyRules) TableName() string { return "policy_rules" }
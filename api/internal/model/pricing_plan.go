@@ -0,0 +1,25 @@
+package model
+
+import "database/sql"
+
+type PricingPlans struct {
+	BaseSql
+	Name        string             `json:"name"`
+	Type        string             `json:"type"`
+	Discount    float64            `json:"discount"`
+	Description sql.NullString     `json:"description"`
+	Tiers       []PricingPlanTiers `json:"tiers,omitempty" gorm:"foreignKey:PricingPlanID"`
+}
+
+func NewPricingPlan() *PricingPlans { return &PricingPlans{} }
+
+func (m *PricingPlans) TableName() string { return "pricing_plans" }
+
+type PricingPlanTiers struct {
+	ID            uint    `json:"id" gorm:"primaryKey"`
+	PricingPlanID uint    `json:"pricing_plan_id"`
+	MinVolume     int64   `json:"min_volume"`
+	Discount      float64 `json:"discount"`
+}
+
+func (m *PricingPlanTiers) TableName() string { return "pricing_plan_tiers" }
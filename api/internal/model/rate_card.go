@@ -0,0 +1,12 @@
+package model
+
+type RateCards struct {
+	BaseSql
+	Operator string  `json:"operator"`
+	Channel  string  `json:"channel"`
+	Price    float64 `json:"price"`
+}
+
+func NewRateCard() *RateCards { return &RateCards{} }
+
+func (m *RateCards) TableName() string { return "rate_cards" }
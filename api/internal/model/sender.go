@@ -0,0 +1,17 @@
+package model
+
+import "database/sql"
+
+type Senders struct {
+	BaseSql
+	TenantID    uint           `json:"tenant_id"`
+	Sender      string         `json:"sender"`
+	Status      string         `json:"status"`
+	Description sql.NullString `json:"description"`
+	ReviewedAt  sql.NullTime   `json:"reviewed_at"`
+	Tenant      Tenants        `json:"tenant,omitempty" gorm:"foreignKey:TenantID"`
+}
+
+func NewSender() *Senders { return &Senders{} }
+
+func (m *Senders) TableName() string { return "senders" }
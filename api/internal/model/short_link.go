@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+type ShortLinks struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Code      string    `json:"code"`
+	TenantID  uint      `json:"tenant_id"`
+	MessageID uint      `json:"message_id"`
+	TargetUrl string    `json:"target_url"`
+	CreatedAt time.Time `json:"created_at"`
+	Message   Messages  `json:"message,omitempty" gorm:"foreignKey:MessageID"`
+}
+
+func NewShortLink() *ShortLinks { return &ShortLinks{} }
+
+func (m *ShortLinks) TableName() string { return "short_links" }
+
+type LinkClicks struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ShortLinkID uint      `json:"short_link_id"`
+	MessageID   uint      `json:"message_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+}
+
+func NewLinkClick() *LinkClicks { return &LinkClicks{} }
+
+func (m *LinkClicks) TableName() string { return "link_clicks" }
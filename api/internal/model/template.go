@@ -0,0 +1,12 @@
+package model
+
+type Templates struct {
+	BaseSql
+	TenantID uint   `json:"tenant_id"`
+	Name     string `json:"name"`
+	Body     string `json:"body"`
+}
+
+func NewTemplate() *Templates { return &Templates{} }
+
+func (m *Templates) TableName() string { return "templates" }
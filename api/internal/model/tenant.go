@@ -1,11 +1,20 @@
 package model
 
+import "database/sql"
+
 type Tenants struct {
 	BaseSql
-	Username   string  `json:"username"`
-	TenantName string  `json:"tenant_name"`
-	Active     bool    `json:"active"`
-	Credit     Credits `json:"credit,omitempty" gorm:"foreignKey:TenantID"`
+	Username           string         `json:"username"`
+	TenantName         string         `json:"tenant_name"`
+	Active             bool           `json:"active"`
+	WebhookUrl         sql.NullString `json:"webhook_url"`
+	WebhookSecret      sql.NullString `json:"webhook_secret"`
+	DedupWindow        int            `json:"dedup_window_sec" gorm:"column:dedup_window_sec;default:60"`
+	PricingPlanID      sql.NullInt64  `json:"pricing_plan_id"`
+	QuietHoursStart    sql.NullString `json:"quiet_hours_start"`
+	QuietHoursEnd      sql.NullString `json:"quiet_hours_end"`
+	QuietHoursTimezone sql.NullString `json:"quiet_hours_timezone"`
+	Credit             Credits        `json:"credit,omitempty" gorm:"foreignKey:TenantID"`
 }
 
 func NewTenant() *Tenants { return &Tenants{} }
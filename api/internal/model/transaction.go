@@ -1,13 +1,19 @@
 package model
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type CreditTransactions struct {
-	ID            []byte    `json:"id" gorm:"type:VARCHAR(64);primaryKey;default:null"`
-	CreditID      uint      `json:"credit_id"`
-	Amount        float64   `json:"amount"`
-	MessageHashID []byte    `json:"message_hash_id"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            []byte          `json:"id" gorm:"type:VARCHAR(64);primaryKey;default:null"`
+	CreditID      uint            `json:"credit_id"`
+	Amount        float64         `json:"amount"`
+	Type          string          `json:"type"`
+	MessageHashID []byte          `json:"message_hash_id"`
+	Operator      sql.NullString  `json:"operator"`
+	UnitPrice     sql.NullFloat64 `json:"unit_price"`
+	CreatedAt     time.Time       `json:"created_at"`
 }
 
 func NewTransaction() *CreditTransactions { return &CreditTransactions{} }
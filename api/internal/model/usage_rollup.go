@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type UsageRollups struct {
+	TenantID     uint      `json:"tenant_id" gorm:"primaryKey"`
+	BucketAt     time.Time `json:"bucket_at" gorm:"primaryKey"`
+	Channel      string    `json:"channel" gorm:"primaryKey"`
+	Status       string    `json:"status" gorm:"primaryKey"`
+	Messages     int64     `json:"messages"`
+	CreditSpent  float64   `json:"credit_spent"`
+	LatencyMs    int64     `json:"latency_ms"`
+	LatencyCount int64     `json:"latency_count"`
+	Clicks       int64     `json:"clicks"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func NewUsageRollup() *UsageRollups { return &UsageRollups{} }
+
+func (m *UsageRollups) TableName() string { return "usage_rollups" }
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"time"
+)
+
+type WebhookDeliveries struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	EventUuid        uuid.UUID      `json:"event_uuid"`
+	Event            string         `json:"event"`
+	TenantID         uint           `json:"tenant_id"`
+	MessageID        sql.NullInt64  `json:"message_id"`
+	InboundMessageID sql.NullInt64  `json:"inbound_message_id"`
+	Status           sql.NullString `json:"status"`
+	Url              string         `json:"url"`
+	Attempt          int            `json:"attempt"`
+	ResponseCode     sql.NullInt32  `json:"response_code"`
+	Error            sql.NullString `json:"error"`
+	Succeeded        bool           `json:"succeeded"`
+	DurationMs       int64          `json:"duration_ms"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+func NewWebhookDelivery() *WebhookDeliveries { return &WebhookDeliveries{} }
+
+func (m *WebhookDeliveries) TableName() string { return "webhook_deliveries" }
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"time"
+)
+
+type WebhookRetries struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	EventUuid     uuid.UUID      `json:"event_uuid"`
+	TenantID      uint           `json:"tenant_id"`
+	Payload       datatypes.JSON `json:"payload"`
+	NextAttemptAt time.Time      `json:"next_attempt_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+func NewWebhookRetry() *WebhookRetries { return &WebhookRetries{} }
+
+func (m *WebhookRetries) TableName() string { return "webhook_retries" }
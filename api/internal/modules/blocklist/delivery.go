@@ -0,0 +1,276 @@
+package blocklist
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+)
+
+type (
+	IBlocklistHttpHandler interface {
+		Create(c echo.Context) error
+		Delete(c echo.Context) error
+		List(c echo.Context) error
+		CreateGlobal(c echo.Context) error
+		DeleteGlobal(c echo.Context) error
+		GlobalList(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale      locale.ILocale
+		Tracer      trace.ITracer
+		Logger      logger.ILogger
+		Metric      metric.IMetric
+		TenantUC    port.ITenantUsecase
+		BlocklistUC port.IBlocklistUsecase
+	}
+
+	Handler struct {
+		l           locale.ILocale
+		trc         trace.ITracer
+		lgr         logger.ILogger
+		metric      metric.IMetric
+		tenantUC    port.ITenantUsecase
+		blocklistUC port.IBlocklistUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) IBlocklistHttpHandler {
+	return &Handler{
+		l:           fx.Locale,
+		trc:         fx.Tracer,
+		lgr:         fx.Logger,
+		metric:      fx.Metric,
+		tenantUC:    fx.TenantUC,
+		blocklistUC: fx.BlocklistUC,
+	}
+}
+
+// Create godoc
+// @Summary Block Mobile Number
+// @Description the messages to the blocked number are rejected before charging
+// @Tags Blocklist
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body blocklist.CreateRequest true "necessary fields for request"
+// @Success 201 {object} meta.Response{data=blocklist.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "not found"
+// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/blocklist/create [post]
+func (h *Handler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	blocked, err := meta.ReqBodyToDomain[*CreateRequest, domain.BlockedNumber](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	blocked.SetTenantID(tenant.ID())
+
+	res, ucErr := h.blocklistUC.Create(ctx, blocked)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(DetailsResp(res)).Json()
+}
+
+// Delete godoc
+// @Summary Unblock Mobile Number
+// @Tags Blocklist
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Blocked Number UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no blocked number found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/blocklist/{uuid} [delete]
+func (h *Handler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	blocked, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.BlockedNumber](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	blocked.SetTenantID(tenant.ID())
+
+	if ucErr = h.blocklistUC.Delete(ctx, blocked); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
+
+// List godoc
+// @Summary Get Tenant Blocked Numbers
+// @Tags Blocklist
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, mobile, reason, created_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Mobile"
+// @Success 200 {object} meta.Response{data=blocklist.ListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/blocklist/list [get]
+func (h *Handler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	list, err := meta.ReqQryParamToDomain[*ListQryRequest, domain.BlockedNumberListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	list.SetTenantId(tenant.ID())
+
+	res, err := h.blocklistUC.GetList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ListResp(list, res)).Json()
+}
+
+// CreateGlobal godoc
+// @Summary Block Mobile Number for All Tenants
+// @Description the do-not-disturb list, the messages of all tenants to the number are rejected before charging
+// @Tags Blocklist
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param Request body blocklist.CreateRequest true "necessary fields for request"
+// @Success 201 {object} meta.Response{data=blocklist.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/blocklist/global/create [post]
+func (h *Handler) CreateGlobal(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	blocked, err := meta.ReqBodyToDomain[*CreateRequest, domain.BlockedNumber](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	blocked.SetTenantID(domain.GlobalBlocklist)
+
+	res, ucErr := h.blocklistUC.Create(ctx, blocked)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(DetailsResp(res)).Json()
+}
+
+// DeleteGlobal godoc
+// @Summary Unblock Mobile Number for All Tenants
+// @Tags Blocklist
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Blocked Number UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no blocked number found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/blocklist/global/{uuid} [delete]
+func (h *Handler) DeleteGlobal(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	blocked, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.BlockedNumber](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	blocked.SetTenantID(domain.GlobalBlocklist)
+
+	if ucErr := h.blocklistUC.Delete(ctx, blocked); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
+
+// GlobalList godoc
+// @Summary Get Global Blocked Numbers
+// @Tags Blocklist
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, mobile, reason, created_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Mobile"
+// @Success 200 {object} meta.Response{data=blocklist.ListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/blocklist/global/list [get]
+func (h *Handler) GlobalList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := meta.ReqQryParamToDomain[*ListQryRequest, domain.BlockedNumberListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	list.SetTenantId(domain.GlobalBlocklist)
+
+	res, err := h.blocklistUC.GetList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ListResp(list, res)).Json()
+}
@@ -0,0 +1,85 @@
+package blocklist
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"microservice/pkg/validator"
+	"time"
+)
+
+type CreateRequest struct {
+	Mobile string `json:"mobile" validate:"required,mobile" example:"09123456789"`
+}
+
+func (dto *CreateRequest) ToDomain() domain.BlockedNumber {
+	d := domain.NewBlockedNumber()
+	mobile, _ := validator.NormalizeMobile(dto.Mobile) // the validated number
+	d.SetMobile(mobile)
+	d.SetReason(string(domain.BlockManual))
+	return *d
+}
+
+type DetailsResponse struct {
+	Uuid      string `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Mobile    string `json:"mobile" example:"+989123456789"`
+	Reason    string `json:"reason" example:"manual"` // `manual` or `opt_out`
+	CreatedAt string `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+}
+
+func DetailsResp(src domain.BlockedNumber) DetailsResponse {
+	return DetailsResponse{
+		Uuid:      src.UUID().String(),
+		Mobile:    src.Mobile(),
+		Reason:    src.Reason(),
+		CreatedAt: src.CreatedAt().Format(time.RFC3339),
+	}
+}
+
+//
+
+type DetailsRequest struct {
+	Uuid string `json:"uuid" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+}
+
+func (dto *DetailsRequest) ToDomain() domain.BlockedNumber {
+	d := domain.NewBlockedNumber()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return *d
+}
+
+//
+
+type ListQryRequest struct {
+	dto.ListQryRequest
+}
+
+func (dto *ListQryRequest) ToDomain() domain.BlockedNumberListReqQryParam {
+	qry := domain.NewBlockedNumberListReqQryParam()
+	qry.ReqBaseQryParam = dto.EvalBaseQry()
+
+	return *qry
+}
+
+type ListResponse struct {
+	dto.ListBaseResponse
+	BlockedNumbers []DetailsResponse `json:"items"`
+}
+
+func ListResp(qry domain.BlockedNumberListReqQryParam, src domain.BlockedNumberList) ListResponse {
+	list := new(ListResponse)
+	list.Page = qry.Page()
+	list.Limit = qry.Limit()
+	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
+	list.Total = src.Total()
+	list.BlockedNumbers = make([]DetailsResponse, 0)
+
+	if len(src.List()) > 0 {
+		for _, blocked := range src.List() {
+			list.BlockedNumbers = append(list.BlockedNumbers, DetailsResp(blocked))
+		}
+	}
+
+	return *list
+}
@@ -0,0 +1,191 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.IBlocklistRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, ent domain.BlockedNumber) (res domain.BlockedNumber, err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.BlockedNumbers{})
+
+	txErr := tx.Omit("uuid").Clauses(clause.Returning{}).Create(&m).Error
+	if txErr != nil {
+		err = txErr
+		r.lgr.Error("blocklist.repo.create", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+func (r *Repository) Delete(ctx context.Context, ent domain.BlockedNumber) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := scopeTenant(db.WithContext(ctx), ent.TenantID()).
+		Where("uuid = ?", ent.UUID()).
+		Delete(&model.BlockedNumbers{})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("blocklist.repo.delete", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+// IsBlocked checks the tenant and the global lists
+func (r *Repository) IsBlocked(ctx context.Context, tenantId uint, mobile string) (res bool, err error) {
+	var total int64
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.BlockedNumbers{}).
+		Where("mobile = ? AND (tenant_id = ? OR tenant_id IS NULL)", mobile, tenantId).
+		Count(&total)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("blocklist.repo.blocked", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = total > 0
+	return
+}
+
+// GetMobiles all the blocked mobiles of the list, to be cached
+func (r *Repository) GetMobiles(ctx context.Context, tenantId uint) (res []string, err error) {
+	res = make([]string, 0)
+
+	db := r.sql.TxOf(ctx)
+	tx := scopeTenant(db.WithContext(ctx).Model(&model.BlockedNumbers{}), tenantId).
+		Pluck("mobile", &res)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("blocklist.repo.mobiles", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetList(ctx context.Context, ent domain.BlockedNumberListReqQryParam) (res domain.BlockedNumberList, err error) {
+	list := domain.NewBlockedNumberList()
+
+	var (
+		models []model.BlockedNumbers
+		total  int64
+	)
+
+	db := r.sql.TxOf(ctx)
+	tx := scopeTenant(db.WithContext(ctx).Model(&model.BlockedNumbers{}), ent.TenantId())
+
+	if ent.Items() != nil && len(ent.Items()) > 0 {
+		tx.Where("uuid IN ?", ent.Items()) // get all items
+	}
+
+	if len(ent.Search()) > 0 {
+		val := fmt.Sprintf("%%%s%%", ent.Search()) // this returns %search_value%
+		tx.Where("mobile ILIKE ?", val)
+	}
+
+	//
+
+	if err = tx.Count(&total).Error; err != nil {
+		r.lgr.Error("blocklist.repo.list.count", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	list.SetTotal(total)
+
+	//
+
+	if ent.Items() == nil {
+		tx.Offset(ent.Offset()).Limit(ent.Limit())
+	}
+
+	items := tx.Order(ent.SortOrder()).Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("blocklist.repo.list", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if items.RowsAffected > 0 {
+		list.ListFromDB(models)
+	}
+
+	res = *list
+	return
+}
+
+// HELPERS
+
+// scopeTenant limits the query to the tenant list, or to the global list for domain.GlobalBlocklist
+func scopeTenant(tx *gorm.DB, tenantId uint) *gorm.DB {
+	if tenantId == domain.GlobalBlocklist {
+		return tx.Where("tenant_id IS NULL")
+	}
+
+	return tx.Where("tenant_id = ?", tenantId)
+}
@@ -0,0 +1,176 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"microservice/internal/adapter/cache"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"time"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale        locale.ILocale
+		Tracer        trace.ITracer
+		Logger        logger.ILogger
+		Cache         cache.ICache
+		BlocklistRepo port.IBlocklistRepository
+	}
+
+	Usecase struct {
+		l             locale.ILocale
+		trc           trace.ITracer
+		lgr           logger.ILogger
+		cache         cache.ICache
+		blocklistRepo port.IBlocklistRepository
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.IBlocklistUsecase {
+	return &Usecase{
+		l:             fx.Locale,
+		trc:           fx.Tracer,
+		lgr:           fx.Logger,
+		cache:         fx.Cache,
+		blocklistRepo: fx.BlocklistRepo,
+	}
+}
+
+const (
+	// BlocklistCacheTtl the cached lists are reloaded from the database after the ttl
+	BlocklistCacheTtl = 10 * time.Minute
+	// blocklistLoaded the set member which marks the cached list as loaded, even if the list is empty
+	blocklistLoaded = "-"
+)
+
+func (uc *Usecase) Create(ctx context.Context, ent domain.BlockedNumber) (res domain.BlockedNumber, err error) {
+	res, txErr := uc.blocklistRepo.Create(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	uc.invalidate(ctx, ent.TenantID())
+	return
+}
+
+func (uc *Usecase) Delete(ctx context.Context, ent domain.BlockedNumber) (err error) {
+	if txErr := uc.blocklistRepo.Delete(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	uc.invalidate(ctx, ent.TenantID())
+	return
+}
+
+// IsBlocked checks the tenant and the global lists. the lists are read from the cache, the database is queried on cache failures
+func (uc *Usecase) IsBlocked(ctx context.Context, tenantId uint, mobile string) (res bool, err error) {
+	for _, listId := range []uint{domain.GlobalBlocklist, tenantId} {
+		blocked, cErr := uc.isCachedBlocked(ctx, listId, mobile)
+		if cErr != nil {
+			uc.lgr.Error("blocklist.cache.blocked", zap.Error(cErr))
+
+			blocked, txErr := uc.blocklistRepo.IsBlocked(ctx, tenantId, mobile)
+			if txErr != nil {
+				err = meta.EvalTxErr(txErr)
+				return
+			}
+
+			res = blocked
+			return
+		}
+
+		if blocked {
+			res = true
+			return
+		}
+	}
+
+	return
+}
+
+// OptOut blocks the mobile for the tenant on the recipient request. the already blocked mobile is ignored
+func (uc *Usecase) OptOut(ctx context.Context, tenantId uint, mobile string) (err error) {
+	ent := domain.NewBlockedNumber()
+	ent.SetTenantID(tenantId)
+	ent.SetMobile(mobile)
+	ent.SetReason(string(domain.BlockOptOut))
+
+	if _, err = uc.Create(ctx, *ent); errors.Is(err, meta.ItemExist) {
+		err = nil
+	}
+
+	return
+}
+
+func (uc *Usecase) GetList(ctx context.Context, ent domain.BlockedNumberListReqQryParam) (res domain.BlockedNumberList, err error) {
+	res, txErr := uc.blocklistRepo.GetList(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// HELPERS
+
+// isCachedBlocked the list is loaded into a redis set on the first check
+func (uc *Usecase) isCachedBlocked(ctx context.Context, listId uint, mobile string) (res bool, err error) {
+	key := blocklistCacheKey(listId)
+
+	loaded, err := uc.cache.C().Exists(ctx, key).Result()
+	if err != nil {
+		return
+	}
+
+	if loaded == 0 {
+		mobiles, txErr := uc.blocklistRepo.GetMobiles(ctx, listId)
+		if txErr != nil {
+			err = txErr
+			return
+		}
+
+		members := make([]interface{}, 0, len(mobiles)+1)
+		members = append(members, blocklistLoaded)
+		for _, m := range mobiles {
+			members = append(members, m)
+		}
+
+		pipe := uc.cache.C().TxPipeline()
+		pipe.SAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, BlocklistCacheTtl)
+
+		if _, err = pipe.Exec(ctx); err != nil {
+			return
+		}
+	}
+
+	res, err = uc.cache.C().SIsMember(ctx, key, mobile).Result()
+	return
+}
+
+// invalidate the list is reloaded on the next check
+func (uc *Usecase) invalidate(ctx context.Context, listId uint) {
+	if cErr := uc.cache.Del(ctx, blocklistCacheKey(listId)); cErr != nil {
+		uc.lgr.Error("blocklist.cache.invalidate", zap.Error(cErr))
+	}
+}
+
+func blocklistCacheKey(listId uint) string {
+	if listId == domain.GlobalBlocklist {
+		return "blocklist:global"
+	}
+
+	return fmt.Sprintf("blocklist:%d", listId)
+}
@@ -113,6 +113,11 @@ func (h *Handler) IncreaseCredit(c echo.Context) error {
 // @Param page query int false "Page Number"
 // @Param limit query int false "Page Limit"
 // @Param order query string false "`asc` or `desc` based on `created_at`"
+// @Param cursor query string false "the `nextCursor` of the former page, replaces the page and sorts by `created_at`"
+// @Param skipTotal query bool false "skips counting the `total` and `pages`"
+// @Param type query string false "`deposit`, `charge` or `refund`"
+// @Param createdFrom query string false "RFC3339, inclusive start of the creation time"
+// @Param createdTo query string false "RFC3339, exclusive end of the creation time"
 // @Success 200 {object} meta.Response{data=credit.ListResponse, error=nil} "success response"
 // @Failure	400 {object} meta.Response{data=nil} "process failure"
 // @Failure	404 {object} meta.Response{data=nil} "not found"
@@ -2,10 +2,10 @@ package credit
 
 import (
 	"encoding/hex"
-	"math"
 	"microservice/internal/domain"
 	"microservice/internal/modules/dto"
 	"microservice/pkg/utils"
+	"time"
 )
 
 type IncreaseCreditRequest struct {
@@ -38,12 +38,26 @@ func IncreaseCreditResp(src domain.Credit) IncreaseCreditResponse {
 //
 
 type ListQryRequest struct {
-	dto.ListQryRequest
+	dto.CursorListQryRequest
+	Type        string `query:"type" json:"type" validate:"omitempty,oneof=deposit charge refund"`
+	CreatedFrom string `query:"createdFrom" json:"createdFrom" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC3339, inclusive
+	CreatedTo   string `query:"createdTo" json:"createdTo" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`     // RFC3339, exclusive
 }
 
 func (dto *ListQryRequest) ToDomain() domain.TransactionListReqQryParam {
 	qry := domain.NewTransactionListReqQryParam()
 	qry.ReqBaseQryParam = dto.EvalBaseQry()
+	qry.SetType(domain.TransactionType(dto.Type))
+
+	if len(dto.CreatedFrom) > 0 {
+		createdFrom, _ := time.Parse(time.RFC3339, dto.CreatedFrom)
+		qry.SetCreatedFrom(createdFrom.UTC())
+	}
+
+	if len(dto.CreatedTo) > 0 {
+		createdTo, _ := time.Parse(time.RFC3339, dto.CreatedTo)
+		qry.SetCreatedTo(createdTo.UTC())
+	}
 
 	return *qry
 }
@@ -52,6 +66,9 @@ type (
 	ListItemDetail struct {
 		ID          string  `json:"uuid" example:"67f5627c-2d71-48f0-8afc-b7bed370bb45"`
 		Amount      float64 `json:"amount" example:"10.23"`
+		Type        string  `json:"type" example:"deposit"`
+		Operator    string  `json:"operator,omitempty" example:"mci"`    // the recipient operator of the charge and refund
+		UnitPrice   float64 `json:"unitPrice,omitempty" example:"8.455"` // the discounted segment price of the charge and refund
 		Incremented bool    `json:"incremented" example:"true"`
 		CreatedAt   string  `json:"createdAt" example:"2025-01-01 12:13:14"`
 	}
@@ -67,10 +84,7 @@ func ListResp(qry domain.TransactionListReqQryParam, src domain.Credit) ListResp
 	transactions := src.Transactions()
 
 	list := new(ListResponse)
-	list.Page = qry.Page()
-	list.Limit = qry.Limit()
-	list.Pages = int(math.Ceil(float64(transactions.Total()) / float64(qry.Limit())))
-	list.Total = transactions.Total()
+	list.ListBaseResponse = dto.EvalListBase(qry.ReqBaseQryParam, transactions.BaseList)
 	list.Balance = src.Balance()
 	list.Transactions = make([]ListItemDetail, 0)
 
@@ -81,7 +95,10 @@ func ListResp(qry domain.TransactionListReqQryParam, src domain.Credit) ListResp
 			list.Transactions = append(list.Transactions, ListItemDetail{
 				ID:          hex.EncodeToString(transaction.ID()),
 				Amount:      amount,
-				Incremented: transaction.MessageHashID() == nil,
+				Type:        string(transaction.Type()),
+				Operator:    string(transaction.Operator()),
+				UnitPrice:   transaction.UnitPrice(),
+				Incremented: transaction.Incremented(),
 				CreatedAt:   transaction.CreatedAt().Format("2006-01-02 15:04:05"),
 			})
 		}
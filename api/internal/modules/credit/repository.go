@@ -47,7 +47,7 @@ func NewRepositoryFx(fx RepositoryFx) port.ICreditRepository {
 func (r *Repository) Create(ctx context.Context, ent domain.Credit) (res domain.Credit, err error) {
 	m := ent.ToDB()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Credits{})
 
 	txErr := tx.Omit("uuid", "created_at", "deleted_at").Clauses(clause.Returning{}).Create(&m).Error
@@ -74,7 +74,7 @@ func (r *Repository) Create(ctx context.Context, ent domain.Credit) (res domain.
 func (r *Repository) GetDetails(ctx context.Context, ent domain.Credit) (res domain.Credit, err error) {
 	m := model.NewCredit()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Tenants{})
 
 	if ent.GetRelations() != nil {
@@ -104,7 +104,7 @@ func (r *Repository) GetDetails(ctx context.Context, ent domain.Credit) (res dom
 func (r *Repository) Update(ctx context.Context, ent domain.Credit) (err error) {
 	m := ent.ToDB()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Credits{}).Unscoped().
 		Omit("created_at", "deleted_at").
 		Clauses(clause.Locking{Strength: "UPDATE"}). //locking the row to avoid race condition
@@ -127,3 +127,25 @@ func (r *Repository) Update(ctx context.Context, ent domain.Credit) (err error)
 
 	return
 }
+
+// IncreaseBalance adds the amount to the current balance in place, so it is safe against concurrent updates
+func (r *Repository) IncreaseBalance(ctx context.Context, id uint, amount float64) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Credits{}).Unscoped().
+		Omit("created_at", "deleted_at").
+		Where("id = ?", id).
+		Update("balance", gorm.Expr("balance + ?", amount))
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("credit.repo.increase", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
@@ -79,6 +79,7 @@ func (uc *Usecase) IncreaseAmount(ctx context.Context, ent domain.Credit) (res d
 
 	transaction := ent.TxAmount()
 	transaction.SetID(utils.TransactionIdGen(tenant, ent, nil))
+	transaction.SetType(domain.TxDeposit)
 
 	transactionRes, txErr := uc.transactionRepo.Create(ctx, transaction)
 	if txErr != nil {
@@ -117,3 +118,40 @@ func (uc *Usecase) GetDetails(ctx context.Context, ent domain.Credit, qp domain.
 	res = ent
 	return
 }
+
+// Refund compensates the charged credit of the message. it runs within the caller's active transaction
+// and returns the zero transaction when the message is already refunded.
+func (uc *Usecase) Refund(ctx context.Context, message domain.Message) (res domain.Transaction, err error) {
+	charge, txErr := uc.transactionRepo.GetByMessageHash(ctx, []byte(message.MessageHash()), domain.TxCharge)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	refund := domain.NewTransaction()
+	refund.SetID(utils.RefundTransactionIdGen(message))
+	refund.SetCreditID(charge.CreditID())
+	refund.SetAmount(charge.Amount())
+	refund.SetType(domain.TxRefund)
+	refund.SetMessageHashID(charge.MessageHashID())
+	refund.SetOperator(charge.Operator())
+	refund.SetUnitPrice(charge.UnitPrice())
+
+	// the refund id is derived from the message, the second refund of it inserts nothing
+	res, created, txErr := uc.transactionRepo.CreateIfAbsent(ctx, *refund)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if !created {
+		return
+	}
+
+	if txErr = uc.creditRepo.IncreaseBalance(ctx, charge.CreditID(), charge.Amount()); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
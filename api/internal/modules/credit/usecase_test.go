@@ -0,0 +1,102 @@
+package credit
+
+import (
+	"context"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"testing"
+)
+
+// unitOfWork the statements of one db transaction, any failed statement aborts it as postgres does
+type unitOfWork struct {
+	aborted bool
+	balance map[uint]float64
+	txs     map[string]domain.Transaction
+}
+
+type fakeTransactionRepo struct {
+	port.ITransactionRepository
+	uow *unitOfWork
+}
+
+func (r *fakeTransactionRepo) GetByMessageHash(_ context.Context, hash []byte, txType domain.TransactionType) (domain.Transaction, error) {
+	for _, t := range r.uow.txs {
+		if string(t.MessageHashID()) == string(hash) && t.Type() == txType {
+			return t, nil
+		}
+	}
+
+	r.uow.aborted = true
+	return domain.Transaction{}, meta.NotFound
+}
+
+func (r *fakeTransactionRepo) CreateIfAbsent(_ context.Context, ent domain.Transaction) (domain.Transaction, bool, error) {
+	if _, ok := r.uow.txs[string(ent.ID())]; ok {
+		return domain.Transaction{}, false, nil
+	}
+
+	r.uow.txs[string(ent.ID())] = ent
+	return ent, true, nil
+}
+
+type fakeCreditRepo struct {
+	port.ICreditRepository
+	uow *unitOfWork
+}
+
+func (r *fakeCreditRepo) IncreaseBalance(_ context.Context, id uint, amount float64) error {
+	r.uow.balance[id] += amount
+	return nil
+}
+
+func TestRefundTwiceInOneTransaction(t *testing.T) {
+	const creditId = 7
+
+	message := domain.NewMessage()
+	message.SetMessageHash("message-hash")
+
+	charge := domain.NewTransaction()
+	charge.SetID([]byte("charge"))
+	charge.SetCreditID(creditId)
+	charge.SetAmount(250)
+	charge.SetType(domain.TxCharge)
+	charge.SetMessageHashID([]byte(message.MessageHash()))
+
+	uow := &unitOfWork{
+		balance: map[uint]float64{creditId: 0},
+		txs:     map[string]domain.Transaction{string(charge.ID()): *charge},
+	}
+
+	uc := &Usecase{
+		creditRepo:      &fakeCreditRepo{uow: uow},
+		transactionRepo: &fakeTransactionRepo{uow: uow},
+	}
+
+	tests := []struct {
+		name   string
+		amount float64
+	}{
+		{name: "first refund", amount: 250},
+		{name: "duplicate refund", amount: 0},
+	}
+
+	for _, tt := range tests {
+		res, err := uc.Refund(context.Background(), *message)
+		if err != nil {
+			t.Fatalf("%s: Refund() err = %v, want nil", tt.name, err)
+		}
+
+		if res.Amount() != tt.amount {
+			t.Errorf("%s: Refund() amount = %v, want %v", tt.name, res.Amount(), tt.amount)
+		}
+	}
+
+	if uow.aborted {
+		t.Error("the transaction is aborted by the duplicate refund")
+	}
+
+	if uow.balance[creditId] != 250 {
+		t.Errorf("balance = %v, want 250", uow.balance[creditId])
+	}
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/google/uuid"
+	"math"
 	"microservice/internal/domain"
 )
 
@@ -50,9 +51,46 @@ func (dto *ListQryRequest) EvalBaseQry() domain.ReqBaseQryParam {
 	return *d
 }
 
+// CursorListQryRequest the list query of the large lists, which are paginated by the keyset cursor as well
+type CursorListQryRequest struct {
+	ListQryRequest
+	Cursor    string `query:"cursor" json:"cursor" validate:"omitempty,max=128,pagination_cursor"` // the `nextCursor` of the former page, the page is ignored and the list is sorted by "created_at"
+	SkipTotal bool   `query:"skipTotal" json:"skipTotal"`                                          // skips counting the items, the `total` and `pages` are zero
+}
+
+func (dto *CursorListQryRequest) EvalBaseQry() domain.ReqBaseQryParam {
+	d := dto.ListQryRequest.EvalBaseQry()
+
+	if len(dto.Cursor) > 0 {
+		cursor, _ := domain.DecodeListCursor(dto.Cursor) // validated by the `pagination_cursor`
+		d.SetCursor(cursor)
+	}
+
+	d.SetSkipTotal(dto.SkipTotal)
+
+	return d
+}
+
 type ListBaseResponse struct {
-	Page  int   `json:"page" example:"1"`
-	Limit int   `json:"limit" example:"10"`
-	Pages int   `json:"pages" example:"5"`
-	Total int64 `json:"total" example:"45"`
+	Page       int    `json:"page" example:"1"`
+	Limit      int    `json:"limit" example:"10"`
+	Pages      int    `json:"pages" example:"5"`
+	Total      int64  `json:"total" example:"45"`
+	NextCursor string `json:"nextCursor,omitempty" example:"MTc1OTI5NDgwMDAwMDAwMHw0Mg"` // the cursor of the next page, empty on the last page
+}
+
+// EvalListBase the pagination details of the list, the total and pages are not counted on `skipTotal`
+func EvalListBase(qry domain.ReqBaseQryParam, src domain.BaseList) ListBaseResponse {
+	res := ListBaseResponse{
+		Page:       qry.Page(),
+		Limit:      qry.Limit(),
+		NextCursor: src.NextCursor().Encode(),
+	}
+
+	if !qry.SkipTotal() {
+		res.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
+		res.Total = src.Total()
+	}
+
+	return res
 }
@@ -0,0 +1,360 @@
+package export
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+	"net/http"
+	"time"
+)
+
+type (
+	IExportHttpHandler interface {
+		Messages(c echo.Context) error
+		Transactions(c echo.Context) error
+		MessagesJob(c echo.Context) error
+		TransactionsJob(c echo.Context) error
+		JobDetails(c echo.Context) error
+		Download(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale   locale.ILocale
+		Tracer   trace.ITracer
+		Logger   logger.ILogger
+		Metric   metric.IMetric
+		TenantUC port.ITenantUsecase
+		ExportUC port.IExportUsecase
+	}
+
+	Handler struct {
+		l        locale.ILocale
+		trc      trace.ITracer
+		lgr      logger.ILogger
+		metric   metric.IMetric
+		tenantUC port.ITenantUsecase
+		exportUC port.IExportUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) IExportHttpHandler {
+	return &Handler{
+		l:        fx.Locale,
+		trc:      fx.Tracer,
+		lgr:      fx.Logger,
+		metric:   fx.Metric,
+		tenantUC: fx.TenantUC,
+		exportUC: fx.ExportUC,
+	}
+}
+
+// Messages godoc
+// @Summary Export Messages
+// @Description streams the filtered messages as `csv` or `jsonl` (JSON Lines). the request is bound to the server write timeout, the large exports have to run as a job
+// @Tags Export
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param format query string true "`csv` or `jsonl`"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Message"
+// @Param status query string false "`queued`, `sending`, `sent`, `delivered`, `failed`, `scheduled`, `canceled`, `expired`, `held` or `rejected`"
+// @Param channel query string false "one of the configured channels, e.g. `event.prod`"
+// @Param mobile query string false "the exact recipient mobile, local or international format"
+// @Param mobilePrefix query string false "the leading digits of the recipient mobile, local (`0912`) or international (`98912`)"
+// @Param createdFrom query string false "RFC3339, inclusive start of the creation time"
+// @Param createdTo query string false "RFC3339, exclusive end of the creation time"
+// @Param clientRef query string false "the tenant own reference of the message"
+// @Success 200 {file} file "the exported rows"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/export/messages [get]
+func (h *Handler) Messages(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	job, err := meta.ReqQryParamToDomain[*MessagesQryRequest, domain.ExportJob](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	qry := job.MessageQry()
+	qry.SetTenantId(tenant.ID())
+	job.SetMessageQry(qry)
+
+	return h.stream(c, job)
+}
+
+// Transactions godoc
+// @Summary Export Credit Transactions
+// @Description streams the filtered credit transactions as `csv` or `jsonl` (JSON Lines). the request is bound to the server write timeout, the large exports have to run as a job
+// @Tags Export
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param format query string true "`csv` or `jsonl`"
+// @Param order query string false "`asc` or `desc` based on `created_at`"
+// @Param type query string false "`deposit`, `charge` or `refund`"
+// @Param createdFrom query string false "RFC3339, inclusive start of the creation time"
+// @Param createdTo query string false "RFC3339, exclusive end of the creation time"
+// @Success 200 {file} file "the exported rows"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/export/transactions [get]
+func (h *Handler) Transactions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	job, err := meta.ReqQryParamToDomain[*TransactionsQryRequest, domain.ExportJob](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	credit := tenant.Credit()
+	qry := job.TransactionQry()
+	qry.SetRelId(credit.ID())
+	job.SetTransactionQry(qry)
+
+	return h.stream(c, job)
+}
+
+// MessagesJob godoc
+// @Summary Create Messages Export Job
+// @Description the export of the filtered messages runs in the background, the file is downloadable once the job is `completed`
+// @Tags Export
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param format query string true "`csv` or `jsonl`"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Message"
+// @Param status query string false "`queued`, `sending`, `sent`, `delivered`, `failed`, `scheduled`, `canceled`, `expired`, `held` or `rejected`"
+// @Param channel query string false "one of the configured channels, e.g. `event.prod`"
+// @Param mobile query string false "the exact recipient mobile, local or international format"
+// @Param mobilePrefix query string false "the leading digits of the recipient mobile, local (`0912`) or international (`98912`)"
+// @Param createdFrom query string false "RFC3339, inclusive start of the creation time"
+// @Param createdTo query string false "RFC3339, exclusive end of the creation time"
+// @Param clientRef query string false "the tenant own reference of the message"
+// @Success 201 {object} meta.Response{data=export.JobDetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/export/messages/job [post]
+func (h *Handler) MessagesJob(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	job, err := meta.ReqQryParamToDomain[*MessagesQryRequest, domain.ExportJob](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	qry := job.MessageQry()
+	qry.SetTenantId(tenant.ID())
+	job.SetMessageQry(qry)
+	job.SetTenantID(tenant.ID())
+	job.SetFilters(c.QueryString())
+
+	res, ucErr := h.exportUC.CreateJob(ctx, job)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(JobDetailsResp(res)).Json()
+}
+
+// TransactionsJob godoc
+// @Summary Create Credit Transactions Export Job
+// @Description the export of the filtered credit transactions runs in the background, the file is downloadable once the job is `completed`
+// @Tags Export
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param format query string true "`csv` or `jsonl`"
+// @Param order query string false "`asc` or `desc` based on `created_at`"
+// @Param type query string false "`deposit`, `charge` or `refund`"
+// @Param createdFrom query string false "RFC3339, inclusive start of the creation time"
+// @Param createdTo query string false "RFC3339, exclusive end of the creation time"
+// @Success 201 {object} meta.Response{data=export.JobDetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/export/transactions/job [post]
+func (h *Handler) TransactionsJob(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	job, err := meta.ReqQryParamToDomain[*TransactionsQryRequest, domain.ExportJob](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	credit := tenant.Credit()
+	qry := job.TransactionQry()
+	qry.SetRelId(credit.ID())
+	job.SetTransactionQry(qry)
+	job.SetTenantID(tenant.ID())
+	job.SetFilters(c.QueryString())
+
+	res, ucErr := h.exportUC.CreateJob(ctx, job)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(JobDetailsResp(res)).Json()
+}
+
+// JobDetails godoc
+// @Summary Get Export Job Details
+// @Tags Export
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Export Job UUID" example(5e0a7f8c-3d2b-4c1a-9f6e-8b7d6c5a4e3f)
+// @Success 200 {object} meta.Response{data=export.JobDetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no job found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/export/job/{uuid} [get]
+func (h *Handler) JobDetails(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	job, err := h.tenantJob(c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.exportUC.GetJob(ctx, job)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(JobDetailsResp(res)).Json()
+}
+
+// Download godoc
+// @Summary Download Export Job File
+// @Tags Export
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Export Job UUID" example(5e0a7f8c-3d2b-4c1a-9f6e-8b7d6c5a4e3f)
+// @Success 200 {file} file "the exported rows"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no job found"
+// @Failure	409 {object} meta.Response{data=nil} "the job is not completed or its file is expired"
+// @Router /api/v1/export/job/{uuid}/download [get]
+func (h *Handler) Download(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	job, err := h.tenantJob(c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.exportUC.GetJob(ctx, job)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	if res.Status() == string(domain.ExportExpired) {
+		return meta.Resp(c, h.l).ServiceErr(meta.Conflict.SetErr(h.l.Get("export_expired"))).Json()
+	}
+
+	if !res.IsDownloadable() {
+		return meta.Resp(c, h.l).ServiceErr(meta.Conflict.SetErr(h.l.Get("export_not_ready"))).Json()
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, ContentType(res.Format()))
+	return c.Attachment(res.FilePath(), res.FileName())
+}
+
+// HELPERS
+
+// stream writes the export rows as the response body, the failures after the first row only abort the response
+func (h *Handler) stream(c echo.Context, job domain.ExportJob) error {
+	name := fmt.Sprintf("%s-%s.%s", job.Resource(), time.Now().UTC().Format("20060102150405"), job.Format())
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, ContentType(job.Format()))
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name))
+	res.WriteHeader(http.StatusOK)
+
+	rows, err := h.exportUC.Write(c.Request().Context(), job, res)
+	if err != nil {
+		h.lgr.Error("export.http.stream",
+			zap.String("resource", string(job.Resource())),
+			zap.Int64("rows", rows),
+			zap.Error(err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (h *Handler) tenantJob(c echo.Context) (res domain.ExportJob, err error) {
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return
+	}
+
+	res, err = meta.ReqRouteParamsToDomain[*JobDetailsRequest, domain.ExportJob](c)
+	if err != nil {
+		return
+	}
+
+	tenant, err := h.tenantUC.GetDetails(c.Request().Context(), req)
+	if err != nil {
+		return
+	}
+
+	res.SetTenantID(tenant.ID())
+	return
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"github.com/google/uuid"
+	"microservice/internal/domain"
+	"microservice/internal/modules/credit"
+	"microservice/internal/modules/message"
+	"time"
+)
+
+// MessagesQryRequest the message list filters along with the export format, the pagination is ignored
+type MessagesQryRequest struct {
+	message.ListQryRequest
+	Format string `query:"format" json:"format" validate:"required,oneof=csv jsonl" example:"csv"`
+}
+
+func (dto *MessagesQryRequest) ToDomain() domain.ExportJob {
+	d := domain.NewExportJob()
+	d.SetResource(domain.ExportMessages)
+	d.SetFormat(domain.ExportFormat(dto.Format))
+	d.SetMessageQry(dto.ListQryRequest.ToDomain())
+	return *d
+}
+
+// TransactionsQryRequest the credit transaction list filters along with the export format, the pagination is ignored
+type TransactionsQryRequest struct {
+	credit.ListQryRequest
+	Format string `query:"format" json:"format" validate:"required,oneof=csv jsonl" example:"csv"`
+}
+
+func (dto *TransactionsQryRequest) ToDomain() domain.ExportJob {
+	d := domain.NewExportJob()
+	d.SetResource(domain.ExportTransactions)
+	d.SetFormat(domain.ExportFormat(dto.Format))
+	d.SetTransactionQry(dto.ListQryRequest.ToDomain())
+	return *d
+}
+
+type JobDetailsRequest struct {
+	Uuid string `param:"uuid" validate:"required,uuid"`
+}
+
+func (dto *JobDetailsRequest) ToDomain() domain.ExportJob {
+	d := domain.NewExportJob()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return *d
+}
+
+type JobDetailsResponse struct {
+	Uuid       string `json:"uuid" example:"5e0a7f8c-3d2b-4c1a-9f6e-8b7d6c5a4e3f"`
+	Resource   string `json:"resource" example:"messages"`
+	Format     string `json:"format" example:"csv"`
+	Status     string `json:"status" example:"completed"`
+	Rows       int64  `json:"rows" example:"120000"`
+	Error      string `json:"error,omitempty" example:""`
+	CreatedAt  string `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+	FinishedAt string `json:"finishedAt,omitempty" example:"2025-10-01T05:01:12Z"`
+}
+
+func JobDetailsResp(src domain.ExportJob) JobDetailsResponse {
+	resp := JobDetailsResponse{
+		Uuid:      src.UUID().String(),
+		Resource:  string(src.Resource()),
+		Format:    string(src.Format()),
+		Status:    src.Status(),
+		Rows:      src.Rows(),
+		Error:     src.Err(),
+		CreatedAt: src.CreatedAt().Format(time.RFC3339),
+	}
+
+	if !src.FinishedAt().IsZero() {
+		resp.FinishedAt = src.FinishedAt().Format(time.RFC3339)
+	}
+
+	return resp
+}
@@ -0,0 +1,163 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"time"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.IExportRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, ent domain.ExportJob) (res domain.ExportJob, err error) {
+	m := ent.ToDB()
+	columns := []string{"uuid"}
+
+	if m.Status == "" {
+		columns = append(columns, "status") // the db default status
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.ExportJobs{})
+
+	if err = tx.Omit(columns...).Clauses(clause.Returning{}).Create(&m).Error; err != nil {
+		r.lgr.Error("export.repo.create", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+// GetDetails the job of the tenant by its uuid
+func (r *Repository) GetDetails(ctx context.Context, ent domain.ExportJob) (res domain.ExportJob, err error) {
+	m := model.NewExportJob()
+
+	db := r.sql.TxOf(ctx)
+	u := db.WithContext(ctx).Model(&model.ExportJobs{}).
+		First(&m, "uuid = ? AND tenant_id = ?", ent.UUID(), ent.TenantID())
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("export.repo.detail", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewExportJob()
+	res.FromDB(*m)
+	return
+}
+
+// UpdateResult sets the progress or the final result of the job
+func (r *Repository) UpdateResult(ctx context.Context, ent domain.ExportJob) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.ExportJobs{}).
+		Where("id = ?", ent.ID()).
+		Updates(map[string]interface{}{
+			"status":      m.Status,
+			"rows":        m.Rows,
+			"file_path":   m.FilePath,
+			"error":       m.Error,
+			"finished_at": m.FinishedAt,
+		})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("export.repo.update.result", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+// FailUnfinished fails the pending and running jobs, their runner is gone along with the former process
+func (r *Repository) FailUnfinished(ctx context.Context, reason string) (res int64, err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.ExportJobs{}).
+		Where("status IN ?", []string{string(domain.ExportPending), string(domain.ExportRunning)}).
+		Updates(map[string]interface{}{
+			"status":      string(domain.ExportFailed),
+			"error":       reason,
+			"finished_at": time.Now().UTC(),
+		})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("export.repo.fail.unfinished", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = tx.RowsAffected
+	return
+}
+
+// ListExpired the completed jobs finished before the given time, their files are due to be removed
+func (r *Repository) ListExpired(ctx context.Context, before time.Time) (res []domain.ExportJob, err error) {
+	var list []model.ExportJobs
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.ExportJobs{}).
+		Where("status = ? AND finished_at < ?", string(domain.ExportCompleted), before).
+		Find(&list)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("export.repo.list.expired", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = make([]domain.ExportJob, 0, len(list))
+	for _, m := range list {
+		res = append(res, domain.NewExportJob().FromDB(m))
+	}
+
+	return
+}
@@ -0,0 +1,285 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"io"
+	"microservice/config"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/registry"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/utils"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale          locale.ILocale
+		Tracer          trace.ITracer
+		Logger          logger.ILogger
+		Lifecycle       fx.Lifecycle
+		Tx              orm.ISqlTx
+		Registry        registry.IRegistry
+		ExportRepo      port.IExportRepository
+		MessageRepo     port.IMessageRepository
+		TransactionRepo port.ITransactionRepository
+	}
+
+	Usecase struct {
+		l               locale.ILocale
+		trc             trace.ITracer
+		lgr             logger.ILogger
+		tx              orm.ISqlTx
+		config          config.Export
+		exportRepo      port.IExportRepository
+		messageRepo     port.IMessageRepository
+		transactionRepo port.ITransactionRepository
+		done            chan struct{}
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.IExportUsecase {
+	uc := &Usecase{
+		l:               fx.Locale,
+		trc:             fx.Tracer,
+		lgr:             fx.Logger,
+		tx:              fx.Tx,
+		exportRepo:      fx.ExportRepo,
+		messageRepo:     fx.MessageRepo,
+		transactionRepo: fx.TransactionRepo,
+		done:            make(chan struct{}),
+	}
+
+	if err := fx.Registry.Parse(&uc.config); err != nil {
+		utils.PrintStd(utils.StdPanic, "export", "config parse err: %s", err)
+	}
+
+	uc.Fx(fx.Lifecycle)
+
+	return uc
+}
+
+const (
+	// JobTimeout the longest run of a background export
+	JobTimeout = 2 * time.Hour
+	// FileTtl the default lifetime of the export files
+	FileTtl = 24 * time.Hour
+	// fileSweepTick the interval of removing the expired export files
+	fileSweepTick = time.Hour
+)
+
+// Fx fails the jobs left unfinished by the former process on the startup and sweeps the expired files while running
+func (uc *Usecase) Fx(lc fx.Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) (err error) {
+			failed, txErr := uc.exportRepo.FailUnfinished(ctx, "export interrupted by the restart")
+			if txErr != nil {
+				uc.lgr.Error("export.job.db.unfinished", zap.Error(txErr))
+			} else if failed > 0 {
+				utils.PrintStd(utils.StdLog, "export", "%d unfinished jobs failed", failed)
+			}
+
+			go uc.sweepFiles()
+			return
+		},
+		OnStop: func(ctx context.Context) (err error) {
+			close(uc.done)
+			return
+		},
+	})
+}
+
+// Write streams the rows of the export resource into the writer, the rows are read from the db one by one
+func (uc *Usecase) Write(ctx context.Context, ent domain.ExportJob, w io.Writer) (rows int64, err error) {
+	rw := newRecordWriter(ent.Format(), w)
+
+	switch ent.Resource() {
+	case domain.ExportMessages:
+		if err = rw.WriteHeader(messageColumns); err != nil {
+			return
+		}
+
+		err = uc.messageRepo.Stream(ctx, ent.MessageQry(), func(m domain.Message) error {
+			rows++
+			return rw.WriteRecord(messageRecord(m))
+		})
+	case domain.ExportTransactions:
+		if err = rw.WriteHeader(transactionColumns); err != nil {
+			return
+		}
+
+		err = uc.transactionRepo.Stream(ctx, ent.TransactionQry(), func(t domain.Transaction) error {
+			rows++
+			return rw.WriteRecord(transactionRecord(t))
+		})
+	default:
+		err = meta.Validate
+		return
+	}
+
+	if err != nil {
+		return
+	}
+
+	err = rw.Flush()
+	return
+}
+
+// CreateJob the export runs in the background, its file is downloadable once the job is completed
+func (uc *Usecase) CreateJob(ctx context.Context, ent domain.ExportJob) (res domain.ExportJob, err error) {
+	ent.SetStatus(string(domain.ExportPending))
+
+	job, txErr := uc.exportRepo.Create(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	// the filters are not persisted, they are carried to the runner
+	job.SetMessageQry(ent.MessageQry())
+	job.SetTransactionQry(ent.TransactionQry())
+
+	go uc.runJob(job)
+
+	res = job
+	return
+}
+
+func (uc *Usecase) GetJob(ctx context.Context, ent domain.ExportJob) (res domain.ExportJob, err error) {
+	res, txErr := uc.exportRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// HELPERS
+
+// runJob writes the export file of the job and records the result. it is detached from the request context
+func (uc *Usecase) runJob(job domain.ExportJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), JobTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			uc.lgr.Error("export.job.recover", zap.Uint("job.id", job.ID()), zap.Any("panic", r))
+			uc.finishJob(ctx, job, 0, errors.New("export interrupted"))
+		}
+	}()
+
+	job.SetStatus(string(domain.ExportRunning))
+	if err := uc.exportRepo.UpdateResult(ctx, job); err != nil {
+		uc.lgr.Error("export.job.db.running", zap.Uint("job.id", job.ID()), zap.Error(err))
+	}
+
+	dir := uc.exportDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		uc.finishJob(ctx, job, 0, err)
+		return
+	}
+
+	path := filepath.Join(dir, job.FileName())
+
+	file, err := os.Create(path)
+	if err != nil {
+		uc.finishJob(ctx, job, 0, err)
+		return
+	}
+
+	rows, err := uc.Write(ctx, job, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path)
+		uc.finishJob(ctx, job, rows, err)
+		return
+	}
+
+	job.SetFilePath(path)
+	uc.finishJob(ctx, job, rows, nil)
+}
+
+func (uc *Usecase) finishJob(ctx context.Context, job domain.ExportJob, rows int64, err error) {
+	job.SetRows(rows)
+	job.SetFinishedAt(time.Now().UTC())
+	job.SetStatus(string(domain.ExportCompleted))
+
+	if err != nil {
+		uc.lgr.Error("export.job.failed", zap.Uint("job.id", job.ID()), zap.Error(err))
+
+		job.SetStatus(string(domain.ExportFailed))
+		job.SetErr(err.Error())
+	}
+
+	if dbErr := uc.exportRepo.UpdateResult(ctx, job); dbErr != nil {
+		//todo: set grafana/prometheus alarm
+		uc.lgr.Error("export.job.db.result", zap.Uint("job.id", job.ID()), zap.Error(dbErr))
+	}
+}
+
+// sweepFiles removes the files of the completed jobs after their ttl, the jobs are marked as expired
+func (uc *Usecase) sweepFiles() {
+	ticker := time.NewTicker(fileSweepTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-uc.done:
+			return
+		case <-ticker.C:
+			uc.expireFiles(context.Background())
+		}
+	}
+}
+
+func (uc *Usecase) expireFiles(ctx context.Context) {
+	jobs, err := uc.exportRepo.ListExpired(ctx, time.Now().UTC().Add(-uc.fileTtl()))
+	if err != nil {
+		uc.lgr.Error("export.job.db.expired", zap.Error(err))
+		return
+	}
+
+	for _, job := range jobs {
+		if err = os.Remove(job.FilePath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			uc.lgr.Error("export.job.file.remove", zap.Uint("job.id", job.ID()), zap.Error(err))
+			continue
+		}
+
+		job.SetFilePath("")
+		job.SetStatus(string(domain.ExportExpired))
+
+		if err = uc.exportRepo.UpdateResult(ctx, job); err != nil {
+			uc.lgr.Error("export.job.db.expire", zap.Uint("job.id", job.ID()), zap.Error(err))
+		}
+	}
+}
+
+func (uc *Usecase) fileTtl() time.Duration {
+	if uc.config.Ttl > 0 {
+		return time.Duration(uc.config.Ttl) * time.Hour
+	}
+
+	return FileTtl
+}
+
+func (uc *Usecase) exportDir() string {
+	if len(uc.config.Dir) > 0 {
+		return uc.config.Dir
+	}
+
+	return filepath.Join(os.TempDir(), "exports")
+}
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"microservice/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// flushRows the count of the rows which are buffered before flushing them to the client
+const flushRows = 500
+
+var (
+	messageColumns = []string{
+		"uuid", "mobile", "operator", "from", "message", "status", "segments", "encoding",
+		"client_ref", "send_at", "dlr_state", "dlr_at", "created_at",
+	}
+
+	transactionColumns = []string{"id", "type", "amount", "incremented", "operator", "unit_price", "created_at"}
+)
+
+// recordWriter writes the exported rows in the requested format, the rows are flushed periodically to keep the
+// memory use constant
+type recordWriter interface {
+	WriteHeader(columns []string) error
+	WriteRecord(values []string) error
+	Flush() error
+}
+
+func newRecordWriter(format domain.ExportFormat, w io.Writer) recordWriter {
+	if format == domain.ExportJsonl {
+		return &jsonlWriter{w: w, buf: bufio.NewWriter(w)}
+	}
+
+	return &csvWriter{w: w, csv: csv.NewWriter(w)}
+}
+
+// ContentType the http content type of the format
+func ContentType(format domain.ExportFormat) string {
+	if format == domain.ExportJsonl {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv"
+}
+
+//
+
+type csvWriter struct {
+	w     io.Writer
+	csv   *csv.Writer
+	count int
+}
+
+func (cw *csvWriter) WriteHeader(columns []string) error {
+	return cw.csv.Write(columns)
+}
+
+func (cw *csvWriter) WriteRecord(values []string) error {
+	if err := cw.csv.Write(values); err != nil {
+		return err
+	}
+
+	cw.count++
+	if cw.count%flushRows == 0 {
+		return cw.Flush()
+	}
+
+	return nil
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.csv.Flush()
+	flushHttp(cw.w)
+	return cw.csv.Error()
+}
+
+//
+
+type jsonlWriter struct {
+	w       io.Writer
+	buf     *bufio.Writer
+	columns []string
+	count   int
+}
+
+func (jw *jsonlWriter) WriteHeader(columns []string) error {
+	jw.columns = columns
+	return nil
+}
+
+func (jw *jsonlWriter) WriteRecord(values []string) error {
+	record := make(map[string]string, len(jw.columns))
+	for i, column := range jw.columns {
+		record[column] = values[i]
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err = jw.buf.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	jw.count++
+	if jw.count%flushRows == 0 {
+		return jw.Flush()
+	}
+
+	return nil
+}
+
+func (jw *jsonlWriter) Flush() error {
+	err := jw.buf.Flush()
+	flushHttp(jw.w)
+	return err
+}
+
+// HELPERS
+
+// flushHttp sends the buffered response to the client when the rows are streamed over http
+func flushHttp(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func messageRecord(m domain.Message) []string {
+	return []string{
+		m.UUID().String(),
+		m.Mobile(),
+		string(m.Operator()),
+		m.Sender(),
+		m.MessageText(),
+		m.Status(),
+		strconv.Itoa(m.Segments()),
+		m.Encoding(),
+		m.ClientRef(),
+		formatTime(m.SendAt()),
+		m.DlrState(),
+		formatTime(m.DlrAt()),
+		formatTime(m.CreatedAt()),
+	}
+}
+
+func transactionRecord(t domain.Transaction) []string {
+	return []string{
+		hex.EncodeToString(t.ID()),
+		string(t.Type()),
+		strconv.FormatFloat(t.Amount(), 'f', 4, 64),
+		strconv.FormatBool(t.Incremented()),
+		string(t.Operator()),
+		formatPrice(t.UnitPrice()),
+		formatTime(t.CreatedAt()),
+	}
+}
+
+// formatPrice the deposits have no unit price
+func formatPrice(price float64) string {
+	if price == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(price, 'f', 4, 64)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
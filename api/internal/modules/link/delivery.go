@@ -0,0 +1,69 @@
+package link
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"net/http"
+)
+
+type (
+	ILinkHttpHandler interface {
+		Redirect(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		LinkUC port.ILinkUsecase
+	}
+
+	Handler struct {
+		l      locale.ILocale
+		trc    trace.ITracer
+		lgr    logger.ILogger
+		linkUC port.ILinkUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) ILinkHttpHandler {
+	return &Handler{
+		l:      fx.Locale,
+		trc:    fx.Tracer,
+		lgr:    fx.Logger,
+		linkUC: fx.LinkUC,
+	}
+}
+
+// Redirect godoc
+// @Summary Short Link Redirect
+// @Description the short link of the tracked message, the click is counted against the message and redirected to the original link
+// @Tags Link
+// @Param code path string true "Short Link Code" example(aZ3kP9q)
+// @Success 302 "redirect to the original link"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no link found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /l/{code} [get]
+func (h *Handler) Redirect(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	link, err := meta.ReqRouteParamsToDomain[*RedirectRequest, domain.ShortLink](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.linkUC.Click(ctx, link)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return c.Redirect(http.StatusFound, res.TargetUrl())
+}
@@ -0,0 +1,13 @@
+package link
+
+import "microservice/internal/domain"
+
+type RedirectRequest struct {
+	Code string `json:"code" param:"code" validate:"required,alphanum,max=16" example:"aZ3kP9q"`
+}
+
+func (dto *RedirectRequest) ToDomain() domain.ShortLink {
+	d := domain.NewShortLink()
+	d.SetCode(dto.Code)
+	return *d
+}
@@ -0,0 +1,122 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.ILinkRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+// CreateBatch the short links are created along with their message
+func (r *Repository) CreateBatch(ctx context.Context, ents []domain.ShortLink) (err error) {
+	if len(ents) == 0 {
+		return
+	}
+
+	m := make([]model.ShortLinks, 0, len(ents))
+	for _, ent := range ents {
+		m = append(m, ent.ToDB())
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.ShortLinks{})
+
+	if err = tx.Omit("created_at", "Message").CreateInBatches(&m, 500).Error; err != nil {
+		r.lgr.Error("link.repo.create.batch", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+// GetByCode the short link along with its message, the channel of the message is loaded for the usage report
+func (r *Repository) GetByCode(ctx context.Context, code string) (res domain.ShortLink, err error) {
+	m := model.NewShortLink()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.ShortLinks{}).
+		Preload("Message").
+		Preload("Message.Outbox").
+		First(&m, "code = ?", code)
+
+	if err = tx.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("link.repo.code", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = domain.NewShortLink().FromDB(*m)
+	return
+}
+
+func (r *Repository) CreateClick(ctx context.Context, ent domain.LinkClick) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.LinkClicks{})
+
+	if err = tx.Create(&m).Error; err != nil {
+		r.lgr.Error("link.repo.click.create", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+// CountClicks the clicks of all the short links of the message
+func (r *Repository) CountClicks(ctx context.Context, messageId uint) (res int64, err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.LinkClicks{}).
+		Where("message_id = ?", messageId).
+		Count(&res)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("link.repo.click.count", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
@@ -0,0 +1,87 @@
+package link
+
+import (
+	"context"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"microservice/config"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/queue"
+	"microservice/internal/adapter/registry"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/utils"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale   locale.ILocale
+		Tracer   trace.ITracer
+		Logger   logger.ILogger
+		Registry registry.IRegistry
+		LinkRepo port.ILinkRepository
+		Queue    queue.IQueue
+	}
+
+	Usecase struct {
+		l        locale.ILocale
+		trc      trace.ITracer
+		lgr      logger.ILogger
+		config   config.Link
+		linkRepo port.ILinkRepository
+		queue    queue.IQueue
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.ILinkUsecase {
+	uc := &Usecase{
+		l:        fx.Locale,
+		trc:      fx.Tracer,
+		lgr:      fx.Logger,
+		linkRepo: fx.LinkRepo,
+		queue:    fx.Queue,
+	}
+
+	if err := fx.Registry.Parse(&uc.config); err != nil {
+		utils.PrintStd(utils.StdPanic, "link", "config parse err: %s", err)
+	}
+
+	// the short links are sent to the phones, the internal address of the http server would not reach them
+	if len(uc.config.BaseUrl) == 0 {
+		utils.PrintStd(utils.StdPanic, "link", "SHORT_LINK_BASE_URL is required")
+	}
+
+	return uc
+}
+
+// Shorten replaces the links of the text by the short links, the links are created by the caller along with the message
+func (uc *Usecase) Shorten(text string) (string, []domain.ShortLink) {
+	return domain.ShortenLinks(text, uc.config.BaseUrl, func() string {
+		return utils.RandomAlphaNum(domain.ShortLinkCodeLength)
+	})
+}
+
+// Click records the click of the short link against its message, the target is redirected to.
+// a click failed to be recorded does not stop the redirect
+func (uc *Usecase) Click(ctx context.Context, ent domain.ShortLink) (res domain.ShortLink, err error) {
+	res, txErr := uc.linkRepo.GetByCode(ctx, ent.Code())
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if txErr = uc.linkRepo.CreateClick(ctx, *domain.NewLinkClick(res)); txErr != nil {
+		uc.lgr.Error("link.click.create", zap.String("code", res.Code()), zap.Error(txErr))
+		return
+	}
+
+	usage := domain.NewUsageEvent(res.TenantID(), res.Channel(), domain.LinkClicked, 0)
+	usage.SetClicks(1)
+	uc.queue.Track(ctx, *usage)
+
+	return
+}
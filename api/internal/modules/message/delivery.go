@@ -18,6 +18,13 @@ import (
 type (
 	IMessageHttpHandler interface {
 		Send(c echo.Context) error
+		SendBulk(c echo.Context) error
+		BulkProgress(c echo.Context) error
+		Details(c echo.Context) error
+		Cancel(c echo.Context) error
+		Dlr(c echo.Context) error
+		Inbound(c echo.Context) error
+		InboundList(c echo.Context) error
 		List(c echo.Context) error
 	}
 
@@ -57,17 +64,19 @@ func NewHttpHandlerFx(fx HandlerFx) IMessageHttpHandler {
 
 // Send godoc
 // @Summary Send Message
-// @Description request body channel values `event.prod` or `event.express`
+// @Description request body channel is one of the configured channels, e.g. `event.prod` or `event.express`. the optional `sendAt` schedules the message. either `message` or `templateId` along with its `variables` is required. the same message to the same mobile is rejected within the tenant deduplication window. the retried request with the same `Idempotency-Key` replays the accepted message. the non-urgent channel messages due in the tenant quiet hours are `scheduled` to the window end. the optional `validUntil` or `ttl` expires the message not sent in time, and refunds it. the content policy rejects the message, or holds it as `held` until the admin review. the optional `trackLinks` replaces the links by the short links and counts their clicks, the segments are counted on the shortened text
 // @Tags Message
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Idempotency-Key header string false "Client request key, kept for 24 hours" example(order-1234-otp)
 // @Param Request body message.SendMessageRequest true "necessary fields for request"
-// @Success 201 {object} meta.Response{error=nil} "success response"
+// @Success 201 {object} meta.Response{data=message.SendResponse, error=nil} "success response"
 // @Failure	400 {object} meta.Response{data=nil} "process failure"
 // @Failure	404 {object} meta.Response{data=nil} "not found"
-// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	403 {object} meta.Response{data=nil} "recipient blocked or content rejected"
+// @Failure	409 {object} meta.Response{data=nil} "duplicate message or the request with the same key is in progress"
 // @Failure	422 {object} meta.Response{data=nil} "unprocessable"
 // @Router /api/v1/message/send [post]
 func (h *Handler) Send(c echo.Context) error {
@@ -78,6 +87,11 @@ func (h *Handler) Send(c echo.Context) error {
 		return meta.Resp(c, h.l).ServiceErr(err).Json()
 	}
 
+	idempotencyKey, err := meta.ReqHeaderToDomain[*IdempotencyKeyRequest, string](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
 	message, err := meta.ReqBodyToDomain[*SendMessageRequest, domain.Message](c)
 	if err != nil {
 		return meta.Resp(c, h.l).ServiceErr(err).Json()
@@ -89,13 +103,278 @@ func (h *Handler) Send(c echo.Context) error {
 	}
 
 	message.SetTenantID(tenant.ID())
+	message.SetIdempotencyKey(idempotencyKey)
+
+	res, ucErr := h.messageUC.Send(ctx, tenant, message)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(SendResp(res)).Json()
+}
+
+// SendBulk godoc
+// @Summary Send Message to Many Recipients
+// @Description the same message is sent to all valid recipients. each recipient result is `accepted`, `invalid_number`, `blocked` or `duplicate`. the non-urgent channel batch arriving in the tenant quiet hours is `scheduled` to the window end. the content policy rejects the whole batch, or holds all of its messages as `held` until the admin review
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body message.BulkSendMessageRequest true "necessary fields for request"
+// @Success 201 {object} meta.Response{data=message.BulkSendResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "not found"
+// @Failure	403 {object} meta.Response{data=nil} "content rejected"
+// @Failure	409 {object} meta.Response{data=nil} "not enough credit"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/message/bulk [post]
+func (h *Handler) SendBulk(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	bulk, err := meta.ReqBodyToDomain[*BulkSendMessageRequest, domain.MessageBulk](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	res, ucErr := h.messageUC.SendBulk(ctx, tenant, bulk)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(BulkSendResp(res)).Json()
+}
+
+// BulkProgress godoc
+// @Summary Get Bulk Send Job Progress
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param jobId path string true "Bulk Job ID" example(d5f1e2a4-6c1b-4f0e-9a0a-3f1b2c3d4e5f)
+// @Success 200 {object} meta.Response{data=message.BulkProgressResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no job found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/message/bulk/{jobId} [get]
+func (h *Handler) BulkProgress(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	bulk, err := meta.ReqRouteParamsToDomain[*BulkProgressRequest, domain.MessageBulk](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	bulk.SetTenantID(tenant.ID())
+
+	res, ucErr := h.messageUC.GetBulkProgress(ctx, bulk)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(BulkProgressResp(res)).Json()
+}
+
+// Details godoc
+// @Summary Get Message Details
+// @Description the message along with its outbox retries, the charged price, the short link clicks and the timeline of the status changes
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Message UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{data=message.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no message found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/message/{uuid} [get]
+func (h *Handler) Details(c echo.Context) error {
+	ctx := c.Request().Context()
 
-	_, ucErr = h.messageUC.Send(ctx, tenant, message)
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	message, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.Message](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
 	if ucErr != nil {
 		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
 	}
 
-	return meta.Resp(c, h.l).Status(status.Success).Json()
+	res, ucErr := h.messageUC.GetDetails(ctx, tenant, message)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
+// Cancel godoc
+// @Summary Cancel Scheduled Message
+// @Description only the messages in `scheduled` status are canceled, and their reserved credit is refunded
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Message UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{data=message.CancelResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no message found"
+// @Failure	409 {object} meta.Response{data=nil} "message is not scheduled"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/message/{uuid}/cancel [post]
+func (h *Handler) Cancel(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	message, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.Message](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	res, ucErr := h.messageUC.Cancel(ctx, tenant, message)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(CancelResp(res)).Json()
+}
+
+// Dlr godoc
+// @Summary Receive Provider Delivery Report
+// @Description the provider callback of the message delivery state. `DELIVRD` is mapped to `delivered`, and `UNDELIV`, `REJECTD` and `EXPIRED` to `failed`. the intermediate states are ignored. the failed messages are refunded once
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param X.PROVIDER.SECRET header string true "Provider Callback Secret"
+// @Param Request body message.DlrRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=message.DlrResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid provider secret"
+// @Failure	404 {object} meta.Response{data=nil} "no message found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/message/dlr [post]
+func (h *Handler) Dlr(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	dlr, err := meta.ReqBodyToDomain[*DlrRequest, domain.MessageDlr](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.messageUC.Deliver(ctx, dlr)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DlrResp(res)).Json()
+}
+
+// Inbound godoc
+// @Summary Receive Provider Inbound Message
+// @Description the provider callback of the mobile originated messages. the message belongs to the tenant which owns the `to` line, or else to the tenant which has last messaged the mobile. the message is stored and forwarded to the tenant webhook as a `message.inbound` event. the opt-out keywords, like `STOP`, block the mobile for the tenant
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param X.PROVIDER.SECRET header string true "Provider Callback Secret"
+// @Param Request body message.InboundRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=message.InboundResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid provider secret"
+// @Failure	404 {object} meta.Response{data=nil} "no message found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/message/inbound [post]
+func (h *Handler) Inbound(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	inbound, err := meta.ReqBodyToDomain[*InboundRequest, domain.MessageInbound](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.messageUC.Receive(ctx, inbound)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(InboundResp(res)).Json()
+}
+
+// InboundList godoc
+// @Summary Get Inbound Message List
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, received_at, created_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Message or the Mobile"
+// @Success 200 {object} meta.Response{data=message.InboundListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/message/inbound [get]
+func (h *Handler) InboundList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	list, err := meta.ReqQryParamToDomain[*InboundListQryRequest, domain.MessageInboundListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	list.SetTenantId(tenant.ID())
+
+	res, err := h.messageUC.GetInboundList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(InboundListResp(list, res)).Json()
 }
 
 // List godoc
@@ -108,7 +387,16 @@ func (h *Handler) Send(c echo.Context) error {
 // @Param limit query int false "Page Limit"
 // @Param sort query string false "id, created_at, updated_at\n(other valid columns are acceptable)"
 // @Param order query string false "`asc` or `desc`"
+// @Param cursor query string false "the `nextCursor` of the former page, replaces the page and sorts by `created_at`"
+// @Param skipTotal query bool false "skips counting the `total` and `pages`"
 // @Param search query string false "Search the Message"
+// @Param status query string false "`queued`, `sending`, `sent`, `delivered`, `failed`, `scheduled`, `canceled`, `expired`, `held` or `rejected`"
+// @Param channel query string false "one of the configured channels, e.g. `event.prod`"
+// @Param mobile query string false "the exact recipient mobile, local or international format"
+// @Param mobilePrefix query string false "the leading digits of the recipient mobile, local (`0912`) or international (`98912`)"
+// @Param createdFrom query string false "RFC3339, inclusive start of the creation time"
+// @Param createdTo query string false "RFC3339, exclusive end of the creation time"
+// @Param clientRef query string false "the tenant own reference of the message"
 // @Success 200 {object} meta.Response{data=message.ListResponse, error=nil} "success response"
 // @Failure	400 {object} meta.Response{data=nil} "process failure"
 // @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
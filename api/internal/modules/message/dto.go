@@ -1,46 +1,465 @@
 package message
 
 import (
+	"github.com/google/uuid"
 	"math"
 	"microservice/internal/domain"
 	"microservice/internal/modules/dto"
+	"microservice/pkg/validator"
+	"time"
 )
 
 type SendMessageRequest struct {
-	Channel string `json:"channel" validate:"required,ascii,oneof=event.prod event.express" example:"event.prod"`
-	Mobile  string `json:"mobile" validate:"required,mobile" example:"09123456789"`
-	Message string `json:"message"  validate:"required,fa_alphanum" example:"some dummy message"`
+	Channel    string            `json:"channel" validate:"required,ascii,channel" example:"event.prod"`
+	Mobile     string            `json:"mobile" validate:"required,mobile" example:"09123456789"`
+	Message    string            `json:"message"  validate:"required_without=TemplateId,excluded_with=TemplateId,fa_sms" example:"some dummy message"`
+	TemplateId string            `json:"templateId" validate:"omitempty,uuid" example:"67f5627c-2d71-48f0-8afc-b7bed370bb45"` // replaces the message by the rendered template
+	Variables  map[string]string `json:"variables" validate:"omitempty,dive,keys,required,alphanum,endkeys,max=255" example:"code:1234"`
+	SendAt     string            `json:"sendAt" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T08:30:00+03:30"`     // RFC3339, optional future time
+	From       string            `json:"from" validate:"omitempty,alphanum,max=32" example:"98100020003000"`                                     // an approved sender of the tenant, the default line if empty
+	ClientRef  string            `json:"clientRef" validate:"omitempty,printascii,max=64" example:"order-1024"`                                  // the tenant own reference, searchable in the list
+	ValidUntil string            `json:"validUntil" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T08:32:00+03:30"` // RFC3339, the message is expired if not sent until
+	Ttl        int               `json:"ttl" validate:"omitempty,min=30,max=604800,excluded_with=ValidUntil" example:"120"`                      // seconds from the send time, instead of validUntil
+	TrackLinks bool              `json:"trackLinks" example:"true"`                                                                              // replaces the links by the short links and counts their clicks
 }
 
 func (dto *SendMessageRequest) ToDomain() domain.Message {
 	d := domain.NewMessage()
 	d.SetChannel(dto.Channel)
-	d.SetMobile(dto.Mobile)
+	mobile, _ := validator.NormalizeMobile(dto.Mobile) // the validated number
+	d.SetMobile(mobile)
 	d.SetMessageText(dto.Message)
+	d.SetSender(dto.From)
+	d.SetClientRef(dto.ClientRef)
+	d.SetTrackLinks(dto.TrackLinks)
+
+	if len(dto.TemplateId) > 0 {
+		template := domain.NewTemplate()
+		template.SetUUID(uuid.MustParse(dto.TemplateId))
+		d.SetTemplate(*template)
+		d.SetVariables(dto.Variables)
+	}
+
+	if len(dto.SendAt) > 0 {
+		sendAt, _ := time.Parse(time.RFC3339, dto.SendAt)
+		d.SetSendAt(sendAt.UTC())
+	}
+
+	if len(dto.ValidUntil) > 0 {
+		validUntil, _ := time.Parse(time.RFC3339, dto.ValidUntil)
+		d.SetValidUntil(validUntil.UTC())
+	}
+
+	// the ttl of the scheduled message starts from its send time
+	if dto.Ttl > 0 {
+		from := time.Now().UTC()
+		if d.SendAt().After(from) {
+			from = d.SendAt()
+		}
+
+		d.SetValidUntil(from.Add(time.Duration(dto.Ttl) * time.Second))
+	}
+
+	return *d
+}
+
+// IdempotencyKeyRequest the optional client key of the send request
+type IdempotencyKeyRequest struct {
+	Key string `header:"Idempotency-Key" json:"idempotencyKey" validate:"omitempty,printascii,max=255"`
+}
+
+func (dto *IdempotencyKeyRequest) ToDomain() string {
+	return dto.Key
+}
+
+type SendResponse struct {
+	Uuid   string `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Status string `json:"status" example:"queued"`
+}
+
+func SendResp(src domain.Message) SendResponse {
+	return SendResponse{
+		Uuid:   src.UUID().String(),
+		Status: src.Status(),
+	}
+}
+
+//
+
+type DetailsRequest struct {
+	Uuid string `json:"uuid" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+}
+
+func (dto *DetailsRequest) ToDomain() domain.Message {
+	d := domain.NewMessage()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
 	return *d
 }
 
+type (
+	DetailsOutbox struct {
+		Status  string `json:"status" example:"published"`
+		Retries int    `json:"retries" example:"1"`
+		RetryAt string `json:"retryAt,omitempty" example:"2025-10-01T05:00:09Z"`
+	}
+
+	DetailsHistory struct {
+		Status    string `json:"status" example:"sent"`
+		CreatedAt string `json:"createdAt" example:"2025-10-01T05:00:03Z"`
+	}
+
+	DetailsResponse struct {
+		Uuid              string           `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+		Channel           string           `json:"channel" example:"event.prod"`
+		Mobile            string           `json:"mobile" example:"+989123456789"`
+		Operator          string           `json:"operator,omitempty" example:"mci"` // `mci`, `irancell`, `rightel` or `international`
+		Message           string           `json:"message" example:"Hello R1 Cloud"`
+		Status            string           `json:"status" example:"delivered"`
+		Segments          int              `json:"segments" example:"1"`
+		Encoding          string           `json:"encoding" example:"GSM-7"`
+		Price             float64          `json:"price" example:"8.9"`
+		JobId             string           `json:"jobId,omitempty" example:"d5f1e2a4-6c1b-4f0e-9a0a-3f1b2c3d4e5f"`
+		ProviderMessageId string           `json:"providerMessageId,omitempty" example:"4f1d2c3b-7a6e-4b8f-9c0d-1e2f3a4b5c6d"`
+		From              string           `json:"from,omitempty" example:"98100020003000"`
+		ClientRef         string           `json:"clientRef,omitempty" example:"order-1024"`
+		SendAt            string           `json:"sendAt,omitempty" example:"2025-10-01T05:00:00Z"`
+		ValidUntil        string           `json:"validUntil,omitempty" example:"2025-10-01T05:02:00Z"`
+		DlrAt             string           `json:"dlrAt,omitempty" example:"2025-10-01T05:00:04Z"`
+		TrackLinks        bool             `json:"trackLinks" example:"true"`
+		Clicks            int64            `json:"clicks" example:"3"` // the short link clicks, zero if the links are not tracked
+		CreatedAt         string           `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+		Outbox            DetailsOutbox    `json:"outbox"`
+		History           []DetailsHistory `json:"history"`
+	}
+)
+
+func DetailsResp(src domain.Message) DetailsResponse {
+	outbox := src.Outbox()
+
+	resp := DetailsResponse{
+		Uuid:              src.UUID().String(),
+		Channel:           src.Channel(),
+		Mobile:            src.Mobile(),
+		Operator:          string(src.Operator()),
+		Message:           src.MessageText(),
+		Status:            src.Status(),
+		Segments:          src.Segments(),
+		Encoding:          src.Encoding(),
+		Price:             src.Price(),
+		ProviderMessageId: src.ProviderMessageID(),
+		From:              src.Sender(),
+		ClientRef:         src.ClientRef(),
+		TrackLinks:        src.TrackLinks(),
+		Clicks:            src.Clicks(),
+		CreatedAt:         src.CreatedAt().Format(time.RFC3339),
+		Outbox: DetailsOutbox{
+			Status:  outbox.Status(),
+			Retries: outbox.Retries(),
+		},
+		History: make([]DetailsHistory, 0),
+	}
+
+	if src.JobID() != uuid.Nil {
+		resp.JobId = src.JobID().String()
+	}
+
+	if !src.SendAt().IsZero() {
+		resp.SendAt = src.SendAt().Format(time.RFC3339)
+	}
+
+	if !src.ValidUntil().IsZero() {
+		resp.ValidUntil = src.ValidUntil().Format(time.RFC3339)
+	}
+
+	if !src.DlrAt().IsZero() {
+		resp.DlrAt = src.DlrAt().Format(time.RFC3339)
+	}
+
+	if !outbox.RetryAt().IsZero() {
+		resp.Outbox.RetryAt = outbox.RetryAt().Format(time.RFC3339)
+	}
+
+	for _, change := range src.History() {
+		resp.History = append(resp.History, DetailsHistory{
+			Status:    change.Status(),
+			CreatedAt: change.CreatedAt().Format(time.RFC3339),
+		})
+	}
+
+	return resp
+}
+
+type CancelResponse struct {
+	Uuid   string `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Status string `json:"status" example:"canceled"`
+}
+
+func CancelResp(src domain.Message) CancelResponse {
+	return CancelResponse{
+		Uuid:   src.UUID().String(),
+		Status: src.Status(),
+	}
+}
+
 //
 
+type DlrRequest struct {
+	MessageId   string `json:"messageId" validate:"required,printascii,max=128" example:"4f1d2c3b-7a6e-4b8f-9c0d-1e2f3a4b5c6d"` // the provider message id
+	Status      string `json:"status" validate:"required,alpha,max=32" example:"DELIVRD"`
+	DeliveredAt string `json:"deliveredAt" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T08:30:05+03:30"` // RFC3339, the report time by default
+}
+
+func (dto *DlrRequest) ToDomain() domain.MessageDlr {
+	d := domain.NewMessageDlr()
+	d.SetProviderMessageID(dto.MessageId)
+	d.SetState(dto.Status)
+
+	if len(dto.DeliveredAt) > 0 {
+		deliveredAt, _ := time.Parse(time.RFC3339, dto.DeliveredAt)
+		d.SetReportedAt(deliveredAt.UTC())
+	}
+
+	return *d
+}
+
+type DlrResponse struct {
+	Uuid   string `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Status string `json:"status" example:"delivered"`
+}
+
+func DlrResp(src domain.Message) DlrResponse {
+	return DlrResponse{
+		Uuid:   src.UUID().String(),
+		Status: src.Status(),
+	}
+}
+
 //
 
-type ListQryRequest struct {
+type InboundRequest struct {
+	Mobile     string `json:"mobile" validate:"required,mobile" example:"09123456789"`          // the sender mobile
+	To         string `json:"to" validate:"omitempty,alphanum,max=32" example:"98100020003000"` // the destination line, the provider default line if empty
+	Message    string `json:"message" validate:"required,max=1600" example:"STOP"`
+	ReceivedAt string `json:"receivedAt" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T08:30:05+03:30"` // RFC3339, the report time by default
+}
+
+func (dto *InboundRequest) ToDomain() domain.MessageInbound {
+	d := domain.NewMessageInbound()
+	mobile, _ := validator.NormalizeMobile(dto.Mobile) // the validated number
+	d.SetMobile(mobile)
+	d.SetReceiver(dto.To)
+	d.SetMessageText(dto.Message)
+	d.SetReceivedAt(time.Now().UTC())
+
+	if len(dto.ReceivedAt) > 0 {
+		receivedAt, _ := time.Parse(time.RFC3339, dto.ReceivedAt)
+		d.SetReceivedAt(receivedAt.UTC())
+	}
+
+	return *d
+}
+
+type InboundResponse struct {
+	Uuid     string `json:"uuid" example:"2b0c5a3e-8f4d-4c1a-9e6b-7d2f1a0c3b4e"`
+	OptedOut bool   `json:"optedOut" example:"true"`
+}
+
+func InboundResp(src domain.MessageInbound) InboundResponse {
+	return InboundResponse{
+		Uuid:     src.UUID().String(),
+		OptedOut: src.OptedOut(),
+	}
+}
+
+type InboundListQryRequest struct {
 	dto.ListQryRequest
 }
 
+func (dto *InboundListQryRequest) ToDomain() domain.MessageInboundListReqQryParam {
+	qry := domain.NewMessageInboundListReqQryParam()
+	qry.ReqBaseQryParam = dto.EvalBaseQry()
+
+	return *qry
+}
+
+type (
+	InboundListItemDetail struct {
+		Uuid       string `json:"uuid" example:"2b0c5a3e-8f4d-4c1a-9e6b-7d2f1a0c3b4e"`
+		Mobile     string `json:"mobile" example:"+989123456789"`
+		To         string `json:"to,omitempty" example:"98100020003000"`
+		Message    string `json:"message" example:"Yes, confirmed"`
+		OptedOut   bool   `json:"optedOut" example:"false"`
+		ReceivedAt string `json:"receivedAt" example:"2025-10-01T05:00:05Z"`
+	}
+
+	InboundListResponse struct {
+		dto.ListBaseResponse
+		Messages []InboundListItemDetail `json:"items"`
+	}
+)
+
+func InboundListResp(qry domain.MessageInboundListReqQryParam, src domain.MessageInboundList) InboundListResponse {
+	list := new(InboundListResponse)
+	list.Page = qry.Page()
+	list.Limit = qry.Limit()
+	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
+	list.Total = src.Total()
+	list.Messages = make([]InboundListItemDetail, 0)
+
+	for _, message := range src.List() {
+		list.Messages = append(list.Messages, InboundListItemDetail{
+			Uuid:       message.UUID().String(),
+			Mobile:     message.Mobile(),
+			To:         message.Receiver(),
+			Message:    message.MessageText(),
+			OptedOut:   message.OptedOut(),
+			ReceivedAt: message.ReceivedAt().Format(time.RFC3339),
+		})
+	}
+
+	return *list
+}
+
+//
+
+type BulkSendMessageRequest struct {
+	Channel string   `json:"channel" validate:"required,ascii,channel" example:"event.prod"`
+	Mobiles []string `json:"mobiles" validate:"required,min=1,max=1000" example:"09123456789,09121234567"`
+	Message string   `json:"message"  validate:"required,fa_alphanum" example:"some dummy message"`
+}
+
+func (dto *BulkSendMessageRequest) ToDomain() domain.MessageBulk {
+	d := domain.NewMessageBulk()
+	d.SetChannel(dto.Channel)
+	d.SetRecipients(dto.Mobiles)
+	d.SetMessageText(dto.Message)
+	return *d
+}
+
+type (
+	BulkItemDetail struct {
+		Mobile string `json:"mobile" example:"+989123456789"`
+		Result string `json:"result" example:"accepted"`
+		Uuid   string `json:"uuid,omitempty" example:"67f5627c-2d71-48f0-8afc-b7bed370bb45"`
+	}
+
+	BulkSendResponse struct {
+		JobId    string           `json:"jobId" example:"d5f1e2a4-6c1b-4f0e-9a0a-3f1b2c3d4e5f"`
+		Total    int              `json:"total" example:"3"`
+		Accepted int              `json:"accepted" example:"2"`
+		Items    []BulkItemDetail `json:"items"`
+	}
+)
+
+func BulkSendResp(src domain.MessageBulk) BulkSendResponse {
+	resp := BulkSendResponse{
+		JobId:    src.JobID().String(),
+		Total:    len(src.Items()),
+		Accepted: src.Accepted(),
+		Items:    make([]BulkItemDetail, 0),
+	}
+
+	for _, item := range src.Items() {
+		detail := BulkItemDetail{
+			Mobile: item.Mobile(),
+			Result: string(item.Result()),
+		}
+
+		if message := item.Message(); message.UUID() != uuid.Nil {
+			detail.Uuid = message.UUID().String()
+		}
+
+		resp.Items = append(resp.Items, detail)
+	}
+
+	return resp
+}
+
+//
+
+type BulkProgressRequest struct {
+	JobId string `json:"jobId" param:"jobId" validate:"required,uuid" example:"d5f1e2a4-6c1b-4f0e-9a0a-3f1b2c3d4e5f"`
+}
+
+func (dto *BulkProgressRequest) ToDomain() domain.MessageBulk {
+	d := domain.NewMessageBulk()
+	d.SetJobID(uuid.MustParse(dto.JobId))
+	return *d
+}
+
+type BulkProgressResponse struct {
+	JobId    string           `json:"jobId" example:"d5f1e2a4-6c1b-4f0e-9a0a-3f1b2c3d4e5f"`
+	Total    int64            `json:"total" example:"120"`
+	Statuses map[string]int64 `json:"statuses"`
+}
+
+func BulkProgressResp(src domain.MessageBulk) BulkProgressResponse {
+	resp := BulkProgressResponse{
+		JobId:    src.JobID().String(),
+		Statuses: src.Progress(),
+	}
+
+	for _, count := range src.Progress() {
+		resp.Total += count
+	}
+
+	return resp
+}
+
+//
+
+type ListQryRequest struct {
+	dto.CursorListQryRequest
+	Status       string `query:"status" json:"status" validate:"omitempty,oneof=queued sending sent delivered failed scheduled canceled expired held rejected"`
+	Channel      string `query:"channel" json:"channel" validate:"omitempty,channel"`
+	Mobile       string `query:"mobile" json:"mobile" validate:"omitempty,mobile"`                                       // the exact recipient
+	MobilePrefix string `query:"mobilePrefix" json:"mobilePrefix" validate:"omitempty,numeric,max=15"`                   // the leading digits of the recipient, local (0912) or international (98912)
+	CreatedFrom  string `query:"createdFrom" json:"createdFrom" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC3339, inclusive
+	CreatedTo    string `query:"createdTo" json:"createdTo" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`     // RFC3339, exclusive
+	ClientRef    string `query:"clientRef" json:"clientRef" validate:"omitempty,printascii,max=64"`
+}
+
 func (dto *ListQryRequest) ToDomain() domain.MessageListReqQryParam {
 	qry := domain.NewMessageListReqQryParam()
 	qry.ReqBaseQryParam = dto.EvalBaseQry()
+	qry.SetStatus(dto.Status)
+	qry.SetChannel(dto.Channel)
+	if len(dto.Mobile) > 0 {
+		mobile, _ := validator.NormalizeMobile(dto.Mobile)
+		qry.SetMobile(mobile)
+	}
+
+	if len(dto.MobilePrefix) > 0 {
+		qry.SetMobilePrefix(validator.NormalizeMobilePrefix(dto.MobilePrefix))
+	}
+	qry.SetClientRef(dto.ClientRef)
+
+	if len(dto.CreatedFrom) > 0 {
+		createdFrom, _ := time.Parse(time.RFC3339, dto.CreatedFrom)
+		qry.SetCreatedFrom(createdFrom.UTC())
+	}
+
+	if len(dto.CreatedTo) > 0 {
+		createdTo, _ := time.Parse(time.RFC3339, dto.CreatedTo)
+		qry.SetCreatedTo(createdTo.UTC())
+	}
 
 	return *qry
 }
 
 type (
 	ListItemDetail struct {
-		Channel string `json:"channel" example:"event.prod"`
-		Mobile  string `json:"mobile" example:"09123456789"`
-		Message string `json:"message" example:"Hello R1 Cloud"`
-		Status  string `json:"status" example:"sent"`
+		Uuid      string `json:"uuid" example:"67f5627c-2d71-48f0-8afc-b7bed370bb45"`
+		Channel   string `json:"channel" example:"event.prod"`
+		Mobile    string `json:"mobile" example:"+989123456789"`
+		Operator  string `json:"operator,omitempty" example:"mci"`
+		Message   string `json:"message" example:"Hello R1 Cloud"`
+		Status    string `json:"status" example:"sent"`
+		Segments  int    `json:"segments" example:"1"`
+		Encoding  string `json:"encoding" example:"GSM-7"`
+		SendAt    string `json:"sendAt,omitempty" example:"2025-10-01T05:00:00Z"`
+		DlrAt     string `json:"dlrAt,omitempty" example:"2025-10-01T05:00:04Z"`
+		ClientRef string `json:"clientRef,omitempty" example:"order-1024"`
 	}
 
 	ListResponse struct {
@@ -51,20 +470,32 @@ type (
 
 func ListResp(qry domain.MessageListReqQryParam, src domain.MessageList) ListResponse {
 	list := new(ListResponse)
-	list.Page = qry.Page()
-	list.Limit = qry.Limit()
-	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
-	list.Total = src.Total()
+	list.ListBaseResponse = dto.EvalListBase(qry.ReqBaseQryParam, src.BaseList)
 	list.Messages = make([]ListItemDetail, 0)
 
 	if len(src.List()) > 0 {
 		for _, message := range src.List() {
-			list.Messages = append(list.Messages, ListItemDetail{
-				Channel: message.Channel(),
-				Mobile:  message.Mobile(),
-				Message: message.MessageText(),
-				Status:  message.Status(),
-			})
+			item := ListItemDetail{
+				Uuid:      message.UUID().String(),
+				Channel:   message.Channel(),
+				Mobile:    message.Mobile(),
+				Operator:  string(message.Operator()),
+				Message:   message.MessageText(),
+				Status:    message.Status(),
+				Segments:  message.Segments(),
+				Encoding:  message.Encoding(),
+				ClientRef: message.ClientRef(),
+			}
+
+			if !message.SendAt().IsZero() {
+				item.SendAt = message.SendAt().Format(time.RFC3339)
+			}
+
+			if !message.DlrAt().IsZero() {
+				item.DlrAt = message.DlrAt().Format(time.RFC3339)
+			}
+
+			list.Messages = append(list.Messages, item)
 		}
 	}
 
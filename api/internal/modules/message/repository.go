@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -17,6 +18,8 @@ import (
 	"microservice/internal/model"
 	"microservice/internal/modules/port"
 	"microservice/pkg/meta"
+	"strconv"
+	"time"
 )
 
 type (
@@ -47,11 +50,16 @@ func NewRepositoryFx(fx RepositoryFx) port.IMessageRepository {
 
 func (r *Repository) Create(ctx context.Context, ent domain.Message) (res domain.Message, err error) {
 	m := ent.ToDB()
+	columns := []string{"uuid"}
 
-	db := r.sql.Tx()
+	if m.Status == "" {
+		columns = append(columns, "status") // the db default status
+	}
+
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Messages{})
 
-	txErr := tx.Omit("uuid", "status").Clauses(clause.Returning{}).Create(&m).Error
+	txErr := tx.Omit(columns...).Clauses(clause.Returning{}).Create(&m).Error
 	if txErr != nil {
 		err = txErr
 		r.lgr.Error("message.repo.create", zap.Error(err))
@@ -72,14 +80,92 @@ func (r *Repository) Create(ctx context.Context, ent domain.Message) (res domain
 	return
 }
 
+func (r *Repository) CreateBatch(ctx context.Context, ents []domain.Message) (res []domain.Message, err error) {
+	list := domain.NewMessageList()
+	list.SetList(ents)
+	m := list.ListToDB()
+	columns := []string{"uuid"}
+
+	if len(m) > 0 && m[0].Status == "" {
+		columns = append(columns, "status") // the db default status, the batch messages share the status
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Messages{})
+
+	txErr := tx.Omit(columns...).Clauses(clause.Returning{}).CreateInBatches(&m, 500).Error
+	if txErr != nil {
+		err = txErr
+		r.lgr.Error("message.repo.create.batch", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	res = make([]domain.Message, 0, len(m))
+	for i, item := range m {
+		msg := ents[i]
+		res = append(res, msg.FromDB(item))
+	}
+
+	return
+}
+
+func (r *Repository) GetJobProgress(ctx context.Context, tenantId uint, jobId uuid.UUID) (res map[string]int64, err error) {
+	var rows []struct {
+		Status string
+		Total  int64
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Messages{}).
+		Select("status, COUNT(*) AS total").
+		Where("tenant_id = ? AND job_uuid = ?", tenantId, jobId).
+		Group("status").
+		Scan(&rows)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("message.repo.job.progress", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if len(rows) == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = make(map[string]int64)
+	for _, row := range rows {
+		res[row.Status] = row.Total
+	}
+
+	return
+}
+
 func (r *Repository) GetDetails(ctx context.Context, ent domain.Message) (res domain.Message, err error) {
 	m := model.NewMessage()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Messages{})
 
 	if ent.GetRelations() != nil {
 		for _, rel := range ent.GetRelations() {
+			if rel == "History" { // the timeline is kept in order of occurrence
+				tx = tx.Preload(rel, func(db *gorm.DB) *gorm.DB {
+					return db.Order("created_at ASC, id ASC")
+				})
+				continue
+			}
+
 			tx = tx.Preload(rel)
 		}
 	}
@@ -105,7 +191,7 @@ func (r *Repository) GetDetails(ctx context.Context, ent domain.Message) (res do
 func (r *Repository) Update(ctx context.Context, ent domain.Message) (err error) {
 	m := ent.ToDB()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Messages{}).Where("uuid = ?", ent.UUID()).Updates(m)
 	if err = tx.Error; err != nil {
 		r.lgr.Error("message.repo.update", zap.Error(err))
@@ -126,7 +212,7 @@ func (r *Repository) Update(ctx context.Context, ent domain.Message) (err error)
 }
 
 func (r *Repository) UpdateStatus(ctx context.Context, id uint, status string) (err error) {
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Messages{}).Where("id = ?", id).Update("status", status)
 	if err = tx.Error; err != nil {
 		r.lgr.Error("message.repo.update", zap.Error(err))
@@ -146,6 +232,215 @@ func (r *Repository) UpdateStatus(ctx context.Context, id uint, status string) (
 	return
 }
 
+// UpdateStatusUnless updates the message status only if the current status is not excluded. it reports whether it was updated
+func (r *Repository) UpdateStatusUnless(ctx context.Context, id uint, excluded []string, status string) (updated bool, err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Messages{}).
+		Where("id = ? AND status NOT IN ?", id, excluded).
+		Update("status", status)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("message.repo.update.status", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	updated = tx.RowsAffected > 0
+	return
+}
+
+// CreateStatusHistory records the status transition of the messages on their timeline
+func (r *Repository) CreateStatusHistory(ctx context.Context, ids []uint, status string) (err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	m := make([]model.MessageStatusHistory, 0, len(ids))
+	for _, id := range ids {
+		m = append(m, model.MessageStatusHistory{MessageID: id, Status: status})
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.MessageStatusHistory{})
+
+	if err = tx.Omit("created_at").CreateInBatches(&m, 500).Error; err != nil {
+		r.lgr.Error("message.repo.history.create", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+// UpdateStatusIf updates the message status only if the current status matches. it reports whether it was updated
+func (r *Repository) UpdateStatusIf(ctx context.Context, id uint, current, status string) (updated bool, err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Messages{}).
+		Where("id = ? AND status = ?", id, current).
+		Update("status", status)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("message.repo.update.status", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	updated = tx.RowsAffected > 0
+	return
+}
+
+// MarkSent moves the sending message to sent along with the message id returned by the SMS provider, which matches the
+// later delivery reports. the message already finalized by a delivery report is not updated, it reports whether it was
+func (r *Repository) MarkSent(ctx context.Context, id uint, providerId string) (updated bool, err error) {
+	values := map[string]interface{}{"status": string(domain.MsgSent)}
+	if len(providerId) > 0 {
+		values["provider_message_id"] = providerId
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Messages{}).
+		Where("id = ? AND status = ?", id, domain.MsgSending).
+		Updates(values)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("message.repo.update.sent", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	updated = tx.RowsAffected > 0
+	return
+}
+
+func (r *Repository) GetByProviderMessageID(ctx context.Context, providerId string) (res domain.Message, err error) {
+	m := model.NewMessage()
+
+	db := r.sql.TxOf(ctx)
+	u := db.WithContext(ctx).Model(&model.Messages{}).Preload("Outbox").First(&m, "provider_message_id = ?", providerId)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("message.repo.detail.provider", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewMessage()
+	res.FromDB(*m)
+	return
+}
+
+// GetLatestByMobile the last message sent to the mobile
+func (r *Repository) GetLatestByMobile(ctx context.Context, mobile string) (res domain.Message, err error) {
+	m := model.NewMessage()
+
+	db := r.sql.TxOf(ctx)
+	u := db.WithContext(ctx).Model(&model.Messages{}).
+		Where("mobile = ?", mobile).
+		Order("created_at DESC, id DESC").
+		First(&m)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("message.repo.detail.mobile", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewMessage()
+	res.FromDB(*m)
+	return
+}
+
+// UpdateDelivery applies the final delivery state to a message which is not finalized yet. it reports whether it was updated
+func (r *Repository) UpdateDelivery(ctx context.Context, id uint, status string, dlr domain.MessageDlr) (updated bool, err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Messages{}).
+		Where("id = ? AND status IN ?", id, []string{string(domain.MsgSending), string(domain.MsgSent)}).
+		Updates(map[string]interface{}{
+			"status":    status,
+			"dlr_state": dlr.State(),
+			"dlr_at":    dlr.ReportedAt(),
+		})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("message.repo.update.delivery", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	updated = tx.RowsAffected > 0
+	return
+}
+
+// ReleaseScheduled claims the due scheduled messages by moving them to the `queued` status and returns them
+// along with their outbox. the rows locked by other instances are skipped.
+func (r *Repository) ReleaseScheduled(ctx context.Context, now time.Time, limit int) (res domain.MessageList, err error) {
+	list := domain.NewMessageList()
+
+	var (
+		ids    []uint
+		models []model.Messages
+	)
+
+	db := r.sql.TxOf(ctx)
+
+	claim := db.WithContext(ctx).Raw(`
+		UPDATE messages SET status = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM messages
+			WHERE status = ? AND send_at <= ? AND deleted_at IS NULL
+			ORDER BY send_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`,
+		domain.MsgQueued, now, domain.MsgScheduled, now, limit,
+	).Scan(&ids)
+
+	if err = claim.Error; err != nil {
+		r.lgr.Error("message.repo.scheduled.claim", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if len(ids) == 0 {
+		res = *list
+		return
+	}
+
+	items := db.WithContext(ctx).Model(&model.Messages{}).Preload("Outbox").Where("id IN ?", ids).Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("message.repo.scheduled.list", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if items.RowsAffected > 0 {
+		list.ListFromDB(models)
+	}
+
+	res = *list
+	return
+}
+
 func (r *Repository) GetList(ctx context.Context, ent domain.MessageListReqQryParam) (res domain.MessageList, err error) {
 	defer func() {
 		if err != nil {
@@ -160,30 +455,113 @@ func (r *Repository) GetList(ctx context.Context, ent domain.MessageListReqQryPa
 		total  int64
 	)
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Messages{})
 
-	tx.Where("tenant_id = ?", ent.TenantId())
-
 	if ent.GetRelations() != nil {
 		for _, rel := range ent.GetRelations() {
 			tx = tx.Preload(rel)
 		}
 	}
 
+	if ent.TenantId() == 0 {
+		tx.Preload("Tenant") // the admin list
+	}
+
+	applyListFilters(tx, ent)
+
+	//
+
+	if !ent.SkipTotal() {
+		if err = tx.Count(&total).Error; err != nil {
+			r.lgr.Error("message.repo.list.count", zap.Error(err))
+			err = meta.Failed
+			return
+		}
+
+		list.SetTotal(total)
+	}
+
+	//
+
+	if ent.Items() == nil {
+		if cursor := ent.Cursor(); !cursor.IsZero() {
+			tx.Where(ent.KeysetCond(), cursor.CreatedAt(), cursor.ID())
+		} else {
+			tx.Offset(ent.Offset())
+		}
+
+		tx.Limit(ent.Limit() + 1) // the extra item reports the next page
+	}
+
+	items := tx.Order(ent.SortOrder()).Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("message.repo.list", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if ent.Items() == nil && len(models) > ent.Limit() {
+		models = models[:ent.Limit()]
+
+		if ent.Keyset() {
+			last := models[len(models)-1]
+			list.SetNextCursor(domain.NewListCursor(last.CreatedAt, strconv.FormatUint(uint64(last.ID), 10)))
+		}
+	}
+
+	if len(models) > 0 {
+		list.ListFromDB(models)
+	}
+
+	res = *list
+	return
+}
+
+//
+
+func (r *Repository) CreateInbound(ctx context.Context, ent domain.MessageInbound) (res domain.MessageInbound, err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.InboundMessages{})
+
+	if err = tx.Omit("uuid").Clauses(clause.Returning{}).Create(&m).Error; err != nil {
+		r.lgr.Error("message.repo.create.inbound", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+func (r *Repository) GetInboundList(ctx context.Context, ent domain.MessageInboundListReqQryParam) (res domain.MessageInboundList, err error) {
+	list := domain.NewMessageInboundList()
+
+	var (
+		models []model.InboundMessages
+		total  int64
+	)
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.InboundMessages{})
+
+	tx.Where("tenant_id = ?", ent.TenantId())
+
 	if ent.Items() != nil && len(ent.Items()) > 0 {
 		tx.Where("uuid IN ?", ent.Items()) // get all items
 	}
 
 	if len(ent.Search()) > 0 {
 		val := fmt.Sprintf("%%%s%%", ent.Search()) // this returns %search_value%
-		tx.Where("message_text ILIKE ? ", val)
+		tx.Where("(message_text ILIKE ? OR mobile ILIKE ?)", val, val)
 	}
 
 	//
 
 	if err = tx.Count(&total).Error; err != nil {
-		r.lgr.Error("message.repo.list.count", zap.Error(err))
+		r.lgr.Error("message.repo.list.inbound.count", zap.Error(err))
 		err = meta.Failed
 		return
 	}
@@ -198,7 +576,7 @@ func (r *Repository) GetList(ctx context.Context, ent domain.MessageListReqQryPa
 
 	items := tx.Order(ent.SortOrder()).Find(&models)
 	if err = items.Error; err != nil {
-		r.lgr.Error("message.repo.list", zap.Error(err))
+		r.lgr.Error("message.repo.list.inbound", zap.Error(err))
 		err = meta.Failed
 		return
 	}
@@ -210,3 +588,91 @@ func (r *Repository) GetList(ctx context.Context, ent domain.MessageListReqQryPa
 	res = *list
 	return
 }
+
+// Stream passes the messages of the list filters to the fn one by one, without loading them all in memory.
+// the pagination and the relations of the list are ignored
+func (r *Repository) Stream(ctx context.Context, ent domain.MessageListReqQryParam, fn func(domain.Message) error) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Messages{})
+
+	applyListFilters(tx, ent)
+
+	rows, err := tx.Order(ent.SortOrder()).Rows()
+	if err != nil {
+		r.lgr.Error("message.repo.stream", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		m := model.NewMessage()
+		if err = tx.ScanRows(rows, m); err != nil {
+			r.lgr.Error("message.repo.stream.scan", zap.Error(err))
+			err = meta.Failed
+			return
+		}
+
+		if err = fn(domain.NewMessage().FromDB(*m)); err != nil {
+			return
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		r.lgr.Error("message.repo.stream.rows", zap.Error(err))
+		err = meta.Failed
+	}
+
+	return
+}
+
+// HELPERS
+
+func applyListFilters(tx *gorm.DB, ent domain.MessageListReqQryParam) {
+	// the zero tenant lists the messages of all tenants (admin)
+	if ent.TenantId() != 0 {
+		tx.Where("tenant_id = ?", ent.TenantId())
+	}
+
+	if ent.Items() != nil && len(ent.Items()) > 0 {
+		tx.Where("uuid IN ?", ent.Items()) // get all items
+	}
+
+	if len(ent.Search()) > 0 {
+		val := fmt.Sprintf("%%%s%%", ent.Search()) // this returns %search_value%
+		tx.Where("message_text ILIKE ? ", val)
+	}
+
+	if len(ent.Status()) > 0 {
+		tx.Where("status = ?", ent.Status())
+	}
+
+	if len(ent.Channel()) > 0 {
+		tx.Where("EXISTS (SELECT 1 FROM outboxes WHERE outboxes.message_id = messages.id AND outboxes.event_type = ?)", ent.Channel())
+	}
+
+	if len(ent.Mobile()) > 0 {
+		tx.Where("mobile = ?", ent.Mobile())
+	}
+
+	if len(ent.PolicyAction()) > 0 {
+		tx.Where("policy_action = ?", ent.PolicyAction())
+	}
+
+	if len(ent.MobilePrefix()) > 0 {
+		tx.Where("mobile LIKE ?", ent.MobilePrefix()+"%") // the prefix is numeric, no wildcard to escape
+	}
+
+	if !ent.CreatedFrom().IsZero() {
+		tx.Where("created_at >= ?", ent.CreatedFrom())
+	}
+
+	if !ent.CreatedTo().IsZero() {
+		tx.Where("created_at < ?", ent.CreatedTo())
+	}
+
+	if len(ent.ClientRef()) > 0 {
+		tx.Where("client_ref = ?", ent.ClientRef())
+	}
+}
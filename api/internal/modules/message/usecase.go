@@ -4,19 +4,26 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"microservice/config"
 	"microservice/internal/adapter/cache"
 	"microservice/internal/adapter/locale"
 	"microservice/internal/adapter/logger"
 	"microservice/internal/adapter/orm"
 	"microservice/internal/adapter/queue"
+	"microservice/internal/adapter/registry"
 	"microservice/internal/adapter/trace"
 	"microservice/internal/domain"
 	"microservice/internal/modules/port"
 	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
 	"microservice/pkg/utils"
+	"microservice/pkg/validator"
+	"time"
 )
 
 type (
@@ -27,10 +34,20 @@ type (
 		Logger          logger.ILogger
 		Cache           cache.ICache
 		Tx              orm.ISqlTx
+		Registry        registry.IRegistry
 		MessageRepo     port.IMessageRepository
 		OutboxRepo      port.IOutboxRepository
 		CreditRepo      port.ICreditRepository
 		TransactionRepo port.ITransactionRepository
+		TemplateRepo    port.ITemplateRepository
+		SenderRepo      port.ISenderRepository
+		RateCardRepo    port.IRateCardRepository
+		LinkRepo        port.ILinkRepository
+		CreditUC        port.ICreditUsecase
+		PricingUC       port.IPricingUsecase
+		BlocklistUC     port.IBlocklistUsecase
+		PolicyUC        port.IPolicyUsecase
+		LinkUC          port.ILinkUsecase
 		Queue           queue.IQueue
 	}
 
@@ -40,16 +57,27 @@ type (
 		lgr             logger.ILogger
 		cache           cache.ICache
 		tx              orm.ISqlTx
+		service         config.Service
+		quietHours      config.QuietHours
 		messageRepo     port.IMessageRepository
 		outboxRepo      port.IOutboxRepository
 		creditRepo      port.ICreditRepository
 		transactionRepo port.ITransactionRepository
+		templateRepo    port.ITemplateRepository
+		senderRepo      port.ISenderRepository
+		rateCardRepo    port.IRateCardRepository
+		linkRepo        port.ILinkRepository
+		creditUC        port.ICreditUsecase
+		pricingUC       port.IPricingUsecase
+		blocklistUC     port.IBlocklistUsecase
+		policyUC        port.IPolicyUsecase
+		linkUC          port.ILinkUsecase
 		queue           queue.IQueue
 	}
 )
 
 func NewUsecaseFx(fx UsecaseFx) port.IMessageUsecase {
-	return &Usecase{
+	uc := &Usecase{
 		l:               fx.Locale,
 		trc:             fx.Tracer,
 		lgr:             fx.Logger,
@@ -59,27 +87,199 @@ func NewUsecaseFx(fx UsecaseFx) port.IMessageUsecase {
 		outboxRepo:      fx.OutboxRepo,
 		creditRepo:      fx.CreditRepo,
 		transactionRepo: fx.TransactionRepo,
+		templateRepo:    fx.TemplateRepo,
+		senderRepo:      fx.SenderRepo,
+		rateCardRepo:    fx.RateCardRepo,
+		linkRepo:        fx.LinkRepo,
+		creditUC:        fx.CreditUC,
+		pricingUC:       fx.PricingUC,
+		blocklistUC:     fx.BlocklistUC,
+		policyUC:        fx.PolicyUC,
+		linkUC:          fx.LinkUC,
 		queue:           fx.Queue,
 	}
+
+	if err := fx.Registry.Parse(&uc.service); err != nil {
+		utils.PrintStd(utils.StdPanic, "message", "service config parse err: %s", err)
+	}
+
+	if err := fx.Registry.Parse(&uc.quietHours); err != nil {
+		utils.PrintStd(utils.StdPanic, "message", "quiet hours config parse err: %s", err)
+	}
+
+	if len(uc.quietHours.Start) == 0 || len(uc.quietHours.End) == 0 {
+		uc.quietHours.Start, uc.quietHours.End = DefaultQuietHoursStart, DefaultQuietHoursEnd
+	}
+
+	return uc
 }
 
-const MciMessagePrice float64 = 8.9
+const (
+	// MaxMessageSegments the max count of the concatenated SMS parts of a message
+	MaxMessageSegments int = 8
+	// IdempotencyKeyTtl how long the accepted message is replayed for the same Idempotency-Key
+	IdempotencyKeyTtl = 24 * time.Hour
+	// DefaultQuietHoursStart and DefaultQuietHoursEnd the quiet hours of the tenants without their own window
+	DefaultQuietHoursStart = "22:00"
+	DefaultQuietHoursEnd   = "08:00"
+)
 
 func (uc *Usecase) Send(ctx context.Context, tenant domain.Tenant, ent domain.Message) (res domain.Message, err error) {
 	var txErr error
 
-	if len(ent.MessageText()) > 160 {
+	blocked, ucErr := uc.blocklistUC.IsBlocked(ctx, tenant.ID(), ent.Mobile())
+	if ucErr != nil {
+		err = ucErr
+		return
+	}
+
+	if blocked {
+		err = meta.Blocked
+		return
+	}
+
+	if len(ent.Sender()) > 0 {
+		if _, txErr = uc.senderRepo.GetApproved(ctx, tenant.ID(), ent.Sender()); txErr != nil {
+			if errors.Is(txErr, meta.NotFound) {
+				err = meta.Forbidden.SetErr(uc.l.Get("sms_sender_not_approved"))
+				return
+			}
+
+			err = meta.EvalTxErr(txErr)
+			return
+		}
+	}
+
+	if ent.UsesTemplate() {
+		if ent, err = uc.renderTemplate(ctx, tenant, ent); err != nil {
+			return
+		}
+	}
+
+	// the content policy rejects the message before charging, the held and the flagged ones keep the matched rule.
+	// the rules are evaluated on the links of the tenant, not on the short links
+	verdict, ucErr := uc.policyUC.Evaluate(ctx, tenant.ID(), ent.MessageText())
+	if ucErr != nil {
+		err = ucErr
+		return
+	}
+
+	if verdict.Action() == domain.PolicyReject {
+		err = meta.Forbidden.SetErr(uc.l.Get("sms_content_rejected"))
+		return
+	}
+
+	ent.SetPolicy(verdict)
+
+	// the duplicates are detected by the text of the tenant, the short links differ on every send
+	original := ent
+
+	if ent.TrackLinks() {
+		text, links := uc.linkUC.Shorten(ent.MessageText())
+		ent.SetMessageText(text)
+		ent.SetShortLinks(links)
+	}
+
+	// the segments are counted on the delivered text, the short links included
+	segments, encoding := utils.SmsSegments(ent.MessageText())
+	if segments > MaxMessageSegments {
 		err = meta.Conflict.SetErr(uc.l.Get("sms_char_exceed"))
 		return
 	}
 
+	ent.SetSegments(segments)
+	ent.SetEncoding(string(encoding))
+
+	if !ent.SendAt().IsZero() {
+		if !ent.IsScheduled() {
+			err = meta.Validate.SetErr(uc.l.Get("sms_send_at_invalid"))
+			return
+		}
+
+		// the scheduled messages are held and released to the queue by the scheduler when due
+		ent.SetStatus(string(domain.MsgScheduled))
+	}
+
+	// the messages due in the quiet hours are held like the scheduled ones, until the window opens
+	if opensAt, quiet := uc.quietOpensAt(tenant, ent.Channel(), ent.SendAt()); quiet {
+		ent.SetSendAt(opensAt)
+		ent.SetStatus(string(domain.MsgScheduled))
+	}
+
+	// the validity period has to outlast the send time, otherwise the message expires before its first attempt.
+	// it is checked after the quiet hours shift, the message expiring in the quiet hours is rejected instead of charged
+	if !ent.ValidUntil().IsZero() {
+		dueAt := time.Now().UTC()
+		if ent.SendAt().After(dueAt) {
+			dueAt = ent.SendAt()
+		}
+
+		if !ent.ValidUntil().After(dueAt) {
+			err = meta.Validate.SetErr(uc.l.Get("sms_valid_until_invalid"))
+			return
+		}
+	}
+
+	// the held message is charged and waits for the admin review, its send time is kept for the approval
+	if verdict.Action() == domain.PolicyHold {
+		ent.SetStatus(string(domain.MsgHeld))
+	}
+
+	// each segment is charged as a single SMS, by the rate of the recipient operator and the tenant plan discount
+	ent.SetOperator(domain.DetectOperator(ent.Mobile()))
+
+	rate, err := uc.rateCard(ctx, ent.Operator(), ent.Channel())
+	if err != nil {
+		return
+	}
+
+	discount, err := uc.pricingUC.GetDiscount(ctx, tenant.ID())
+	if err != nil {
+		return
+	}
+
+	unitPrice := uc.channelPrice(ent.Channel(), rate.UnitPrice(discount))
+	price := unitPrice * float64(segments)
 	credit := tenant.Credit()
 
-	if credit.Balance() < MciMessagePrice {
+	if credit.Balance() < price {
 		err = meta.Conflict.SetErr(uc.l.Get("sms_balance_err"))
 		return
 	}
 
+	// the retried request replays the message accepted by the former one
+
+	claimedKeys := make([]string, 0, 2)
+	defer func() {
+		if err != nil {
+			uc.releaseCacheKeys(ctx, claimedKeys...)
+		}
+	}()
+
+	if len(ent.IdempotencyKey()) > 0 {
+		key := idempotencyCacheKey(tenant.ID(), ent.IdempotencyKey())
+
+		claimed, replay, cErr := uc.claimIdempotencyKey(ctx, key)
+		if cErr != nil {
+			err = cErr
+			return
+		}
+
+		if !claimed {
+			res = replay
+			return
+		}
+
+		claimedKeys = append(claimedKeys, key)
+	}
+
+	if key, claimed := uc.claimDedupKey(ctx, tenant, original); !claimed {
+		err = meta.ItemExist.SetErr(uc.l.Get("sms_duplicate"))
+		return
+	} else if len(key) > 0 {
+		claimedKeys = append(claimedKeys, key)
+	}
+
 	//
 
 	uc.tx.Begin()
@@ -106,6 +306,24 @@ func (uc *Usecase) Send(ctx context.Context, tenant domain.Tenant, ent domain.Me
 		return
 	}
 
+	txErr = uc.messageRepo.CreateStatusHistory(ctx, []uint{message.ID()}, message.Status())
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	links := ent.ShortLinks()
+	for i := range links {
+		links[i].SetTenantID(tenant.ID())
+		links[i].SetMessageID(message.ID())
+	}
+
+	txErr = uc.linkRepo.CreateBatch(ctx, links)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
 	//
 
 	om := domain.NewOutboxMessage()
@@ -126,7 +344,7 @@ func (uc *Usecase) Send(ctx context.Context, tenant domain.Tenant, ent domain.Me
 
 	//
 
-	decreasedCredit := credit.Balance() - MciMessagePrice
+	decreasedCredit := credit.Balance() - price
 	credit.SetBalance(decreasedCredit)
 
 	txErr = uc.creditRepo.Update(ctx, credit)
@@ -140,8 +358,11 @@ func (uc *Usecase) Send(ctx context.Context, tenant domain.Tenant, ent domain.Me
 	transaction := domain.NewTransaction()
 	transaction.SetID(utils.TransactionIdGen(tenant, credit, &ent))
 	transaction.SetCreditID(credit.ID())
-	transaction.SetAmount(MciMessagePrice)
+	transaction.SetAmount(price)
+	transaction.SetType(domain.TxCharge)
 	transaction.SetMessageHashID([]byte(hashedMessage))
+	transaction.SetOperator(ent.Operator())
+	transaction.SetUnitPrice(unitPrice)
 
 	_, txErr = uc.transactionRepo.Create(ctx, *transaction)
 	if txErr != nil {
@@ -157,9 +378,26 @@ func (uc *Usecase) Send(ctx context.Context, tenant domain.Tenant, ent domain.Me
 		return
 	}
 
+	if len(ent.IdempotencyKey()) > 0 {
+		key := idempotencyCacheKey(tenant.ID(), ent.IdempotencyKey())
+		if cErr := uc.cache.C().Set(ctx, key, message.UUID().String(), IdempotencyKeyTtl).Err(); cErr != nil {
+			uc.lgr.Error("message.cache.idempotency.set", zap.Error(cErr))
+		}
+	}
+
+	usage := domain.NewUsageEvent(tenant.ID(), ent.Channel(), domain.MessageStatus(message.Status()), 1)
+	usage.SetCredit(price)
+	uc.queue.Track(ctx, *usage)
+
 	//
 
-	txErr = uc.queue.Produce(ctx, ent.Channel(), hashedMessage, om.Json())
+	if message.Status() == string(domain.MsgScheduled) || message.Status() == string(domain.MsgHeld) {
+		res = message
+		return
+	}
+
+	om.SetQueuedAt(time.Now().UTC())
+	txErr = uc.queue.Dispatch(ctx, ent.Channel(), hashedMessage, om.Json())
 	if txErr != nil {
 		uc.lgr.Error("message.create.queue.produce", zap.Error(txErr))
 		return
@@ -169,6 +407,513 @@ func (uc *Usecase) Send(ctx context.Context, tenant domain.Tenant, ent domain.Me
 	return
 }
 
+func (uc *Usecase) SendBulk(ctx context.Context, tenant domain.Tenant, ent domain.MessageBulk) (res domain.MessageBulk, err error) {
+	var txErr error
+
+	segments, encoding := utils.SmsSegments(ent.MessageText())
+	if segments > MaxMessageSegments {
+		err = meta.Conflict.SetErr(uc.l.Get("sms_char_exceed"))
+		return
+	}
+
+	ent.SetSegments(segments)
+	ent.SetEncoding(string(encoding))
+	ent.SetJobID(uuid.New())
+	ent.SetTenantID(tenant.ID())
+
+	// the content policy is evaluated once on the shared text, it rejects the whole job or holds all of its messages
+	verdict, ucErr := uc.policyUC.Evaluate(ctx, tenant.ID(), ent.MessageText())
+	if ucErr != nil {
+		err = ucErr
+		return
+	}
+
+	if verdict.Action() == domain.PolicyReject {
+		err = meta.Forbidden.SetErr(uc.l.Get("sms_content_rejected"))
+		return
+	}
+
+	// evaluate the recipients: invalid numbers, blocked numbers and the duplicates(in request or within the tenant window) are rejected
+
+	claimedKeys := make([]string, 0)
+	defer func() {
+		if err != nil {
+			uc.releaseCacheKeys(ctx, claimedKeys...)
+		}
+	}()
+
+	seen := make(map[string]bool)
+	messages := make([]domain.Message, 0)
+
+	for _, recipient := range ent.Recipients() {
+		// the recipients are compared, blocked and stored in their E.164 form
+		mobile, nErr := validator.NormalizeMobile(recipient)
+		if nErr != nil {
+			ent.AddItem(*domain.NewMessageBulkItem(recipient, domain.BulkInvalidNumber))
+			continue
+		}
+
+		message := ent.Message(mobile)
+
+		if seen[mobile] {
+			ent.AddItem(*domain.NewMessageBulkItem(mobile, domain.BulkDuplicate))
+			continue
+		}
+		seen[mobile] = true
+
+		blocked, ucErr := uc.blocklistUC.IsBlocked(ctx, tenant.ID(), mobile)
+		if ucErr != nil {
+			err = ucErr
+			return
+		}
+
+		if blocked {
+			ent.AddItem(*domain.NewMessageBulkItem(mobile, domain.BulkBlocked))
+			continue
+		}
+
+		key, claimed := uc.claimDedupKey(ctx, tenant, message)
+		if !claimed {
+			ent.AddItem(*domain.NewMessageBulkItem(mobile, domain.BulkDuplicate))
+			continue
+		}
+
+		if len(key) > 0 {
+			claimedKeys = append(claimedKeys, key)
+		}
+
+		message.SetMessageHash(messageHashedIdGen(message))
+		message.SetOperator(domain.DetectOperator(mobile))
+		message.SetPolicy(verdict)
+		messages = append(messages, message)
+		ent.AddItem(*domain.NewMessageBulkItem(mobile, domain.BulkAccepted))
+	}
+
+	if len(messages) == 0 {
+		res = ent
+		return
+	}
+
+	// the batch arriving in the quiet hours is held as a whole, the scheduler releases it when the window opens
+	batchStatus := domain.MsgQueued
+	if opensAt, quiet := uc.quietOpensAt(tenant, ent.Channel(), time.Time{}); quiet {
+		batchStatus = domain.MsgScheduled
+		for i := range messages {
+			messages[i].SetStatus(string(domain.MsgScheduled))
+			messages[i].SetSendAt(opensAt)
+		}
+	}
+
+	// the held batch is charged and waits for the admin review, the send time of the quiet hours is kept for the approval
+	if verdict.Action() == domain.PolicyHold {
+		batchStatus = domain.MsgHeld
+		for i := range messages {
+			messages[i].SetStatus(string(domain.MsgHeld))
+		}
+	}
+
+	// the total cost is evaluated once for all accepted recipients, by the rate of each recipient operator and the
+	// plan discount of the tenant at the start of the batch
+
+	discount, err := uc.pricingUC.GetDiscount(ctx, tenant.ID())
+	if err != nil {
+		return
+	}
+
+	unitPrices := make(map[domain.Operator]float64)
+	cost := 0.0
+
+	for _, message := range messages {
+		if _, ok := unitPrices[message.Operator()]; !ok {
+			rate, rErr := uc.rateCard(ctx, message.Operator(), ent.Channel())
+			if rErr != nil {
+				err = rErr
+				return
+			}
+
+			unitPrices[message.Operator()] = uc.channelPrice(ent.Channel(), rate.UnitPrice(discount))
+		}
+
+		cost += unitPrices[message.Operator()] * float64(segments)
+	}
+
+	credit := tenant.Credit()
+
+	if credit.Balance() < cost {
+		err = meta.Conflict.SetErr(uc.l.Get("sms_balance_err"))
+		return
+	}
+
+	//
+
+	uc.tx.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			txErr = r.(error)
+			uc.lgr.Error("message.bulk.recover", zap.Error(txErr))
+			err = meta.Failed
+		}
+
+		if txResErr := uc.tx.Resolve(txErr); txResErr != nil {
+			uc.lgr.Error("message.bulk.tx.rollback", zap.Error(txErr))
+		}
+	}()
+
+	//
+
+	created, txErr := uc.messageRepo.CreateBatch(ctx, messages)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	ids := make([]uint, 0, len(created))
+	for _, message := range created {
+		ids = append(ids, message.ID())
+	}
+
+	txErr = uc.messageRepo.CreateStatusHistory(ctx, ids, string(batchStatus))
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	//
+
+	outboxes := make([]domain.Outbox, 0, len(created))
+	payloads := make([]*domain.OutboxMessage, 0, len(created))
+
+	for _, message := range created {
+		om := domain.NewOutboxMessage()
+		om.FromMessage(message)
+		payloads = append(payloads, om)
+
+		outboxEnt := domain.NewOutbox()
+		outboxEnt.SetEventType(message.Channel())
+		outboxEnt.SetMessageId(message.ID())
+		outboxEnt.SetPayload(om.Json())
+		outboxes = append(outboxes, *outboxEnt)
+	}
+
+	outboxes, txErr = uc.outboxRepo.CreateBatch(ctx, outboxes)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	for i, outbox := range outboxes {
+		payloads[i].SetOutboxID(outbox.ID())
+	}
+
+	//
+
+	transactions := make([]domain.Transaction, 0, len(created))
+
+	for _, message := range created {
+		// the balance is decreased per message to keep the transaction ids unique
+		unitPrice := unitPrices[message.Operator()]
+		price := unitPrice * float64(segments)
+		credit.SetBalance(credit.Balance() - price)
+
+		transaction := domain.NewTransaction()
+		transaction.SetID(utils.TransactionIdGen(tenant, credit, &message))
+		transaction.SetCreditID(credit.ID())
+		transaction.SetAmount(price)
+		transaction.SetType(domain.TxCharge)
+		transaction.SetMessageHashID([]byte(message.MessageHash()))
+		transaction.SetOperator(message.Operator())
+		transaction.SetUnitPrice(unitPrice)
+		transactions = append(transactions, *transaction)
+	}
+
+	txErr = uc.creditRepo.Update(ctx, credit)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	txErr = uc.transactionRepo.CreateBatch(ctx, transactions)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	//
+
+	if txErr = uc.tx.Commit(); txErr != nil {
+		uc.lgr.Error("message.bulk.tx.commit", zap.Error(txErr))
+		err = meta.Failed
+		return
+	}
+
+	usage := domain.NewUsageEvent(tenant.ID(), ent.Channel(), batchStatus, int64(len(created)))
+	usage.SetCredit(cost)
+	uc.queue.Track(ctx, *usage)
+
+	//
+
+	byMobile := make(map[string]domain.Message) // the accepted recipients are unique
+	for i, message := range created {
+		byMobile[message.Mobile()] = message
+
+		if batchStatus == domain.MsgScheduled || batchStatus == domain.MsgHeld {
+			continue
+		}
+
+		payloads[i].SetQueuedAt(time.Now().UTC())
+		if pErr := uc.queue.Dispatch(ctx, message.Channel(), message.MessageHash(), payloads[i].Json()); pErr != nil {
+			uc.lgr.Error("message.bulk.queue.produce", zap.String("job.id", ent.JobID().String()), zap.Error(pErr))
+		}
+	}
+
+	items := make([]domain.MessageBulkItem, 0, len(ent.Items()))
+	for _, item := range ent.Items() {
+		if item.Result() == domain.BulkAccepted {
+			item.SetMessage(byMobile[item.Mobile()])
+		}
+
+		items = append(items, item)
+	}
+
+	ent.SetItems(items)
+	res = ent
+	return
+}
+
+func (uc *Usecase) GetBulkProgress(ctx context.Context, ent domain.MessageBulk) (res domain.MessageBulk, err error) {
+	progress, txErr := uc.messageRepo.GetJobProgress(ctx, ent.TenantID(), ent.JobID())
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	ent.SetProgress(progress)
+	res = ent
+	return
+}
+
+// GetDetails the message along with its outbox, the charged price and the status timeline
+func (uc *Usecase) GetDetails(ctx context.Context, tenant domain.Tenant, ent domain.Message) (res domain.Message, err error) {
+	ent.SetRelations("Outbox", "History")
+	message, txErr := uc.messageRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if message.TenantID() != tenant.ID() {
+		err = meta.NotFound
+		return
+	}
+
+	charge, txErr := uc.transactionRepo.GetByMessageHash(ctx, []byte(message.MessageHash()), domain.TxCharge)
+	if txErr != nil && !errors.Is(txErr, meta.NotFound) {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	message.SetPrice(charge.Amount())
+
+	if message.TrackLinks() {
+		clicks, txErr := uc.linkRepo.CountClicks(ctx, message.ID())
+		if txErr != nil {
+			err = meta.EvalTxErr(txErr)
+			return
+		}
+
+		message.SetClicks(clicks)
+	}
+
+	res = message
+	return
+}
+
+func (uc *Usecase) Cancel(ctx context.Context, tenant domain.Tenant, ent domain.Message) (res domain.Message, err error) {
+	var (
+		refund domain.Transaction
+		txErr  error
+	)
+
+	ent.SetRelations("Outbox")
+	message, txErr := uc.messageRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if message.TenantID() != tenant.ID() {
+		err = meta.NotFound
+		return
+	}
+
+	if message.Status() != string(domain.MsgScheduled) {
+		err = meta.Conflict.SetErr(uc.l.Get("sms_not_scheduled"))
+		return
+	}
+
+	//
+
+	uc.tx.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			txErr = r.(error)
+			uc.lgr.Error("message.cancel.recover", zap.Error(txErr))
+			err = meta.Failed
+		}
+
+		if txResErr := uc.tx.Resolve(txErr); txResErr != nil {
+			uc.lgr.Error("message.cancel.tx.rollback", zap.Error(txErr))
+			return
+		}
+
+		if txErr == nil {
+			usage := domain.NewUsageEvent(tenant.ID(), message.Channel(), domain.MsgCanceled, 1)
+			usage.SetCredit(-refund.Amount())
+			uc.queue.Track(ctx, *usage)
+		}
+	}()
+
+	// the status is checked again in db, the scheduler may have released the message meanwhile
+
+	canceled, txErr := uc.messageRepo.UpdateStatusIf(ctx, message.ID(), string(domain.MsgScheduled), string(domain.MsgCanceled))
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if !canceled {
+		txErr = meta.Conflict.SetErr(uc.l.Get("sms_not_scheduled"))
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	txErr = uc.messageRepo.CreateStatusHistory(ctx, []uint{message.ID()}, string(domain.MsgCanceled))
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	outbox := message.Outbox()
+	if txErr = uc.outboxRepo.UpdateStatus(ctx, outbox.ID(), string(domain.OutboxFailed)); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	// the reserved credit is given back
+
+	refund, txErr = uc.creditUC.Refund(ctx, message)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	message.SetStatus(string(domain.MsgCanceled))
+	res = message
+	return
+}
+
+// Receive the inbound message belongs to the tenant which owns the destination line, or else to the tenant which has last
+// messaged the mobile. the opt-out keywords block the mobile for the tenant, the message is stored and forwarded to the tenant
+func (uc *Usecase) Receive(ctx context.Context, ent domain.MessageInbound) (res domain.MessageInbound, err error) {
+	tenantId, err := uc.inboundTenant(ctx, ent)
+	if err != nil {
+		return
+	}
+
+	ent.SetTenantID(tenantId)
+
+	if ent.IsOptOut() {
+		if err = uc.blocklistUC.OptOut(ctx, ent.TenantID(), ent.Mobile()); err != nil {
+			return
+		}
+
+		ent.SetOptedOut(true)
+	}
+
+	res, txErr := uc.messageRepo.CreateInbound(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	inbound := domain.NewInboundMessage()
+	inbound.FromMessageInbound(res)
+	uc.queue.Forward(ctx, *inbound)
+
+	return
+}
+
+// Deliver applies the provider delivery report on the message. the repeated or late reports of a finalized message are ignored
+func (uc *Usecase) Deliver(ctx context.Context, dlr domain.MessageDlr) (res domain.Message, err error) {
+	message, txErr := uc.messageRepo.GetByProviderMessageID(ctx, dlr.ProviderMessageID())
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	status, final := dlr.Status()
+	if !final {
+		res = message
+		return
+	}
+
+	if dlr.ReportedAt().IsZero() {
+		dlr.SetReportedAt(time.Now().UTC())
+	}
+
+	// the failed delivery is refunded along with the status update, the deterministic refund id keeps it exactly once
+	var (
+		updated  bool
+		refunded float64
+	)
+
+	txErr = uc.tx.Transaction(ctx, func(ctx context.Context) (txErr error) {
+		updated, txErr = uc.messageRepo.UpdateDelivery(ctx, message.ID(), string(status), dlr)
+		if txErr != nil || !updated {
+			return
+		}
+
+		if txErr = uc.messageRepo.CreateStatusHistory(ctx, []uint{message.ID()}, string(status)); txErr != nil {
+			return
+		}
+
+		if status != domain.MsgFailed {
+			return
+		}
+
+		// the already refunded message is refunded by zero
+		refund, txErr := uc.creditUC.Refund(ctx, message)
+		if txErr != nil {
+			return
+		}
+
+		refunded = refund.Amount()
+		return
+	})
+
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if updated {
+		message.SetStatus(string(status))
+		message.SetDlrState(dlr.State())
+		message.SetDlrAt(dlr.ReportedAt())
+
+		event := domain.NewWebhookEvent(status)
+		event.FromMessage(message)
+		uc.queue.Notify(ctx, *event)
+
+		usage := domain.NewUsageEvent(message.TenantID(), message.Channel(), status, 1)
+		usage.SetCredit(-refunded)
+		uc.queue.Track(ctx, *usage)
+	}
+
+	res = message
+	return
+}
+
 func (uc *Usecase) GetList(ctx context.Context, ent domain.MessageListReqQryParam) (res domain.MessageList, err error) {
 	ent.SetRelations("Outbox")
 	res, txErr := uc.messageRepo.GetList(ctx, ent)
@@ -180,10 +925,222 @@ func (uc *Usecase) GetList(ctx context.Context, ent domain.MessageListReqQryPara
 	return
 }
 
+func (uc *Usecase) GetInboundList(ctx context.Context, ent domain.MessageInboundListReqQryParam) (res domain.MessageInboundList, err error) {
+	res, txErr := uc.messageRepo.GetInboundList(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
 // HELPERS
 
+// inboundTenant resolves the owning tenant of the inbound message by its destination line, the replies to the
+// provider default line fall back to the tenant which has last messaged the mobile
+func (uc *Usecase) inboundTenant(ctx context.Context, ent domain.MessageInbound) (tenantId uint, err error) {
+	if len(ent.Receiver()) > 0 {
+		sender, txErr := uc.senderRepo.GetApprovedLine(ctx, ent.Receiver())
+		if txErr == nil {
+			tenantId = sender.TenantID()
+			return
+		}
+
+		if !errors.Is(txErr, meta.NotFound) {
+			err = meta.EvalTxErr(txErr)
+			return
+		}
+	}
+
+	latest, txErr := uc.messageRepo.GetLatestByMobile(ctx, ent.Mobile())
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	tenantId = latest.TenantID()
+	return
+}
+
+// renderTemplate fills the tenant template placeholders by the message variables as the message text
+func (uc *Usecase) renderTemplate(ctx context.Context, tenant domain.Tenant, ent domain.Message) (res domain.Message, err error) {
+	tmpl := ent.Template()
+	tmpl.SetTenantID(tenant.ID())
+
+	template, txErr := uc.templateRepo.GetDetails(ctx, tmpl)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	text, missing := template.Render(ent.Variables())
+	if len(missing) > 0 {
+		err = meta.ServiceErr(status.Validate).
+			SetErr(uc.l.Get("sms_template_var_missing")).
+			Data(map[string]any{"variables": missing})
+		return
+	}
+
+	// the rendered text is validated the same as the direct message text
+	if validator.Var(text, "required,fa_sms") != nil {
+		err = meta.Validate.SetErr(uc.l.Get("sms_template_render_invalid"))
+		return
+	}
+
+	ent.SetTemplate(template)
+	ent.SetMessageText(text)
+
+	res = ent
+	return
+}
+
+// claimIdempotencyKey reserves the key for the request. when the key is already reserved,
+// the message accepted by the former request is returned to be replayed
+func (uc *Usecase) claimIdempotencyKey(ctx context.Context, key string) (claimed bool, res domain.Message, err error) {
+	claimed, cErr := uc.cache.C().SetNX(ctx, key, "", IdempotencyKeyTtl).Result()
+	if cErr != nil {
+		// the cache outage must not block sending
+		uc.lgr.Error("message.cache.idempotency.claim", zap.Error(cErr))
+		claimed = true
+		return
+	}
+
+	if claimed {
+		return
+	}
+
+	value, cErr := uc.cache.C().Get(ctx, key).Result()
+	messageUuid, pErr := uuid.Parse(value)
+	if cErr != nil || pErr != nil { // the former request is not finished yet
+		err = meta.Conflict.SetErr(uc.l.Get("sms_idempotency_in_progress"))
+		return
+	}
+
+	ent := domain.NewMessage()
+	ent.SetUUID(messageUuid)
+
+	res, txErr := uc.messageRepo.GetDetails(ctx, *ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// claimDedupKey reserves the message for the tenant deduplication window.
+// not claimed means the same message to the same mobile is accepted within the window
+func (uc *Usecase) claimDedupKey(ctx context.Context, tenant domain.Tenant, msg domain.Message) (key string, claimed bool) {
+	claimed = true
+
+	if tenant.DedupWindow() <= 0 {
+		return
+	}
+
+	key = dedupCacheKey(tenant.ID(), msg)
+
+	claimed, cErr := uc.cache.C().SetNX(ctx, key, "", tenant.DedupWindow()).Result()
+	if cErr != nil {
+		// the cache outage must not block sending
+		uc.lgr.Error("message.cache.dedup.claim", zap.Error(cErr))
+		key, claimed = "", true
+		return
+	}
+
+	return
+}
+
+// releaseCacheKeys the keys claimed by a failed request are released to let the client retry
+func (uc *Usecase) releaseCacheKeys(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	if cErr := uc.cache.Del(ctx, keys...); cErr != nil {
+		uc.lgr.Error("message.cache.release", zap.Error(cErr))
+	}
+}
+
+// rateCard the segment price of the operator through the channel, the channels without their own rate cards are
+// priced by the default channel rate cards. the unpriced operators are not deliverable
+func (uc *Usecase) rateCard(ctx context.Context, operator domain.Operator, channel string) (res domain.RateCard, err error) {
+	res, txErr := uc.rateCardRepo.GetByOperator(ctx, operator, channel)
+	if defaultChannel := uc.queue.Channels().Default(); errors.Is(txErr, meta.NotFound) && channel != defaultChannel.Name() {
+		res, txErr = uc.rateCardRepo.GetByOperator(ctx, operator, defaultChannel.Name())
+	}
+
+	if txErr != nil {
+		if errors.Is(txErr, meta.NotFound) {
+			err = meta.Conflict.SetErr(uc.l.Get("sms_rate_not_found"))
+			return
+		}
+
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// channelPrice the unit price multiplied by the channel price multiplier
+func (uc *Usecase) channelPrice(channel string, unitPrice float64) float64 {
+	if ch, ok := uc.queue.Channels().Get(channel); ok {
+		return ch.Price(unitPrice)
+	}
+
+	return unitPrice
+}
+
+// messageHashedIdGen the hash is unique per message. the duplicates are evaluated by dedupCacheKey
 func messageHashedIdGen(msg domain.Message) string {
-	id := fmt.Sprintf("%d:%s:%s", msg.TenantID(), msg.Mobile(), msg.MessageText())
+	id := fmt.Sprintf("%d:%s:%s:%s", msg.TenantID(), msg.Mobile(), msg.MessageText(), uuid.NewString())
 	h := sha256.Sum256([]byte(id))
 	return hex.EncodeToString(h[:])
 }
+
+func dedupCacheKey(tenantId uint, msg domain.Message) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s:%s", msg.Mobile(), msg.MessageText())))
+	return fmt.Sprintf("message:dedup:%d:%s", tenantId, hex.EncodeToString(h[:]))
+}
+
+func idempotencyCacheKey(tenantId uint, key string) string {
+	h := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("message:idempotency:%d:%s", tenantId, hex.EncodeToString(h[:]))
+}
+
+// quietOpensAt the time the quiet hours of the tenant end, if the message of the non-urgent channel is due in them.
+// the due time is now for the immediate messages. the invalid tenant window falls back to the service default
+func (uc *Usecase) quietOpensAt(tenant domain.Tenant, channel string, sendAt time.Time) (time.Time, bool) {
+	if ch, ok := uc.queue.Channels().Get(channel); !ok || ch.IsUrgent() {
+		return sendAt, false
+	}
+
+	dueAt := time.Now().UTC()
+	if sendAt.After(dueAt) {
+		dueAt = sendAt
+	}
+
+	start, end, timezone := uc.quietHours.Start, uc.quietHours.End, uc.service.TimeZone
+	if settings := tenant.QuietHours(); len(settings.Start()) > 0 && len(settings.End()) > 0 {
+		start, end = settings.Start(), settings.End()
+	}
+
+	if settings := tenant.QuietHours(); len(settings.Timezone()) > 0 {
+		timezone = settings.Timezone()
+	}
+
+	if len(timezone) == 0 {
+		timezone = "UTC"
+	}
+
+	quietHours, err := domain.NewQuietHours(start, end, timezone)
+	if err != nil {
+		uc.lgr.Error("message.quiet_hours.parse", zap.Uint("tenant.id", tenant.ID()), zap.Error(err))
+		if quietHours, err = domain.NewQuietHours(DefaultQuietHoursStart, DefaultQuietHoursEnd, "UTC"); err != nil {
+			return sendAt, false
+		}
+	}
+
+	return quietHours.OpensAt(dueAt)
+}
@@ -0,0 +1,133 @@
+package otp
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+)
+
+type (
+	IOtpHttpHandler interface {
+		Send(c echo.Context) error
+		Verify(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale   locale.ILocale
+		Tracer   trace.ITracer
+		Logger   logger.ILogger
+		Metric   metric.IMetric
+		TenantUC port.ITenantUsecase
+		OtpUC    port.IOtpUsecase
+	}
+
+	Handler struct {
+		l        locale.ILocale
+		trc      trace.ITracer
+		lgr      logger.ILogger
+		metric   metric.IMetric
+		tenantUC port.ITenantUsecase
+		otpUC    port.IOtpUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) IOtpHttpHandler {
+	return &Handler{
+		l:        fx.Locale,
+		trc:      fx.Tracer,
+		lgr:      fx.Logger,
+		metric:   fx.Metric,
+		tenantUC: fx.TenantUC,
+		otpUC:    fx.OtpUC,
+	}
+}
+
+// Send godoc
+// @Summary Send One-Time Password
+// @Description a numeric code is sent through the most urgent channel and charged as a message. the code expires in 2 minutes and a new code replaces the former one
+// @Tags OTP
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body otp.SendRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=otp.SendResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "not found"
+// @Failure	409 {object} meta.Response{data=nil} "not enough credit"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/otp/send [post]
+func (h *Handler) Send(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	otp, err := meta.ReqBodyToDomain[*SendRequest, domain.Otp](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	res, ucErr := h.otpUC.Send(ctx, tenant, otp)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(SendResp(res)).Json()
+}
+
+// Verify godoc
+// @Summary Verify One-Time Password
+// @Description the code is verified once. the code is revoked after 5 wrong attempts
+// @Tags OTP
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body otp.VerifyRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	403 {object} meta.Response{data=nil} "max attempts exceeded"
+// @Failure	404 {object} meta.Response{data=nil} "not found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid or expired code"
+// @Router /api/v1/otp/verify [post]
+func (h *Handler) Verify(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	otp, err := meta.ReqBodyToDomain[*VerifyRequest, domain.Otp](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	if ucErr = h.otpUC.Verify(ctx, tenant, otp); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
@@ -0,0 +1,47 @@
+package otp
+
+import (
+	"microservice/internal/domain"
+	"microservice/pkg/validator"
+	"time"
+)
+
+type SendRequest struct {
+	Mobile string `json:"mobile" validate:"required,mobile" example:"09123456789"`
+}
+
+func (dto *SendRequest) ToDomain() domain.Otp {
+	d := domain.NewOtp()
+	mobile, _ := validator.NormalizeMobile(dto.Mobile) // the validated number
+	d.SetMobile(mobile)
+	return *d
+}
+
+type SendResponse struct {
+	MessageUuid string `json:"messageUuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	ExpireAt    string `json:"expireAt" example:"2025-10-01T05:02:00Z"`
+}
+
+func SendResp(src domain.Otp) SendResponse {
+	message := src.Message()
+
+	return SendResponse{
+		MessageUuid: message.UUID().String(),
+		ExpireAt:    src.ExpireAt().UTC().Format(time.RFC3339),
+	}
+}
+
+//
+
+type VerifyRequest struct {
+	Mobile string `json:"mobile" validate:"required,mobile" example:"09123456789"`
+	Code   string `json:"code" validate:"required,numeric,len=6" example:"482913"`
+}
+
+func (dto *VerifyRequest) ToDomain() domain.Otp {
+	d := domain.NewOtp()
+	mobile, _ := validator.NormalizeMobile(dto.Mobile) // the validated number
+	d.SetMobile(mobile)
+	d.SetCode(dto.Code)
+	return *d
+}
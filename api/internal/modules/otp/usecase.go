@@ -0,0 +1,170 @@
+package otp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"microservice/internal/adapter/cache"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/queue"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/utils"
+	"time"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale    locale.ILocale
+		Tracer    trace.ITracer
+		Logger    logger.ILogger
+		Cache     cache.ICache
+		Queue     queue.IQueue
+		MessageUC port.IMessageUsecase
+	}
+
+	Usecase struct {
+		l         locale.ILocale
+		trc       trace.ITracer
+		lgr       logger.ILogger
+		cache     cache.ICache
+		queue     queue.IQueue
+		messageUC port.IMessageUsecase
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.IOtpUsecase {
+	return &Usecase{
+		l:         fx.Locale,
+		trc:       fx.Tracer,
+		lgr:       fx.Logger,
+		cache:     fx.Cache,
+		queue:     fx.Queue,
+		messageUC: fx.MessageUC,
+	}
+}
+
+const (
+	// OtpLength the count of the code digits
+	OtpLength = 6
+	// OtpTtl the code is not verifiable after the ttl
+	OtpTtl = 2 * time.Minute
+	// OtpMaxAttempts the code is revoked after the max wrong attempts
+	OtpMaxAttempts = 5
+)
+
+// the verify results of the otpVerifyScript
+const (
+	otpInvalid int64 = iota
+	otpVerified
+	otpExpired
+	otpExhausted
+)
+
+// otpVerifyScript evaluates the attempt atomically, the code is removed on success (one-time use) or when the attempts are exhausted
+var otpVerifyScript = redis.NewScript(`
+local hash = redis.call('HGET', KEYS[1], 'hash')
+if not hash then
+	return 2
+end
+
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if hash == ARGV[1] then
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+
+if attempts >= tonumber(ARGV[2]) then
+	redis.call('DEL', KEYS[1])
+	return 3
+end
+
+return 0
+`)
+
+// Send a new code replaces the former code of the mobile. the code is sent through the most urgent channel and charged as a message
+func (uc *Usecase) Send(ctx context.Context, tenant domain.Tenant, ent domain.Otp) (res domain.Otp, err error) {
+	ent.SetTenantID(tenant.ID())
+	ent.SetCode(utils.RandomDigits(OtpLength))
+
+	key := otpCacheKey(ent)
+
+	// the code is stored before sending, to be verifiable as soon as the SMS is delivered
+	pipe := uc.cache.C().TxPipeline()
+	pipe.HSet(ctx, key, "hash", otpHash(ent), "attempts", 0)
+	pipe.Expire(ctx, key, OtpTtl)
+
+	if _, cErr := pipe.Exec(ctx); cErr != nil {
+		uc.lgr.Error("otp.cache.store", zap.Error(cErr))
+		err = meta.Failed
+		return
+	}
+
+	message := domain.NewMessage()
+	message.SetTenantID(tenant.ID())
+	message.SetChannel(uc.queue.Channels().Urgent().Name())
+	message.SetMobile(ent.Mobile())
+	message.SetMessageText(fmt.Sprintf("%s %s", uc.l.Get("otp_message_text"), ent.Code()))
+	// the code is not verifiable after its ttl, so the message is not delivered after it either
+	message.SetValidUntil(time.Now().UTC().Add(OtpTtl))
+
+	sent, ucErr := uc.messageUC.Send(ctx, tenant, *message)
+	if ucErr != nil {
+		if cErr := uc.cache.Del(ctx, key); cErr != nil {
+			uc.lgr.Error("otp.cache.release", zap.Error(cErr))
+		}
+
+		err = ucErr
+		return
+	}
+
+	ent.SetMessage(sent)
+	ent.SetExpireAt(time.Now().Add(OtpTtl))
+
+	res = ent
+	return
+}
+
+func (uc *Usecase) Verify(ctx context.Context, tenant domain.Tenant, ent domain.Otp) (err error) {
+	ent.SetTenantID(tenant.ID())
+
+	result, cErr := otpVerifyScript.Run(ctx, uc.cache.C(), []string{otpCacheKey(ent)}, otpHash(ent), OtpMaxAttempts).Int64()
+	if cErr != nil {
+		uc.lgr.Error("otp.cache.verify", zap.Error(cErr))
+		err = meta.Failed
+		return
+	}
+
+	switch result {
+	case otpVerified:
+		return
+	case otpExpired:
+		err = meta.Validate.SetErr(uc.l.Get("otp_expired"))
+	case otpExhausted:
+		err = meta.Forbidden.SetErr(uc.l.Get("otp_attempts_exceeded"))
+	default:
+		err = meta.Validate.SetErr(uc.l.Get("otp_invalid"))
+	}
+
+	return
+}
+
+// HELPERS
+
+func otpCacheKey(ent domain.Otp) string {
+	return fmt.Sprintf("otp:%d:%s", ent.TenantID(), ent.Mobile())
+}
+
+// otpHash only the hash of the code is stored
+func otpHash(ent domain.Otp) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%d:%s:%s", ent.TenantID(), ent.Mobile(), ent.Code())))
+	return hex.EncodeToString(h[:])
+}
@@ -49,7 +49,7 @@ func (r *Repository) Create(ctx context.Context, ent domain.Outbox) (res domain.
 	columns := []string{"uuid", "status", "retries", "created_at", "updated_at", "retry_at", "deleted_at"}
 	m := ent.ToDB()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Outboxes{}).Omit("updated_at")
 
 	txErr := tx.Unscoped().Omit(columns...).Clauses(clause.Returning{}).Create(&m).Error
@@ -73,10 +73,46 @@ func (r *Repository) Create(ctx context.Context, ent domain.Outbox) (res domain.
 	return
 }
 
+func (r *Repository) CreateBatch(ctx context.Context, ents []domain.Outbox) (res []domain.Outbox, err error) {
+	columns := []string{"uuid", "status", "retries", "created_at", "updated_at", "retry_at", "deleted_at"}
+
+	list := domain.NewOutboxList()
+	list.SetList(ents)
+	m := list.ListToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Outboxes{}).Omit("updated_at")
+
+	txErr := tx.Unscoped().Omit(columns...).Clauses(clause.Returning{}).CreateInBatches(&m, 500).Error
+	if txErr != nil {
+		err = txErr
+		r.lgr.Error("outbox.repo.create.batch", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	res = make([]domain.Outbox, 0, len(m))
+	for i, item := range m {
+		o := ents[i]
+		res = append(res, o.FromDB(item))
+	}
+
+	return
+}
+
 func (r *Repository) GetDetails(ctx context.Context, ent domain.Outbox) (res domain.Outbox, err error) {
 	m := model.NewOutbox()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Outboxes{})
 
 	if ent.GetRelations() != nil {
@@ -109,7 +145,7 @@ func (r *Repository) Update(ctx context.Context, ent domain.Outbox) (err error)
 		columns = []string{"updated_at"}
 	)
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Outboxes{}).Omit(columns...).Where("uuid = ?", ent.UUID()).Updates(m)
 	if err = tx.Error; err != nil {
 		r.lgr.Error("outbox.repo.update", zap.Error(err))
@@ -130,7 +166,7 @@ func (r *Repository) Update(ctx context.Context, ent domain.Outbox) (err error)
 }
 
 func (r *Repository) UpdateStatus(ctx context.Context, id uint, status string) (err error) {
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Outboxes{}).
 		Omit("updated_at").
 		Where("id = ?", id).Update("status", status)
@@ -154,10 +190,11 @@ func (r *Repository) UpdateStatus(ctx context.Context, id uint, status string) (
 }
 
 func (r *Repository) UpdateTryCount(ctx context.Context, id uint, count int) (err error) {
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Outboxes{}).
 		Omit("updated_at").
-		Where("id = ?", id).Update("retries", count)
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"retries": count, "retry_at": gorm.Expr("CURRENT_TIMESTAMP")})
 
 	if err = tx.Error; err != nil {
 		r.lgr.Error("message.repo.update", zap.Error(err))
@@ -178,7 +215,7 @@ func (r *Repository) UpdateTryCount(ctx context.Context, id uint, count int) (er
 }
 
 func (r *Repository) Delete(ctx context.Context, ent domain.Outbox) (err error) {
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Where("uuid = ?", ent.UUID()).Delete(&model.Outboxes{})
 	if err = tx.Error; err != nil {
 		r.lgr.Error("outbox.repo.delete", zap.Error(err))
@@ -203,7 +240,7 @@ func (r *Repository) GetList(ctx context.Context, ent domain.OutboxListReqQryPar
 		total  int64
 	)
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Outboxes{})
 
 	if ent.GetRelations() != nil {
@@ -0,0 +1,270 @@
+package policy
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+)
+
+type (
+	IPolicyHttpHandler interface {
+		CreateRule(c echo.Context) error
+		DeleteRule(c echo.Context) error
+		RuleList(c echo.Context) error
+		ReviewList(c echo.Context) error
+		Approve(c echo.Context) error
+		Reject(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale   locale.ILocale
+		Tracer   trace.ITracer
+		Logger   logger.ILogger
+		Metric   metric.IMetric
+		TenantUC port.ITenantUsecase
+		PolicyUC port.IPolicyUsecase
+	}
+
+	Handler struct {
+		l        locale.ILocale
+		trc      trace.ITracer
+		lgr      logger.ILogger
+		metric   metric.IMetric
+		tenantUC port.ITenantUsecase
+		policyUC port.IPolicyUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) IPolicyHttpHandler {
+	return &Handler{
+		l:        fx.Locale,
+		trc:      fx.Tracer,
+		lgr:      fx.Logger,
+		metric:   fx.Metric,
+		tenantUC: fx.TenantUC,
+		policyUC: fx.PolicyUC,
+	}
+}
+
+// CreateRule godoc
+// @Summary Create Content Policy Rule
+// @Description the rule is global, or of the tenant if `tenantUuid` is given. the messages matching a `reject` rule are not accepted,
+// @Description a `hold` rule holds them for the admin review and a `flag` rule sends and flags them. the strictest matched action wins
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param Request body policy.CreateRequest true "necessary fields for request"
+// @Success 201 {object} meta.Response{data=policy.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no tenant found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/policy/admin/rule/create [post]
+func (h *Handler) CreateRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rule, err := meta.ReqBodyToDomain[*CreateRequest, domain.PolicyRule](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	rule.SetTenantID(domain.GlobalPolicy)
+
+	if req := rule.Tenant(); req.UUID() != uuid.Nil {
+		tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+		if ucErr != nil {
+			return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+		}
+
+		rule.SetTenantID(tenant.ID())
+	}
+
+	res, ucErr := h.policyUC.Create(ctx, rule)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(DetailsResp(res)).Json()
+}
+
+// DeleteRule godoc
+// @Summary Delete Content Policy Rule
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Rule UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no rule found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/policy/admin/rule/{uuid} [delete]
+func (h *Handler) DeleteRule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rule, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.PolicyRule](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	if ucErr := h.policyUC.Delete(ctx, rule); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
+
+// RuleList godoc
+// @Summary Get Content Policy Rule List
+// @Description the global rules, or the rules of the tenant if `tenantUuid` is given
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, type, action, created_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the rule value and description"
+// @Param tenantUuid query string false "Tenant UUID"
+// @Param type query string false "`keyword`, `regex`, `domain_allowlist` or `max_links`"
+// @Success 200 {object} meta.Response{data=policy.ListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no tenant found"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/policy/admin/rule/list [get]
+func (h *Handler) RuleList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := meta.ReqQryParamToDomain[*ListQryRequest, domain.PolicyRuleListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	list.SetTenantId(domain.GlobalPolicy)
+
+	if req := list.Tenant(); req.UUID() != uuid.Nil {
+		tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+		if ucErr != nil {
+			return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+		}
+
+		list.SetTenantId(tenant.ID())
+	}
+
+	res, err := h.policyUC.GetList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ListResp(list, res)).Json()
+}
+
+// ReviewList godoc
+// @Summary Get Content Policy Review Queue
+// @Description the held messages of all tenants waiting for the review, or the flagged messages by `action=flag`
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param cursor query string false "the nextCursor of the former page"
+// @Param skipTotal query bool false "skip counting the items"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the message text"
+// @Param action query string false "`hold` (default) or `flag`"
+// @Success 200 {object} meta.Response{data=policy.ReviewListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/policy/admin/review/list [get]
+func (h *Handler) ReviewList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := meta.ReqQryParamToDomain[*ReviewListQryRequest, domain.MessageListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, err := h.policyUC.GetReviewList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ReviewListResp(list, res)).Json()
+}
+
+// Approve godoc
+// @Summary Approve Held Message
+// @Description the held message is queued to its channel, or `scheduled` if its send time has not come yet
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Message UUID" example(67f5627c-2d71-48f0-8afc-b7bed370bb45)
+// @Success 200 {object} meta.Response{data=policy.ReviewResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no message found"
+// @Failure	409 {object} meta.Response{data=nil} "the message is not held"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/policy/admin/review/{uuid}/approve [put]
+func (h *Handler) Approve(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	message, err := meta.ReqRouteParamsToDomain[*ReviewRequest, domain.Message](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.policyUC.Approve(ctx, message)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ReviewResp(res)).Json()
+}
+
+// Reject godoc
+// @Summary Reject Held Message
+// @Description the held message is not sent and its charged credit is refunded to the tenant
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Message UUID" example(67f5627c-2d71-48f0-8afc-b7bed370bb45)
+// @Success 200 {object} meta.Response{data=policy.ReviewResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no message found"
+// @Failure	409 {object} meta.Response{data=nil} "the message is not held"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/policy/admin/review/{uuid}/reject [put]
+func (h *Handler) Reject(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	message, err := meta.ReqRouteParamsToDomain[*ReviewRequest, domain.Message](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.policyUC.Reject(ctx, message)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ReviewResp(res)).Json()
+}
@@ -0,0 +1,201 @@
+package policy
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"time"
+)
+
+type CreateRequest struct {
+	TenantUuid  string `json:"tenantUuid" validate:"omitempty,uuid" example:"f81eee2d-2cca-4169-8062-7404a78d5c3b"` // empty for the global rule
+	Type        string `json:"type" validate:"required,oneof=keyword regex domain_allowlist max_links" example:"domain_allowlist"`
+	Value       string `json:"value" validate:"required,max=1024" example:"r1.cloud,shop.r1.cloud"` // the keyword, the pattern, the comma separated domains or the max link count
+	Action      string `json:"action" validate:"required,oneof=reject hold flag" example:"hold"`
+	Description string `json:"description" validate:"omitempty,fa_alphanum,max=255" example:"links out of the tenant domains"`
+}
+
+func (dto *CreateRequest) ToDomain() domain.PolicyRule {
+	d := domain.NewPolicyRule()
+	d.SetType(domain.PolicyRuleType(dto.Type))
+	d.SetValue(dto.Value)
+	d.SetAction(domain.PolicyAction(dto.Action))
+	d.SetDescription(dto.Description)
+
+	if len(dto.TenantUuid) > 0 {
+		tenant := domain.NewTenant()
+		tenant.SetUUID(uuid.MustParse(dto.TenantUuid))
+		d.SetTenant(*tenant)
+	}
+
+	return *d
+}
+
+type DetailsResponse struct {
+	Uuid        string `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Global      bool   `json:"global" example:"false"`
+	Type        string `json:"type" example:"domain_allowlist"` // `keyword`, `regex`, `domain_allowlist` or `max_links`
+	Value       string `json:"value" example:"r1.cloud,shop.r1.cloud"`
+	Action      string `json:"action" example:"hold"` // `reject`, `hold` or `flag`
+	Description string `json:"description,omitempty" example:"links out of the tenant domains"`
+	CreatedAt   string `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+}
+
+func DetailsResp(src domain.PolicyRule) DetailsResponse {
+	return DetailsResponse{
+		Uuid:        src.UUID().String(),
+		Global:      src.IsGlobal(),
+		Type:        string(src.Type()),
+		Value:       src.Value(),
+		Action:      string(src.Action()),
+		Description: src.Description(),
+		CreatedAt:   src.CreatedAt().Format(time.RFC3339),
+	}
+}
+
+//
+
+type DetailsRequest struct {
+	Uuid string `json:"uuid" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+}
+
+func (dto *DetailsRequest) ToDomain() domain.PolicyRule {
+	d := domain.NewPolicyRule()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return *d
+}
+
+//
+
+type ListQryRequest struct {
+	dto.ListQryRequest
+	TenantUuid string `query:"tenantUuid" json:"tenantUuid" validate:"omitempty,uuid"` // empty lists the global rules
+	Type       string `query:"type" json:"type" validate:"omitempty,oneof=keyword regex domain_allowlist max_links"`
+}
+
+func (dto *ListQryRequest) ToDomain() domain.PolicyRuleListReqQryParam {
+	qry := domain.NewPolicyRuleListReqQryParam()
+	qry.ReqBaseQryParam = dto.EvalBaseQry()
+	qry.SetType(domain.PolicyRuleType(dto.Type))
+
+	if len(dto.TenantUuid) > 0 {
+		tenant := domain.NewTenant()
+		tenant.SetUUID(uuid.MustParse(dto.TenantUuid))
+		qry.SetTenant(*tenant)
+	}
+
+	return *qry
+}
+
+type ListResponse struct {
+	dto.ListBaseResponse
+	Rules []DetailsResponse `json:"items"`
+}
+
+func ListResp(qry domain.PolicyRuleListReqQryParam, src domain.PolicyRuleList) ListResponse {
+	list := new(ListResponse)
+	list.Page = qry.Page()
+	list.Limit = qry.Limit()
+	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
+	list.Total = src.Total()
+	list.Rules = make([]DetailsResponse, 0)
+
+	if len(src.List()) > 0 {
+		for _, rule := range src.List() {
+			list.Rules = append(list.Rules, DetailsResp(rule))
+		}
+	}
+
+	return *list
+}
+
+//
+
+type ReviewRequest struct {
+	Uuid string `json:"uuid" param:"uuid" validate:"required,uuid" example:"67f5627c-2d71-48f0-8afc-b7bed370bb45"`
+}
+
+func (dto *ReviewRequest) ToDomain() domain.Message {
+	d := domain.NewMessage()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return *d
+}
+
+type ReviewResponse struct {
+	Uuid       string `json:"uuid" example:"67f5627c-2d71-48f0-8afc-b7bed370bb45"`
+	TenantUuid string `json:"tenantUuid,omitempty" example:"f81eee2d-2cca-4169-8062-7404a78d5c3b"`
+	Channel    string `json:"channel" example:"event.prod"`
+	Mobile     string `json:"mobile" example:"+989123456789"`
+	Sender     string `json:"sender,omitempty" example:"98100020003000"`
+	Message    string `json:"message" example:"Hello R1 Cloud"`
+	Status     string `json:"status" example:"held"`
+	Action     string `json:"action" example:"hold"` // the policy action, `hold` or `flag`
+	Reason     string `json:"reason" example:"links out of the tenant domains"`
+	SendAt     string `json:"sendAt,omitempty" example:"2025-10-01T05:00:00Z"`
+	CreatedAt  string `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+}
+
+func ReviewResp(src domain.Message) ReviewResponse {
+	policy := src.Policy()
+
+	resp := ReviewResponse{
+		Uuid:      src.UUID().String(),
+		Channel:   src.Channel(),
+		Mobile:    src.Mobile(),
+		Sender:    src.Sender(),
+		Message:   src.MessageText(),
+		Status:    src.Status(),
+		Action:    string(policy.Action()),
+		Reason:    policy.Reason(),
+		CreatedAt: src.CreatedAt().Format(time.RFC3339),
+	}
+
+	if tenant := src.Tenant(); tenant.ID() != 0 {
+		resp.TenantUuid = tenant.UUID().String()
+	}
+
+	if !src.SendAt().IsZero() {
+		resp.SendAt = src.SendAt().Format(time.RFC3339)
+	}
+
+	return resp
+}
+
+type ReviewListQryRequest struct {
+	dto.CursorListQryRequest
+	Action string `query:"action" json:"action" validate:"omitempty,oneof=hold flag"` // `hold` (default) lists the messages waiting for the review, `flag` lists the flagged messages
+}
+
+func (dto *ReviewListQryRequest) ToDomain() domain.MessageListReqQryParam {
+	qry := domain.NewMessageListReqQryParam()
+	qry.ReqBaseQryParam = dto.EvalBaseQry()
+
+	if dto.Action == string(domain.PolicyFlag) {
+		qry.SetPolicyAction(domain.PolicyFlag)
+	} else {
+		qry.SetPolicyAction(domain.PolicyHold)
+		qry.SetStatus(string(domain.MsgHeld))
+	}
+
+	return *qry
+}
+
+type ReviewListResponse struct {
+	dto.ListBaseResponse
+	Messages []ReviewResponse `json:"items"`
+}
+
+func ReviewListResp(qry domain.MessageListReqQryParam, src domain.MessageList) ReviewListResponse {
+	list := new(ReviewListResponse)
+	list.ListBaseResponse = dto.EvalListBase(qry.ReqBaseQryParam, src.BaseList)
+	list.Messages = make([]ReviewResponse, 0)
+
+	if len(src.List()) > 0 {
+		for _, message := range src.List() {
+			list.Messages = append(list.Messages, ReviewResp(message))
+		}
+	}
+
+	return *list
+}
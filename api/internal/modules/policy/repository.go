@@ -0,0 +1,177 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.IPolicyRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, ent domain.PolicyRule) (res domain.PolicyRule, err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.PolicyRules{})
+
+	if err = tx.Omit("uuid").Clauses(clause.Returning{}).Create(&m).Error; err != nil {
+		r.lgr.Error("policy.repo.create", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+// Delete the deleted rule is returned, its owner tenant is known by it
+func (r *Repository) Delete(ctx context.Context, ent domain.PolicyRule) (res domain.PolicyRule, err error) {
+	var models []model.PolicyRules
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Clauses(clause.Returning{}).Where("uuid = ?", ent.UUID()).Delete(&models)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("policy.repo.delete", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 || len(models) == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = domain.NewPolicyRule().FromDB(models[0])
+	return
+}
+
+// GetRules the rules of the tenant, or the global rules by the GlobalPolicy list, evaluated on sending
+func (r *Repository) GetRules(ctx context.Context, listId uint) (res []domain.PolicyRule, err error) {
+	var models []model.PolicyRules
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.PolicyRules{})
+
+	if listId == domain.GlobalPolicy {
+		tx = tx.Where("tenant_id IS NULL")
+	} else {
+		tx = tx.Where("tenant_id = ?", listId)
+	}
+
+	tx = tx.Order("id ASC").Find(&models)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("policy.repo.rules", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = make([]domain.PolicyRule, 0, len(models))
+	for _, item := range models {
+		res = append(res, domain.NewPolicyRule().FromDB(item))
+	}
+
+	return
+}
+
+func (r *Repository) GetList(ctx context.Context, ent domain.PolicyRuleListReqQryParam) (res domain.PolicyRuleList, err error) {
+	list := domain.NewPolicyRuleList()
+
+	var (
+		models []model.PolicyRules
+		total  int64
+	)
+
+	db := r.sql.TxOf(ctx)
+	tx := scopeTenant(db.WithContext(ctx).Model(&model.PolicyRules{}), ent.TenantId())
+
+	if len(ent.Type()) > 0 {
+		tx.Where("type = ?", ent.Type())
+	}
+
+	if ent.Items() != nil && len(ent.Items()) > 0 {
+		tx.Where("uuid IN ?", ent.Items()) // get all items
+	}
+
+	if len(ent.Search()) > 0 {
+		val := fmt.Sprintf("%%%s%%", ent.Search()) // this returns %search_value%
+		tx.Where("(value ILIKE ? OR description ILIKE ?)", val, val)
+	}
+
+	//
+
+	if err = tx.Count(&total).Error; err != nil {
+		r.lgr.Error("policy.repo.list.count", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	list.SetTotal(total)
+
+	//
+
+	if ent.Items() == nil {
+		tx.Offset(ent.Offset()).Limit(ent.Limit())
+	}
+
+	items := tx.Order(ent.SortOrder()).Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("policy.repo.list", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if items.RowsAffected > 0 {
+		list.ListFromDB(models)
+	}
+
+	res = *list
+	return
+}
+
+// HELPERS
+
+// scopeTenant limits the query to the tenant rules, or to the global rules for domain.GlobalPolicy
+func scopeTenant(tx *gorm.DB, tenantId uint) *gorm.DB {
+	if tenantId == domain.GlobalPolicy {
+		return tx.Where("tenant_id IS NULL")
+	}
+
+	return tx.Where("tenant_id = ?", tenantId)
+}
@@ -0,0 +1,360 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"microservice/internal/adapter/cache"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/queue"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"time"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale      locale.ILocale
+		Tracer      trace.ITracer
+		Logger      logger.ILogger
+		Tx          orm.ISqlTx
+		Cache       cache.ICache
+		PolicyRepo  port.IPolicyRepository
+		MessageRepo port.IMessageRepository
+		OutboxRepo  port.IOutboxRepository
+		CreditUC    port.ICreditUsecase
+		Queue       queue.IQueue
+	}
+
+	Usecase struct {
+		l           locale.ILocale
+		trc         trace.ITracer
+		lgr         logger.ILogger
+		tx          orm.ISqlTx
+		cache       cache.ICache
+		policyRepo  port.IPolicyRepository
+		messageRepo port.IMessageRepository
+		outboxRepo  port.IOutboxRepository
+		creditUC    port.ICreditUsecase
+		queue       queue.IQueue
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.IPolicyUsecase {
+	return &Usecase{
+		l:           fx.Locale,
+		trc:         fx.Tracer,
+		lgr:         fx.Logger,
+		tx:          fx.Tx,
+		cache:       fx.Cache,
+		policyRepo:  fx.PolicyRepo,
+		messageRepo: fx.MessageRepo,
+		outboxRepo:  fx.OutboxRepo,
+		creditUC:    fx.CreditUC,
+		queue:       fx.Queue,
+	}
+}
+
+// PolicyCacheTtl the cached rules are reloaded from the database after the ttl
+const PolicyCacheTtl = 10 * time.Minute
+
+// Create the rule is applied to the messages sent after it, the held messages are not evaluated again
+func (uc *Usecase) Create(ctx context.Context, ent domain.PolicyRule) (res domain.PolicyRule, err error) {
+	if vErr := ent.Validate(); vErr != nil {
+		err = meta.Validate.SetErr(uc.l.Get("policy_rule_invalid"))
+		return
+	}
+
+	res, txErr := uc.policyRepo.Create(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	uc.invalidate(ctx, res.TenantID())
+	return
+}
+
+func (uc *Usecase) Delete(ctx context.Context, ent domain.PolicyRule) (err error) {
+	rule, txErr := uc.policyRepo.Delete(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	uc.invalidate(ctx, rule.TenantID())
+	return
+}
+
+func (uc *Usecase) GetList(ctx context.Context, ent domain.PolicyRuleListReqQryParam) (res domain.PolicyRuleList, err error) {
+	res, txErr := uc.policyRepo.GetList(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// Evaluate the verdict of the global and the tenant rules on the message text. the rules are read from the cache,
+// the database is queried on cache failures
+func (uc *Usecase) Evaluate(ctx context.Context, tenantId uint, text string) (res domain.PolicyVerdict, err error) {
+	rules := make([]domain.PolicyRule, 0)
+
+	for _, listId := range []uint{domain.GlobalPolicy, tenantId} {
+		list, txErr := uc.cachedRules(ctx, listId)
+		if txErr != nil {
+			err = meta.EvalTxErr(txErr)
+			return
+		}
+
+		rules = append(rules, list...)
+	}
+
+	res = domain.EvaluatePolicy(rules, text)
+	return
+}
+
+// GetReviewList the messages of all tenants held or flagged by the content policy
+func (uc *Usecase) GetReviewList(ctx context.Context, ent domain.MessageListReqQryParam) (res domain.MessageList, err error) {
+	ent.SetRelations("Outbox")
+	res, txErr := uc.messageRepo.GetList(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// Approve releases the held message to its channel, or to the scheduler if its send time has not come yet
+func (uc *Usecase) Approve(ctx context.Context, ent domain.Message) (res domain.Message, err error) {
+	var txErr error
+
+	message, err := uc.heldMessage(ctx, ent)
+	if err != nil {
+		return
+	}
+
+	released := domain.MsgQueued
+	if message.IsScheduled() {
+		released = domain.MsgScheduled
+	}
+
+	//
+
+	uc.tx.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			txErr = r.(error)
+			uc.lgr.Error("policy.approve.recover", zap.Error(txErr))
+			err = meta.Failed
+		}
+
+		if txResErr := uc.tx.Resolve(txErr); txResErr != nil {
+			uc.lgr.Error("policy.approve.tx.rollback", zap.Error(txErr))
+		}
+	}()
+
+	// the status is checked again in db, the message may have been reviewed meanwhile
+
+	approved, txErr := uc.messageRepo.UpdateStatusIf(ctx, message.ID(), string(domain.MsgHeld), string(released))
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if !approved {
+		txErr = meta.Conflict.SetErr(uc.l.Get("sms_not_held"))
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	txErr = uc.messageRepo.CreateStatusHistory(ctx, []uint{message.ID()}, string(released))
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if txErr = uc.tx.Commit(); txErr != nil {
+		uc.lgr.Error("policy.approve.tx.commit", zap.Error(txErr))
+		err = meta.Failed
+		return
+	}
+
+	message.SetStatus(string(released))
+	res = message
+
+	if released == domain.MsgScheduled {
+		return
+	}
+
+	outbox := message.Outbox()
+
+	om := domain.NewOutboxMessage()
+	om.FromMessage(message)
+	om.SetOutboxID(outbox.ID())
+	om.SetQueuedAt(time.Now().UTC())
+
+	if qErr := uc.queue.Dispatch(ctx, message.Channel(), message.MessageHash(), om.Json()); qErr != nil {
+		uc.lgr.Error("policy.approve.queue.produce", zap.Uint("message.id", message.ID()), zap.Error(qErr))
+		return
+	}
+
+	event := domain.NewWebhookEvent(domain.MsgQueued)
+	event.FromMessage(message)
+	uc.queue.Notify(ctx, *event)
+
+	uc.queue.Track(ctx, *domain.NewUsageEvent(message.TenantID(), message.Channel(), domain.MsgQueued, 1))
+	return
+}
+
+// Reject the held message is not sent, its charged credit is given back
+func (uc *Usecase) Reject(ctx context.Context, ent domain.Message) (res domain.Message, err error) {
+	var (
+		refund domain.Transaction
+		txErr  error
+	)
+
+	message, err := uc.heldMessage(ctx, ent)
+	if err != nil {
+		return
+	}
+
+	//
+
+	uc.tx.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			txErr = r.(error)
+			uc.lgr.Error("policy.reject.recover", zap.Error(txErr))
+			err = meta.Failed
+		}
+
+		if txResErr := uc.tx.Resolve(txErr); txResErr != nil {
+			uc.lgr.Error("policy.reject.tx.rollback", zap.Error(txErr))
+			return
+		}
+
+		if txErr == nil {
+			event := domain.NewWebhookEvent(domain.MsgRejected)
+			event.FromMessage(message)
+			uc.queue.Notify(ctx, *event)
+
+			usage := domain.NewUsageEvent(message.TenantID(), message.Channel(), domain.MsgRejected, 1)
+			usage.SetCredit(-refund.Amount())
+			uc.queue.Track(ctx, *usage)
+		}
+	}()
+
+	rejected, txErr := uc.messageRepo.UpdateStatusIf(ctx, message.ID(), string(domain.MsgHeld), string(domain.MsgRejected))
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if !rejected {
+		txErr = meta.Conflict.SetErr(uc.l.Get("sms_not_held"))
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	txErr = uc.messageRepo.CreateStatusHistory(ctx, []uint{message.ID()}, string(domain.MsgRejected))
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	outbox := message.Outbox()
+	if txErr = uc.outboxRepo.UpdateStatus(ctx, outbox.ID(), string(domain.OutboxFailed)); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	refund, txErr = uc.creditUC.Refund(ctx, message)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	message.SetStatus(string(domain.MsgRejected))
+	res = message
+	return
+}
+
+// HELPERS
+
+func (uc *Usecase) heldMessage(ctx context.Context, ent domain.Message) (res domain.Message, err error) {
+	ent.SetRelations("Outbox", "Tenant")
+	res, txErr := uc.messageRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if res.Status() != string(domain.MsgHeld) {
+		err = meta.Conflict.SetErr(uc.l.Get("sms_not_held"))
+		return
+	}
+
+	return
+}
+
+// cachedRules the rules of the list are loaded into the cache on the first evaluation
+func (uc *Usecase) cachedRules(ctx context.Context, listId uint) (res []domain.PolicyRule, err error) {
+	key := policyCacheKey(listId)
+
+	var models []model.PolicyRules
+	cErr := uc.cache.Get(ctx, key, &models)
+	if cErr == nil {
+		res = make([]domain.PolicyRule, 0, len(models))
+		for _, item := range models {
+			res = append(res, domain.NewPolicyRule().FromDB(item))
+		}
+
+		return
+	}
+
+	if !errors.Is(cErr, redis.Nil) {
+		uc.lgr.Error("policy.cache.rules", zap.Error(cErr))
+	}
+
+	if res, err = uc.policyRepo.GetRules(ctx, listId); err != nil {
+		return
+	}
+
+	models = make([]model.PolicyRules, 0, len(res))
+	for _, rule := range res {
+		models = append(models, rule.ToDB())
+	}
+
+	if cErr = uc.cache.Set(ctx, key, models, PolicyCacheTtl); cErr != nil {
+		uc.lgr.Error("policy.cache.store", zap.Error(cErr))
+	}
+
+	return
+}
+
+// invalidate the rules of the list are reloaded on the next evaluation
+func (uc *Usecase) invalidate(ctx context.Context, listId uint) {
+	if cErr := uc.cache.Del(ctx, policyCacheKey(listId)); cErr != nil {
+		uc.lgr.Error("policy.cache.invalidate", zap.Error(cErr))
+	}
+}
+
+func policyCacheKey(listId uint) string {
+	if listId == domain.GlobalPolicy {
+		return "policy:global"
+	}
+
+	return fmt.Sprintf("policy:%d", listId)
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	IBlocklistRepository interface {
+		Create(ctx context.Context, ent domain.BlockedNumber) (domain.BlockedNumber, error)
+		Delete(ctx context.Context, ent domain.BlockedNumber) error
+		IsBlocked(ctx context.Context, tenantId uint, mobile string) (bool, error)
+		GetMobiles(ctx context.Context, tenantId uint) ([]string, error)
+		GetList(ctx context.Context, ent domain.BlockedNumberListReqQryParam) (domain.BlockedNumberList, error)
+	}
+
+	IBlocklistUsecase interface {
+		Create(ctx context.Context, ent domain.BlockedNumber) (domain.BlockedNumber, error)
+		Delete(ctx context.Context, ent domain.BlockedNumber) error
+		IsBlocked(ctx context.Context, tenantId uint, mobile string) (bool, error)
+		OptOut(ctx context.Context, tenantId uint, mobile string) error
+		GetList(ctx context.Context, ent domain.BlockedNumberListReqQryParam) (domain.BlockedNumberList, error)
+	}
+)
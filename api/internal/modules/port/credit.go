@@ -10,10 +10,12 @@ type (
 		Create(ctx context.Context, ent domain.Credit) (domain.Credit, error)
 		GetDetails(ctx context.Context, ent domain.Credit) (domain.Credit, error)
 		Update(ctx context.Context, ent domain.Credit) error
+		IncreaseBalance(ctx context.Context, id uint, amount float64) error
 	}
 
 	ICreditUsecase interface {
 		IncreaseAmount(ctx context.Context, ent domain.Credit) (domain.Credit, error)
 		GetDetails(ctx context.Context, ent domain.Credit, qp domain.TransactionListReqQryParam) (domain.Credit, error)
+		Refund(ctx context.Context, message domain.Message) (domain.Transaction, error)
 	}
 )
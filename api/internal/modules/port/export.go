@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"io"
+	"microservice/internal/domain"
+	"time"
+)
+
+type (
+	IExportRepository interface {
+		Create(ctx context.Context, ent domain.ExportJob) (domain.ExportJob, error)
+		GetDetails(ctx context.Context, ent domain.ExportJob) (domain.ExportJob, error)
+		UpdateResult(ctx context.Context, ent domain.ExportJob) error
+		FailUnfinished(ctx context.Context, reason string) (int64, error)
+		ListExpired(ctx context.Context, before time.Time) ([]domain.ExportJob, error)
+	}
+
+	IExportUsecase interface {
+		Write(ctx context.Context, ent domain.ExportJob, w io.Writer) (int64, error)
+		CreateJob(ctx context.Context, ent domain.ExportJob) (domain.ExportJob, error)
+		GetJob(ctx context.Context, ent domain.ExportJob) (domain.ExportJob, error)
+	}
+)
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	ILinkRepository interface {
+		CreateBatch(ctx context.Context, ents []domain.ShortLink) error
+		GetByCode(ctx context.Context, code string) (domain.ShortLink, error)
+		CreateClick(ctx context.Context, ent domain.LinkClick) error
+		CountClicks(ctx context.Context, messageId uint) (int64, error)
+	}
+
+	ILinkUsecase interface {
+		Shorten(text string) (string, []domain.ShortLink)
+		Click(ctx context.Context, ent domain.ShortLink) (domain.ShortLink, error)
+	}
+)
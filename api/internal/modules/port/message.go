@@ -2,19 +2,42 @@ package port
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"microservice/internal/domain"
+	"time"
 )
 
 type (
 	IMessageRepository interface {
 		Create(ctx context.Context, ent domain.Message) (domain.Message, error)
+		CreateBatch(ctx context.Context, ents []domain.Message) ([]domain.Message, error)
+		GetJobProgress(ctx context.Context, tenantId uint, jobId uuid.UUID) (map[string]int64, error)
+		GetDetails(ctx context.Context, ent domain.Message) (domain.Message, error)
 		Update(ctx context.Context, ent domain.Message) error
 		UpdateStatus(ctx context.Context, id uint, status string) error
+		UpdateStatusIf(ctx context.Context, id uint, current, status string) (bool, error)
+		UpdateStatusUnless(ctx context.Context, id uint, excluded []string, status string) (bool, error)
+		CreateStatusHistory(ctx context.Context, ids []uint, status string) error
+		MarkSent(ctx context.Context, id uint, providerId string) (bool, error)
+		GetByProviderMessageID(ctx context.Context, providerId string) (domain.Message, error)
+		GetLatestByMobile(ctx context.Context, mobile string) (domain.Message, error)
+		UpdateDelivery(ctx context.Context, id uint, status string, dlr domain.MessageDlr) (bool, error)
+		ReleaseScheduled(ctx context.Context, now time.Time, limit int) (domain.MessageList, error)
 		GetList(ctx context.Context, ent domain.MessageListReqQryParam) (domain.MessageList, error)
+		Stream(ctx context.Context, ent domain.MessageListReqQryParam, fn func(domain.Message) error) error
+		CreateInbound(ctx context.Context, ent domain.MessageInbound) (domain.MessageInbound, error)
+		GetInboundList(ctx context.Context, ent domain.MessageInboundListReqQryParam) (domain.MessageInboundList, error)
 	}
 
 	IMessageUsecase interface {
 		Send(ctx context.Context, credit domain.Tenant, ent domain.Message) (domain.Message, error)
+		SendBulk(ctx context.Context, tenant domain.Tenant, ent domain.MessageBulk) (domain.MessageBulk, error)
+		GetBulkProgress(ctx context.Context, ent domain.MessageBulk) (domain.MessageBulk, error)
+		GetDetails(ctx context.Context, tenant domain.Tenant, ent domain.Message) (domain.Message, error)
+		Cancel(ctx context.Context, tenant domain.Tenant, ent domain.Message) (domain.Message, error)
+		Deliver(ctx context.Context, dlr domain.MessageDlr) (domain.Message, error)
+		Receive(ctx context.Context, ent domain.MessageInbound) (domain.MessageInbound, error)
 		GetList(ctx context.Context, ent domain.MessageListReqQryParam) (domain.MessageList, error)
+		GetInboundList(ctx context.Context, ent domain.MessageInboundListReqQryParam) (domain.MessageInboundList, error)
 	}
 )
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	IOtpUsecase interface {
+		Send(ctx context.Context, tenant domain.Tenant, ent domain.Otp) (domain.Otp, error)
+		Verify(ctx context.Context, tenant domain.Tenant, ent domain.Otp) error
+	}
+)
@@ -8,6 +8,7 @@ import (
 type (
 	IOutboxRepository interface {
 		Create(ctx context.Context, ent domain.Outbox) (domain.Outbox, error)
+		CreateBatch(ctx context.Context, ents []domain.Outbox) ([]domain.Outbox, error)
 		GetDetails(ctx context.Context, ent domain.Outbox) (domain.Outbox, error)
 		Update(ctx context.Context, ent domain.Outbox) error
 		UpdateStatus(ctx context.Context, id uint, status string) error
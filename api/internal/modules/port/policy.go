@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	IPolicyRepository interface {
+		Create(ctx context.Context, ent domain.PolicyRule) (domain.PolicyRule, error)
+		Delete(ctx context.Context, ent domain.PolicyRule) (domain.PolicyRule, error)
+		GetRules(ctx context.Context, listId uint) ([]domain.PolicyRule, error)
+		GetList(ctx context.Context, ent domain.PolicyRuleListReqQryParam) (domain.PolicyRuleList, error)
+	}
+
+	IPolicyUsecase interface {
+		Create(ctx context.Context, ent domain.PolicyRule) (domain.PolicyRule, error)
+		Delete(ctx context.Context, ent domain.PolicyRule) error
+		GetList(ctx context.Context, ent domain.PolicyRuleListReqQryParam) (domain.PolicyRuleList, error)
+		Evaluate(ctx context.Context, tenantId uint, text string) (domain.PolicyVerdict, error)
+		GetReviewList(ctx context.Context, ent domain.MessageListReqQryParam) (domain.MessageList, error)
+		Approve(ctx context.Context, ent domain.Message) (domain.Message, error)
+		Reject(ctx context.Context, ent domain.Message) (domain.Message, error)
+	}
+)
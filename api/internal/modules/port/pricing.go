@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	IPricingRepository interface {
+		Create(ctx context.Context, ent domain.PricingPlan) (domain.PricingPlan, error)
+		GetDetails(ctx context.Context, ent domain.PricingPlan) (domain.PricingPlan, error)
+		GetByTenant(ctx context.Context, tenantId uint) (domain.PricingPlan, error)
+		Update(ctx context.Context, ent domain.PricingPlan) error
+		Delete(ctx context.Context, ent domain.PricingPlan) error
+		GetList(ctx context.Context, ent domain.PricingPlanListReqQryParam) (domain.PricingPlanList, error)
+		Assign(ctx context.Context, tenantId uint, planId uint) error
+		CountTenants(ctx context.Context, planId uint) (int64, error)
+	}
+
+	IPricingUsecase interface {
+		Create(ctx context.Context, ent domain.PricingPlan) (domain.PricingPlan, error)
+		GetDetails(ctx context.Context, ent domain.PricingPlan) (domain.PricingPlan, error)
+		Update(ctx context.Context, ent domain.PricingPlan) (domain.PricingPlan, error)
+		Delete(ctx context.Context, ent domain.PricingPlan) error
+		GetList(ctx context.Context, ent domain.PricingPlanListReqQryParam) (domain.PricingPlanList, error)
+		Assign(ctx context.Context, ent domain.PricingAssignment) error
+		GetDiscount(ctx context.Context, tenantId uint) (float64, error)
+	}
+)
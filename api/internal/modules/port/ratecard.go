@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	IRateCardRepository interface {
+		GetByOperator(ctx context.Context, operator domain.Operator, channel string) (domain.RateCard, error)
+	}
+)
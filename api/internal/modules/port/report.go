@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+	"time"
+)
+
+type (
+	IReportRepository interface {
+		Increment(ctx context.Context, ent domain.UsageRollup) error
+		GetUsage(ctx context.Context, ent domain.UsageReportReqQryParam) (domain.UsageReport, error)
+		GetMonthlyVolume(ctx context.Context, tenantId uint, month time.Time) (int64, error)
+	}
+
+	IReportUsecase interface {
+		GetUsage(ctx context.Context, ent domain.UsageReportReqQryParam) (domain.UsageReport, error)
+	}
+)
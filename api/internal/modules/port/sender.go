@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	ISenderRepository interface {
+		Create(ctx context.Context, ent domain.Sender) (domain.Sender, error)
+		GetDetails(ctx context.Context, ent domain.Sender) (domain.Sender, error)
+		GetApproved(ctx context.Context, tenantId uint, sender string) (domain.Sender, error)
+		GetApprovedLine(ctx context.Context, sender string) (domain.Sender, error)
+		UpdateStatus(ctx context.Context, ent domain.Sender) error
+		Delete(ctx context.Context, ent domain.Sender) error
+		GetList(ctx context.Context, ent domain.SenderListReqQryParam) (domain.SenderList, error)
+	}
+
+	ISenderUsecase interface {
+		Create(ctx context.Context, ent domain.Sender) (domain.Sender, error)
+		Review(ctx context.Context, ent domain.Sender) (domain.Sender, error)
+		Delete(ctx context.Context, ent domain.Sender) error
+		GetList(ctx context.Context, ent domain.SenderListReqQryParam) (domain.SenderList, error)
+	}
+)
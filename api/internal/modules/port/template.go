@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+)
+
+type (
+	ITemplateRepository interface {
+		Create(ctx context.Context, ent domain.Template) (domain.Template, error)
+		GetDetails(ctx context.Context, ent domain.Template) (domain.Template, error)
+		Update(ctx context.Context, ent domain.Template) error
+		Delete(ctx context.Context, ent domain.Template) error
+		GetList(ctx context.Context, ent domain.TemplateListReqQryParam) (domain.TemplateList, error)
+	}
+
+	ITemplateUsecase interface {
+		Create(ctx context.Context, ent domain.Template) (domain.Template, error)
+		GetDetails(ctx context.Context, ent domain.Template) (domain.Template, error)
+		Update(ctx context.Context, ent domain.Template) (domain.Template, error)
+		Delete(ctx context.Context, ent domain.Template) error
+		GetList(ctx context.Context, ent domain.TemplateListReqQryParam) (domain.TemplateList, error)
+	}
+)
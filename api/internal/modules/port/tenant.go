@@ -9,12 +9,20 @@ type (
 	ITenantRepository interface {
 		Create(ctx context.Context, ent domain.Tenant) (domain.Tenant, error)
 		GetDetails(ctx context.Context, ent domain.Tenant) (domain.Tenant, error)
+		GetByID(ctx context.Context, id uint) (domain.Tenant, error)
+		UpdateWebhook(ctx context.Context, ent domain.Tenant) error
+		UpdateDedupWindow(ctx context.Context, ent domain.Tenant) error
+		UpdateQuietHours(ctx context.Context, ent domain.Tenant) error
 		GetList(ctx context.Context, ent domain.TenantListReqQryParam) (domain.TenantList, error)
 	}
 
 	ITenantUsecase interface {
 		Create(ctx context.Context, ent domain.Tenant) (domain.Tenant, error)
 		GetDetails(ctx context.Context, ent domain.Tenant) (domain.Tenant, error)
+		SetWebhook(ctx context.Context, ent domain.Tenant) (domain.Tenant, error)
+		RemoveWebhook(ctx context.Context, ent domain.Tenant) error
+		SetDedupWindow(ctx context.Context, ent domain.Tenant) (domain.Tenant, error)
+		SetQuietHours(ctx context.Context, ent domain.Tenant) (domain.Tenant, error)
 		GetList(ctx context.Context, ent domain.TenantListReqQryParam) (domain.TenantList, error)
 	}
 )
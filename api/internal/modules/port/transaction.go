@@ -8,7 +8,11 @@ import (
 type (
 	ITransactionRepository interface {
 		Create(ctx context.Context, ent domain.Transaction) (domain.Transaction, error)
+		CreateIfAbsent(ctx context.Context, ent domain.Transaction) (domain.Transaction, bool, error)
+		CreateBatch(ctx context.Context, ents []domain.Transaction) error
+		GetByMessageHash(ctx context.Context, hash []byte, txType domain.TransactionType) (domain.Transaction, error)
 		GetList(ctx context.Context, ent domain.TransactionListReqQryParam) (domain.TransactionList, error)
+		Stream(ctx context.Context, ent domain.TransactionListReqQryParam, fn func(domain.Transaction) error) error
 	}
 
 	ITransactionUsecase interface {
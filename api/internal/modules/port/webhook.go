@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"microservice/internal/domain"
+	"time"
+)
+
+type (
+	IWebhookRepository interface {
+		CreateDelivery(ctx context.Context, ent domain.WebhookDelivery) (domain.WebhookDelivery, error)
+		CreateRetry(ctx context.Context, event domain.WebhookEvent) error
+		ReleaseRetries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookEvent, error)
+	}
+)
@@ -0,0 +1,247 @@
+package pricing
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+)
+
+type (
+	IPricingHttpHandler interface {
+		Create(c echo.Context) error
+		Details(c echo.Context) error
+		Update(c echo.Context) error
+		Delete(c echo.Context) error
+		List(c echo.Context) error
+		Assign(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale    locale.ILocale
+		Tracer    trace.ITracer
+		Logger    logger.ILogger
+		Metric    metric.IMetric
+		TenantUC  port.ITenantUsecase
+		PricingUC port.IPricingUsecase
+	}
+
+	Handler struct {
+		l         locale.ILocale
+		trc       trace.ITracer
+		lgr       logger.ILogger
+		metric    metric.IMetric
+		tenantUC  port.ITenantUsecase
+		pricingUC port.IPricingUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) IPricingHttpHandler {
+	return &Handler{
+		l:         fx.Locale,
+		trc:       fx.Tracer,
+		lgr:       fx.Logger,
+		metric:    fx.Metric,
+		tenantUC:  fx.TenantUC,
+		pricingUC: fx.PricingUC,
+	}
+}
+
+// Create godoc
+// @Summary Create New Pricing Plan
+// @Description the `standard` plan pays the rate card prices, the `custom` plan gets the flat `discount` percent and
+// @Description the `tiered` plan gets the discount of the highest tier reached by the messages queued in the month (UTC)
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param Request body pricing.CreateRequest true "necessary fields for request"
+// @Success 201 {object} meta.Response{data=pricing.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/pricing/admin/plan/create [post]
+func (h *Handler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	plan, err := meta.ReqBodyToDomain[*CreateRequest, domain.PricingPlan](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.pricingUC.Create(ctx, plan)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(DetailsResp(res)).Json()
+}
+
+// Details godoc
+// @Summary Get Pricing Plan Details
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Pricing Plan UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{data=pricing.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no plan found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/pricing/admin/plan/{uuid} [get]
+func (h *Handler) Details(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	plan, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.PricingPlan](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.pricingUC.GetDetails(ctx, plan)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
+// Update godoc
+// @Summary Update Pricing Plan
+// @Description the plan and its tiers are replaced. the prices of the charged messages are kept unchanged
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Pricing Plan UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Param Request body pricing.CreateRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=pricing.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no plan found"
+// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/pricing/admin/plan/{uuid} [put]
+func (h *Handler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	plan, err := meta.ReqBodyToDomain[*UpdateRequest, domain.PricingPlan](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.pricingUC.Update(ctx, plan)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
+// Delete godoc
+// @Summary Delete Pricing Plan
+// @Description the plan assigned to a tenant is not deletable
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Pricing Plan UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no plan found"
+// @Failure	409 {object} meta.Response{data=nil} "plan in use"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/pricing/admin/plan/{uuid} [delete]
+func (h *Handler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	plan, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.PricingPlan](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	if ucErr := h.pricingUC.Delete(ctx, plan); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
+
+// List godoc
+// @Summary Get Pricing Plan List
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, name, created_at, updated_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Plan Name and Description"
+// @Param type query string false "`standard`, `tiered` or `custom`"
+// @Success 200 {object} meta.Response{data=pricing.ListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/pricing/admin/plan/list [get]
+func (h *Handler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := meta.ReqQryParamToDomain[*ListQryRequest, domain.PricingPlanListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, err := h.pricingUC.GetList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ListResp(list, res)).Json()
+}
+
+// Assign godoc
+// @Summary Assign Pricing Plan To Tenant
+// @Description the tenant pays the plan prices from its next message on, the empty `planUuid` unassigns the plan
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body pricing.AssignRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no tenant or plan found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/pricing/admin/tenant/{uuid} [put]
+func (h *Handler) Assign(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	assignment, err := meta.ReqBodyToDomain[*AssignRequest, domain.PricingAssignment](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, assignment.Tenant())
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	assignment.SetTenant(tenant)
+
+	if ucErr = h.pricingUC.Assign(ctx, assignment); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
@@ -0,0 +1,171 @@
+package pricing
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"time"
+)
+
+type TierRequest struct {
+	MinVolume int64   `json:"minVolume" validate:"min=0" example:"100000"`      // the monthly messages count the tier applies from
+	Discount  float64 `json:"discount" validate:"min=0,max=100" example:"12.5"` // percent
+}
+
+type CreateRequest struct {
+	Name        string        `json:"name" validate:"required,max=64" example:"enterprise"`
+	Type        string        `json:"type" validate:"required,oneof=standard tiered custom" example:"tiered"`
+	Discount    float64       `json:"discount" validate:"min=0,max=100" example:"0"` // percent of the custom plan
+	Description string        `json:"description" validate:"omitempty,max=255" example:"the high volume tenants"`
+	Tiers       []TierRequest `json:"tiers" validate:"required_if=Type tiered,max=20,unique=MinVolume,dive"`
+}
+
+func (dto *CreateRequest) ToDomain() domain.PricingPlan {
+	d := domain.NewPricingPlan()
+	d.SetName(dto.Name)
+	d.SetType(domain.PricingPlanType(dto.Type))
+	d.SetDescription(dto.Description)
+
+	// the flat discount belongs to the custom plans and the tiers to the tiered plans only
+	switch d.Type() {
+	case domain.PlanCustom:
+		d.SetDiscount(dto.Discount)
+	case domain.PlanTiered:
+		d.SetTiers(tiersToDomain(dto.Tiers))
+	}
+
+	return *d
+}
+
+//
+
+type DetailsRequest struct {
+	Uuid string `json:"uuid" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+}
+
+func (dto *DetailsRequest) ToDomain() domain.PricingPlan {
+	d := domain.NewPricingPlan()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return *d
+}
+
+type (
+	TierResponse struct {
+		MinVolume int64   `json:"minVolume" example:"100000"`
+		Discount  float64 `json:"discount" example:"12.5"`
+	}
+
+	DetailsResponse struct {
+		Uuid        string         `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+		Name        string         `json:"name" example:"enterprise"`
+		Type        string         `json:"type" example:"tiered"` // `standard`, `tiered` or `custom`
+		Discount    float64        `json:"discount" example:"0"`
+		Description string         `json:"description,omitempty" example:"the high volume tenants"`
+		Tiers       []TierResponse `json:"tiers"`
+		CreatedAt   string         `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+		UpdatedAt   string         `json:"updatedAt" example:"2025-10-01T05:00:00Z"`
+	}
+)
+
+func DetailsResp(src domain.PricingPlan) DetailsResponse {
+	resp := DetailsResponse{
+		Uuid:        src.UUID().String(),
+		Name:        src.Name(),
+		Type:        string(src.Type()),
+		Discount:    src.Discount(),
+		Description: src.Description(),
+		Tiers:       make([]TierResponse, 0, len(src.Tiers())),
+		CreatedAt:   src.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:   src.UpdatedAt().Format(time.RFC3339),
+	}
+
+	for _, tier := range src.Tiers() {
+		resp.Tiers = append(resp.Tiers, TierResponse{MinVolume: tier.MinVolume(), Discount: tier.Discount()})
+	}
+
+	return resp
+}
+
+//
+
+type UpdateRequest struct {
+	Uuid string `json:"-" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	CreateRequest
+}
+
+func (dto *UpdateRequest) ToDomain() domain.PricingPlan {
+	d := dto.CreateRequest.ToDomain()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return d
+}
+
+//
+
+type AssignRequest struct {
+	TenantUuid string `json:"-" param:"uuid" validate:"required,uuid" example:"f81eee2d-2cca-4169-8062-7404a78d5c3b"`
+	PlanUuid   string `json:"planUuid" validate:"omitempty,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"` // empty to unassign
+}
+
+func (dto *AssignRequest) ToDomain() domain.PricingAssignment {
+	tenant := domain.NewTenant()
+	tenant.SetUUID(uuid.MustParse(dto.TenantUuid))
+
+	plan := domain.NewPricingPlan()
+	if len(dto.PlanUuid) > 0 {
+		plan.SetUUID(uuid.MustParse(dto.PlanUuid))
+	}
+
+	d := domain.NewPricingAssignment()
+	d.SetTenant(*tenant)
+	d.SetPlan(*plan)
+	return *d
+}
+
+//
+
+type ListQryRequest struct {
+	dto.ListQryRequest
+	Type string `query:"type" json:"type" validate:"omitempty,oneof=standard tiered custom"`
+}
+
+func (dto *ListQryRequest) ToDomain() domain.PricingPlanListReqQryParam {
+	qry := domain.NewPricingPlanListReqQryParam()
+	qry.ReqBaseQryParam = dto.EvalBaseQry()
+	qry.SetType(dto.Type)
+
+	return *qry
+}
+
+type ListResponse struct {
+	dto.ListBaseResponse
+	Plans []DetailsResponse `json:"items"`
+}
+
+func ListResp(qry domain.PricingPlanListReqQryParam, src domain.PricingPlanList) ListResponse {
+	list := new(ListResponse)
+	list.Page = qry.Page()
+	list.Limit = qry.Limit()
+	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
+	list.Total = src.Total()
+	list.Plans = make([]DetailsResponse, 0)
+
+	if len(src.List()) > 0 {
+		for _, plan := range src.List() {
+			list.Plans = append(list.Plans, DetailsResp(plan))
+		}
+	}
+
+	return *list
+}
+
+// HELPERS
+
+func tiersToDomain(src []TierRequest) []domain.PricingTier {
+	tiers := make([]domain.PricingTier, 0, len(src))
+	for _, tier := range src {
+		tiers = append(tiers, *domain.NewPricingTier(tier.MinVolume, tier.Discount))
+	}
+
+	return tiers
+}
@@ -0,0 +1,290 @@
+package pricing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.IPricingRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+// Create the plan and its tiers are created together
+func (r *Repository) Create(ctx context.Context, ent domain.PricingPlan) (res domain.PricingPlan, err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.PricingPlans{})
+
+	txErr := tx.Omit("uuid").Clauses(clause.Returning{}).Create(&m).Error
+	if txErr != nil {
+		err = txErr
+		r.lgr.Error("pricing.repo.create", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+func (r *Repository) GetDetails(ctx context.Context, ent domain.PricingPlan) (res domain.PricingPlan, err error) {
+	m := model.NewPricingPlan()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.PricingPlans{})
+
+	u := tx.Preload("Tiers").First(&m, "uuid = ?", ent.UUID())
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("pricing.repo.detail", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewPricingPlan()
+	res.FromDB(*m)
+	return
+}
+
+// GetByTenant the plan assigned to the tenant, not found for the tenants without plan
+func (r *Repository) GetByTenant(ctx context.Context, tenantId uint) (res domain.PricingPlan, err error) {
+	m := model.NewPricingPlan()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.PricingPlans{})
+
+	u := tx.Preload("Tiers").
+		Joins("JOIN tenants ON tenants.pricing_plan_id = pricing_plans.id").
+		First(&m, "tenants.id = ?", tenantId)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("pricing.repo.tenant", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewPricingPlan()
+	res.FromDB(*m)
+	return
+}
+
+// Update the tiers of the plan are replaced by the given tiers
+func (r *Repository) Update(ctx context.Context, ent domain.PricingPlan) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.PricingPlans{}).
+		Omit("uuid", "created_at", "deleted_at", "Tiers").
+		Where("id = ?", ent.ID()).
+		Updates(map[string]interface{}{
+			"name":        m.Name,
+			"type":        m.Type,
+			"discount":    m.Discount,
+			"description": m.Description,
+			"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("pricing.repo.update", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	//
+
+	if err = db.WithContext(ctx).Where("pricing_plan_id = ?", ent.ID()).Delete(&model.PricingPlanTiers{}).Error; err != nil {
+		r.lgr.Error("pricing.repo.update.tiers.delete", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if len(m.Tiers) == 0 {
+		return
+	}
+
+	if err = db.WithContext(ctx).Create(&m.Tiers).Error; err != nil {
+		r.lgr.Error("pricing.repo.update.tiers.create", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+func (r *Repository) Delete(ctx context.Context, ent domain.PricingPlan) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Where("uuid = ?", ent.UUID()).Delete(&model.PricingPlans{})
+	if err = tx.Error; err != nil {
+		r.lgr.Error("pricing.repo.delete", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetList(ctx context.Context, ent domain.PricingPlanListReqQryParam) (res domain.PricingPlanList, err error) {
+	list := domain.NewPricingPlanList()
+
+	var (
+		models []model.PricingPlans
+		total  int64
+	)
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.PricingPlans{})
+
+	if ent.Items() != nil && len(ent.Items()) > 0 {
+		tx.Where("uuid IN ?", ent.Items()) // get all items
+	}
+
+	if len(ent.Type()) > 0 {
+		tx.Where("type = ?", ent.Type())
+	}
+
+	if len(ent.Search()) > 0 {
+		val := fmt.Sprintf("%%%s%%", ent.Search()) // this returns %search_value%
+		tx.Where("name ILIKE ? OR description ILIKE ?", val, val)
+	}
+
+	//
+
+	if err = tx.Count(&total).Error; err != nil {
+		r.lgr.Error("pricing.repo.list.count", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	list.SetTotal(total)
+
+	//
+
+	if ent.Items() == nil {
+		tx.Offset(ent.Offset()).Limit(ent.Limit())
+	}
+
+	items := tx.Preload("Tiers").Order(ent.SortOrder()).Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("pricing.repo.list", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if items.RowsAffected > 0 {
+		list.ListFromDB(models)
+	}
+
+	res = *list
+	return
+}
+
+// Assign sets the plan of the tenant, the zero plan id unassigns the plan
+func (r *Repository) Assign(ctx context.Context, tenantId uint, planId uint) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Tenants{}).
+		Where("id = ?", tenantId).
+		Update("pricing_plan_id", sql.NullInt64{Int64: int64(planId), Valid: planId > 0})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("pricing.repo.assign", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+// CountTenants the count of the tenants the plan is assigned to
+func (r *Repository) CountTenants(ctx context.Context, planId uint) (res int64, err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Tenants{}).Where("pricing_plan_id = ?", planId).Count(&res)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("pricing.repo.tenants.count", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
@@ -0,0 +1,199 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"time"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale      locale.ILocale
+		Tracer      trace.ITracer
+		Logger      logger.ILogger
+		Tx          orm.ISqlTx
+		PricingRepo port.IPricingRepository
+		ReportRepo  port.IReportRepository
+	}
+
+	Usecase struct {
+		l           locale.ILocale
+		trc         trace.ITracer
+		lgr         logger.ILogger
+		tx          orm.ISqlTx
+		pricingRepo port.IPricingRepository
+		reportRepo  port.IReportRepository
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.IPricingUsecase {
+	return &Usecase{
+		l:           fx.Locale,
+		trc:         fx.Tracer,
+		lgr:         fx.Logger,
+		tx:          fx.Tx,
+		pricingRepo: fx.PricingRepo,
+		reportRepo:  fx.ReportRepo,
+	}
+}
+
+func (uc *Usecase) Create(ctx context.Context, ent domain.PricingPlan) (res domain.PricingPlan, err error) {
+	res, txErr := uc.pricingRepo.Create(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) GetDetails(ctx context.Context, ent domain.PricingPlan) (res domain.PricingPlan, err error) {
+	res, txErr := uc.pricingRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// Update the plan and its tiers are replaced, the charged transactions keep their unit price
+func (uc *Usecase) Update(ctx context.Context, ent domain.PricingPlan) (res domain.PricingPlan, err error) {
+	var txErr error
+
+	uc.tx.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			txErr = r.(error)
+			uc.lgr.Error("pricing.update.recover", zap.Error(txErr))
+			err = meta.Failed
+		}
+
+		if txResErr := uc.tx.Resolve(txErr); txResErr != nil {
+			uc.lgr.Error("pricing.update.tx.resolve", zap.Error(txErr))
+		}
+	}()
+
+	//
+
+	plan, txErr := uc.pricingRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	ent.SetID(plan.ID())
+
+	if txErr = uc.pricingRepo.Update(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	res, txErr = uc.pricingRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// Delete the plan is deleted only when no tenant is assigned to it
+func (uc *Usecase) Delete(ctx context.Context, ent domain.PricingPlan) (err error) {
+	plan, txErr := uc.pricingRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	tenants, txErr := uc.pricingRepo.CountTenants(ctx, plan.ID())
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if tenants > 0 {
+		err = meta.Conflict.SetErr(uc.l.Get("pricing_plan_in_use"))
+		return
+	}
+
+	if txErr = uc.pricingRepo.Delete(ctx, plan); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) GetList(ctx context.Context, ent domain.PricingPlanListReqQryParam) (res domain.PricingPlanList, err error) {
+	res, txErr := uc.pricingRepo.GetList(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// Assign the tenant pays its plan prices from the next message on, the zero plan uuid unassigns the plan
+func (uc *Usecase) Assign(ctx context.Context, ent domain.PricingAssignment) (err error) {
+	var planId uint
+
+	plan := ent.Plan()
+	if plan.UUID() != uuid.Nil {
+		res, txErr := uc.pricingRepo.GetDetails(ctx, plan)
+		if txErr != nil {
+			err = meta.EvalTxErr(txErr)
+			return
+		}
+
+		planId = res.ID()
+	}
+
+	tenant := ent.Tenant()
+	if txErr := uc.pricingRepo.Assign(ctx, tenant.ID(), planId); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// GetDiscount the discount percent of the tenant plan for the volume sent in the current month, no discount for
+// the tenants without plan
+func (uc *Usecase) GetDiscount(ctx context.Context, tenantId uint) (res float64, err error) {
+	plan, txErr := uc.pricingRepo.GetByTenant(ctx, tenantId)
+	if txErr != nil {
+		if errors.Is(txErr, meta.NotFound) {
+			return
+		}
+
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	if plan.Type() != domain.PlanTiered {
+		res = plan.EffectiveDiscount(0)
+		return
+	}
+
+	volume, txErr := uc.reportRepo.GetMonthlyVolume(ctx, tenantId, time.Now().UTC())
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	res = plan.EffectiveDiscount(volume)
+	return
+}
@@ -0,0 +1,65 @@
+package ratecard
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.IRateCardRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+func (r *Repository) GetByOperator(ctx context.Context, operator domain.Operator, channel string) (res domain.RateCard, err error) {
+	m := model.NewRateCard()
+
+	db := r.sql.TxOf(ctx)
+	u := db.WithContext(ctx).Model(&model.RateCards{}).First(&m, "operator = ? AND channel = ?", operator, channel)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("ratecard.repo.detail.operator", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewRateCard()
+	res.FromDB(*m)
+	return
+}
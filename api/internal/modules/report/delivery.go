@@ -0,0 +1,144 @@
+package report
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+)
+
+type (
+	IReportHttpHandler interface {
+		Usage(c echo.Context) error
+		AdminUsage(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale   locale.ILocale
+		Tracer   trace.ITracer
+		Logger   logger.ILogger
+		Metric   metric.IMetric
+		TenantUC port.ITenantUsecase
+		ReportUC port.IReportUsecase
+	}
+
+	Handler struct {
+		l        locale.ILocale
+		trc      trace.ITracer
+		lgr      logger.ILogger
+		metric   metric.IMetric
+		tenantUC port.ITenantUsecase
+		reportUC port.IReportUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) IReportHttpHandler {
+	return &Handler{
+		l:        fx.Locale,
+		trc:      fx.Tracer,
+		lgr:      fx.Logger,
+		metric:   fx.Metric,
+		tenantUC: fx.TenantUC,
+		reportUC: fx.ReportUC,
+	}
+}
+
+// Usage godoc
+// @Summary Get Usage Report
+// @Description the message count per status and channel, the spent credit, the short link clicks and the average queued to sent duration per day or hour (UTC).
+// @Description the counts are the status changes within the bucket, the refunds are deducted from the spent credit.
+// @Description the range is the last 7 days (or 24 hours) by default, up to 366 days (or 7 days for hourly)
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param from query string false "RFC3339, inclusive start of the range"
+// @Param to query string false "RFC3339, exclusive end of the range, now by default"
+// @Param granularity query string false "`day` (default) or `hour`"
+// @Param channel query string false "one of the configured channels, e.g. `event.prod`"
+// @Success 200 {object} meta.Response{data=report.UsageResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/reports/usage [get]
+func (h *Handler) Usage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	qry, err := meta.ReqQryParamToDomain[*UsageQryRequest, domain.UsageReportReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	qry.SetTenantId(tenant.ID())
+
+	res, ucErr := h.reportUC.GetUsage(ctx, qry)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(UsageResp(res)).Json()
+}
+
+// AdminUsage godoc
+// @Summary Get All Tenants Usage Report
+// @Description the usage report of all tenants, or of the given tenant
+// @Tags Report
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param tenant query string false "Tenant UUID"
+// @Param from query string false "RFC3339, inclusive start of the range"
+// @Param to query string false "RFC3339, exclusive end of the range, now by default"
+// @Param granularity query string false "`day` (default) or `hour`"
+// @Param channel query string false "one of the configured channels, e.g. `event.prod`"
+// @Success 200 {object} meta.Response{data=report.UsageResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no tenant found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/reports/admin/usage [get]
+func (h *Handler) AdminUsage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	qry, err := meta.ReqQryParamToDomain[*AdminUsageQryRequest, domain.UsageReportReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	if qry.TenantUuid() != uuid.Nil {
+		req := domain.NewTenant()
+		req.SetUUID(qry.TenantUuid())
+
+		tenant, ucErr := h.tenantUC.GetDetails(ctx, *req)
+		if ucErr != nil {
+			return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+		}
+
+		qry.SetTenantId(tenant.ID())
+	}
+
+	res, ucErr := h.reportUC.GetUsage(ctx, qry)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(UsageResp(res)).Json()
+}
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"github.com/google/uuid"
+	"microservice/internal/domain"
+	"microservice/pkg/utils"
+	"time"
+)
+
+type UsageQryRequest struct {
+	From        string `query:"from" json:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC3339, inclusive
+	To          string `query:"to" json:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`     // RFC3339, exclusive
+	Granularity string `query:"granularity" json:"granularity" validate:"omitempty,oneof=day hour"`
+	Channel     string `query:"channel" json:"channel" validate:"omitempty,channel"`
+}
+
+func (dto *UsageQryRequest) ToDomain() domain.UsageReportReqQryParam {
+	qry := domain.NewUsageReportReqQryParam()
+	qry.SetGranularity(domain.UsageGranularity(dto.Granularity))
+	qry.SetChannel(dto.Channel)
+
+	var from, to time.Time
+	if len(dto.From) > 0 {
+		from, _ = time.Parse(time.RFC3339, dto.From)
+	}
+
+	if len(dto.To) > 0 {
+		to, _ = time.Parse(time.RFC3339, dto.To)
+	}
+
+	qry.SetRange(from, to)
+	return *qry
+}
+
+type AdminUsageQryRequest struct {
+	UsageQryRequest
+	Tenant string `query:"tenant" json:"tenant" validate:"omitempty,uuid"` // the tenant uuid, all tenants if empty
+}
+
+func (dto *AdminUsageQryRequest) ToDomain() domain.UsageReportReqQryParam {
+	qry := dto.UsageQryRequest.ToDomain()
+	if len(dto.Tenant) > 0 {
+		qry.SetTenantUuid(uuid.MustParse(dto.Tenant))
+	}
+
+	return qry
+}
+
+type (
+	UsageBucketResponse struct {
+		At                string                      `json:"at" example:"2025-10-01T00:00:00Z"`
+		Statuses          map[string]int64            `json:"statuses"`
+		Channels          map[string]map[string]int64 `json:"channels"`
+		CreditSpent       float64                     `json:"creditSpent" example:"12.5"`
+		AvgQueuedToSentMs int64                       `json:"avgQueuedToSentMs" example:"850"`
+		Clicks            int64                       `json:"clicks" example:"42"`
+	}
+
+	UsageResponse struct {
+		From        string                `json:"from" example:"2025-10-01T00:00:00Z"`
+		To          string                `json:"to" example:"2025-10-08T00:00:00Z"`
+		Granularity string                `json:"granularity" example:"day"`
+		Total       UsageBucketResponse   `json:"total"`
+		Buckets     []UsageBucketResponse `json:"buckets"`
+	}
+)
+
+func UsageBucketResp(src domain.UsageBucket) UsageBucketResponse {
+	return UsageBucketResponse{
+		At:                src.At().Format(time.RFC3339),
+		Statuses:          src.Statuses(),
+		Channels:          src.Channels(),
+		CreditSpent:       utils.RoundToPrecision(src.Credit(), 4),
+		AvgQueuedToSentMs: src.AvgQueuedToSent().Milliseconds(),
+		Clicks:            src.Clicks(),
+	}
+}
+
+func UsageResp(src domain.UsageReport) UsageResponse {
+	resp := UsageResponse{
+		From:        src.From().Format(time.RFC3339),
+		To:          src.To().Format(time.RFC3339),
+		Granularity: string(src.Granularity()),
+		Total:       UsageBucketResp(src.Total()),
+		Buckets:     make([]UsageBucketResponse, 0, len(src.Buckets())),
+	}
+
+	for _, bucket := range src.Buckets() {
+		resp.Buckets = append(resp.Buckets, UsageBucketResp(bucket))
+	}
+
+	return resp
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"context"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"time"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.IReportRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+// Increment adds the usage to its bucket, the bucket is created by the first usage
+func (r *Repository) Increment(ctx context.Context, ent domain.UsageRollup) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.UsageRollups{}).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "bucket_at"}, {Name: "channel"}, {Name: "status"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"messages":      gorm.Expr("usage_rollups.messages + EXCLUDED.messages"),
+				"credit_spent":  gorm.Expr("usage_rollups.credit_spent + EXCLUDED.credit_spent"),
+				"latency_ms":    gorm.Expr("usage_rollups.latency_ms + EXCLUDED.latency_ms"),
+				"latency_count": gorm.Expr("usage_rollups.latency_count + EXCLUDED.latency_count"),
+				"clicks":        gorm.Expr("usage_rollups.clicks + EXCLUDED.clicks"),
+				"updated_at":    gorm.Expr("CURRENT_TIMESTAMP"),
+			}),
+		}).
+		Create(&m)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("report.repo.usage.increment", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+// GetUsage aggregates the hourly buckets by the report granularity
+func (r *Repository) GetUsage(ctx context.Context, ent domain.UsageReportReqQryParam) (res domain.UsageReport, err error) {
+	report := domain.NewUsageReport(ent)
+
+	var models []model.UsageRollups
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.UsageRollups{}).
+		Select(`date_trunc(?, bucket_at) AS bucket_at, channel, status,
+			SUM(messages) AS messages, SUM(credit_spent) AS credit_spent,
+			SUM(latency_ms) AS latency_ms, SUM(latency_count) AS latency_count, SUM(clicks) AS clicks`, string(ent.Granularity())).
+		Where("bucket_at >= ? AND bucket_at < ?", ent.From(), ent.To())
+
+	if ent.TenantId() != 0 {
+		tx.Where("tenant_id = ?", ent.TenantId())
+	}
+
+	if len(ent.Channel()) > 0 {
+		tx.Where("channel = ?", ent.Channel())
+	}
+
+	items := tx.Group("1, channel, status").Order("1").Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("report.repo.usage", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	report.ListFromDB(models)
+	res = *report
+	return
+}
+
+// GetMonthlyVolume the count of the messages of the tenant queued to the providers since the start of the month
+func (r *Repository) GetMonthlyVolume(ctx context.Context, tenantId uint, month time.Time) (res int64, err error) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.UsageRollups{}).
+		Select("COALESCE(SUM(messages), 0)").
+		Where("tenant_id = ? AND status = ?", tenantId, domain.MsgQueued).
+		Where("bucket_at >= ? AND bucket_at < ?", from, from.AddDate(0, 1, 0)).
+		Scan(&res)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("report.repo.usage.volume", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
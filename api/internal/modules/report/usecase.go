@@ -0,0 +1,54 @@
+package report
+
+import (
+	"context"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale     locale.ILocale
+		Tracer     trace.ITracer
+		Logger     logger.ILogger
+		ReportRepo port.IReportRepository
+	}
+
+	Usecase struct {
+		l          locale.ILocale
+		trc        trace.ITracer
+		lgr        logger.ILogger
+		reportRepo port.IReportRepository
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.IReportUsecase {
+	return &Usecase{
+		l:          fx.Locale,
+		trc:        fx.Tracer,
+		lgr:        fx.Logger,
+		reportRepo: fx.ReportRepo,
+	}
+}
+
+// GetUsage the usage is read from the rollups, the zero tenant id reports all tenants
+func (uc *Usecase) GetUsage(ctx context.Context, ent domain.UsageReportReqQryParam) (res domain.UsageReport, err error) {
+	if !ent.IsValid() {
+		err = meta.Validate.SetErr(uc.l.Get("report_range_invalid"))
+		return
+	}
+
+	res, txErr := uc.reportRepo.GetUsage(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,245 @@
+package sender
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+)
+
+type (
+	ISenderHttpHandler interface {
+		Create(c echo.Context) error
+		Delete(c echo.Context) error
+		List(c echo.Context) error
+		Review(c echo.Context) error
+		AdminList(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale   locale.ILocale
+		Tracer   trace.ITracer
+		Logger   logger.ILogger
+		Metric   metric.IMetric
+		TenantUC port.ITenantUsecase
+		SenderUC port.ISenderUsecase
+	}
+
+	Handler struct {
+		l        locale.ILocale
+		trc      trace.ITracer
+		lgr      logger.ILogger
+		metric   metric.IMetric
+		tenantUC port.ITenantUsecase
+		senderUC port.ISenderUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) ISenderHttpHandler {
+	return &Handler{
+		l:        fx.Locale,
+		trc:      fx.Tracer,
+		lgr:      fx.Logger,
+		metric:   fx.Metric,
+		tenantUC: fx.TenantUC,
+		senderUC: fx.SenderUC,
+	}
+}
+
+// Create godoc
+// @Summary Register Sender
+// @Description the sender id or the dedicated line number is usable as the message `from` after the admin approval
+// @Tags Sender
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body sender.CreateRequest true "necessary fields for request"
+// @Success 201 {object} meta.Response{data=sender.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "not found"
+// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/sender/create [post]
+func (h *Handler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	sender, err := meta.ReqBodyToDomain[*CreateRequest, domain.Sender](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	sender.SetTenantID(tenant.ID())
+
+	res, ucErr := h.senderUC.Create(ctx, sender)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(DetailsResp(res)).Json()
+}
+
+// Delete godoc
+// @Summary Delete Sender
+// @Tags Sender
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Sender UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no sender found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/sender/{uuid} [delete]
+func (h *Handler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	sender, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.Sender](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	sender.SetTenantID(tenant.ID())
+
+	if ucErr = h.senderUC.Delete(ctx, sender); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
+
+// List godoc
+// @Summary Get Tenant Sender List
+// @Tags Sender
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, sender, status, created_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Sender"
+// @Param status query string false "`pending`, `approved` or `rejected`"
+// @Success 200 {object} meta.Response{data=sender.ListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/sender/list [get]
+func (h *Handler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	list, err := meta.ReqQryParamToDomain[*ListQryRequest, domain.SenderListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	list.SetTenantId(tenant.ID())
+
+	res, err := h.senderUC.GetList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ListResp(list, res)).Json()
+}
+
+// Review godoc
+// @Summary Review Sender
+// @Description the admin approves or rejects the tenant sender. only the approved senders are accepted as the message `from`
+// @Tags Sender
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param uuid path string true "Sender UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Param Request body sender.ReviewRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=sender.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	404 {object} meta.Response{data=nil} "no sender found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/sender/admin/{uuid}/review [put]
+func (h *Handler) Review(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqBodyToDomain[*ReviewRequest, domain.Sender](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.senderUC.Review(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
+// AdminList godoc
+// @Summary Get All Tenants Sender List
+// @Description the review queue of the admin, filtered by `status=pending`
+// @Tags Sender
+// @Accept json
+// @Produce json
+// @Param X.ADMIN.TOKEN header string true "Admin Token"
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, sender, status, created_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Sender"
+// @Param status query string false "`pending`, `approved` or `rejected`"
+// @Success 200 {object} meta.Response{data=sender.ListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	401 {object} meta.Response{data=nil} "invalid admin token"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/sender/admin/list [get]
+func (h *Handler) AdminList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	list, err := meta.ReqQryParamToDomain[*ListQryRequest, domain.SenderListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, err := h.senderUC.GetList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ListResp(list, res)).Json()
+}
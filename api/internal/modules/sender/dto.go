@@ -0,0 +1,114 @@
+package sender
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"time"
+)
+
+type CreateRequest struct {
+	Sender      string `json:"sender" validate:"required,alphanum,min=3,max=32" example:"98100020003000"` // the sender id or the line number
+	Description string `json:"description" validate:"omitempty,fa_alphanum,max=255" example:"the marketing line"`
+}
+
+func (dto *CreateRequest) ToDomain() domain.Sender {
+	d := domain.NewSender()
+	d.SetSender(dto.Sender)
+	d.SetDescription(dto.Description)
+	return *d
+}
+
+type DetailsResponse struct {
+	Uuid        string `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	TenantUuid  string `json:"tenantUuid,omitempty" example:"f81eee2d-2cca-4169-8062-7404a78d5c3b"`
+	Sender      string `json:"sender" example:"98100020003000"`
+	Status      string `json:"status" example:"pending"` // `pending`, `approved` or `rejected`
+	Description string `json:"description,omitempty" example:"the marketing line"`
+	ReviewedAt  string `json:"reviewedAt,omitempty" example:"2025-10-01T06:00:00Z"`
+	CreatedAt   string `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+}
+
+func DetailsResp(src domain.Sender) DetailsResponse {
+	resp := DetailsResponse{
+		Uuid:        src.UUID().String(),
+		Sender:      src.Sender(),
+		Status:      src.Status(),
+		Description: src.Description(),
+		CreatedAt:   src.CreatedAt().Format(time.RFC3339),
+	}
+
+	if tenant := src.Tenant(); tenant.ID() != 0 {
+		resp.TenantUuid = tenant.UUID().String()
+	}
+
+	if !src.ReviewedAt().IsZero() {
+		resp.ReviewedAt = src.ReviewedAt().Format(time.RFC3339)
+	}
+
+	return resp
+}
+
+//
+
+type DetailsRequest struct {
+	Uuid string `json:"uuid" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+}
+
+func (dto *DetailsRequest) ToDomain() domain.Sender {
+	d := domain.NewSender()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return *d
+}
+
+//
+
+type ReviewRequest struct {
+	Uuid   string `json:"-" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Status string `json:"status" validate:"required,oneof=approved rejected" example:"approved"`
+}
+
+func (dto *ReviewRequest) ToDomain() domain.Sender {
+	d := domain.NewSender()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	d.SetStatus(dto.Status)
+	return *d
+}
+
+//
+
+type ListQryRequest struct {
+	dto.ListQryRequest
+	Status string `query:"status" json:"status" validate:"omitempty,oneof=pending approved rejected"`
+}
+
+func (dto *ListQryRequest) ToDomain() domain.SenderListReqQryParam {
+	qry := domain.NewSenderListReqQryParam()
+	qry.ReqBaseQryParam = dto.EvalBaseQry()
+	qry.SetStatus(dto.Status)
+
+	return *qry
+}
+
+type ListResponse struct {
+	dto.ListBaseResponse
+	Senders []DetailsResponse `json:"items"`
+}
+
+func ListResp(qry domain.SenderListReqQryParam, src domain.SenderList) ListResponse {
+	list := new(ListResponse)
+	list.Page = qry.Page()
+	list.Limit = qry.Limit()
+	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
+	list.Total = src.Total()
+	list.Senders = make([]DetailsResponse, 0)
+
+	if len(src.List()) > 0 {
+		for _, sender := range src.List() {
+			list.Senders = append(list.Senders, DetailsResp(sender))
+		}
+	}
+
+	return *list
+}
@@ -0,0 +1,260 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.ISenderRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, ent domain.Sender) (res domain.Sender, err error) {
+	m := ent.ToDB()
+	columns := []string{"uuid", clause.Associations}
+
+	if m.Status == "" {
+		columns = append(columns, "status") // the db default status
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Senders{})
+
+	txErr := tx.Omit(columns...).Clauses(clause.Returning{}).Create(&m).Error
+	if txErr != nil {
+		err = txErr
+		r.lgr.Error("sender.repo.create", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+// GetDetails the tenant sender, or any sender for the zero tenant id (admin)
+func (r *Repository) GetDetails(ctx context.Context, ent domain.Sender) (res domain.Sender, err error) {
+	m := model.NewSender()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Senders{}).Preload("Tenant").Where("uuid = ?", ent.UUID())
+
+	if ent.TenantID() != 0 {
+		tx.Where("tenant_id = ?", ent.TenantID())
+	}
+
+	u := tx.First(&m)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("sender.repo.detail", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewSender()
+	res.FromDB(*m)
+	return
+}
+
+func (r *Repository) GetApproved(ctx context.Context, tenantId uint, sender string) (res domain.Sender, err error) {
+	m := model.NewSender()
+
+	db := r.sql.TxOf(ctx)
+	u := db.WithContext(ctx).Model(&model.Senders{}).
+		First(&m, "tenant_id = ? AND sender = ? AND status = ?", tenantId, sender, domain.SenderApproved)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("sender.repo.detail.approved", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewSender()
+	res.FromDB(*m)
+	return
+}
+
+// GetApprovedLine the approved sender of any tenant, the latest approved one if the line is shared
+func (r *Repository) GetApprovedLine(ctx context.Context, sender string) (res domain.Sender, err error) {
+	m := model.NewSender()
+
+	db := r.sql.TxOf(ctx)
+	u := db.WithContext(ctx).Model(&model.Senders{}).
+		Where("sender = ? AND status = ?", sender, domain.SenderApproved).
+		Order("reviewed_at DESC, id DESC").
+		First(&m)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("sender.repo.detail.line", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewSender()
+	res.FromDB(*m)
+	return
+}
+
+// UpdateStatus sets the review result of the sender
+func (r *Repository) UpdateStatus(ctx context.Context, ent domain.Sender) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Senders{}).
+		Where("uuid = ?", ent.UUID()).
+		Updates(map[string]interface{}{
+			"status":      m.Status,
+			"reviewed_at": m.ReviewedAt,
+		})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("sender.repo.update.status", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+func (r *Repository) Delete(ctx context.Context, ent domain.Sender) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Where("uuid = ? AND tenant_id = ?", ent.UUID(), ent.TenantID()).Delete(&model.Senders{})
+	if err = tx.Error; err != nil {
+		r.lgr.Error("sender.repo.delete", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetList(ctx context.Context, ent domain.SenderListReqQryParam) (res domain.SenderList, err error) {
+	list := domain.NewSenderList()
+
+	var (
+		models []model.Senders
+		total  int64
+	)
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Senders{})
+
+	if ent.TenantId() != 0 {
+		tx.Where("tenant_id = ?", ent.TenantId())
+	} else {
+		tx.Preload("Tenant") // the admin list
+	}
+
+	if len(ent.Status()) > 0 {
+		tx.Where("status = ?", ent.Status())
+	}
+
+	if ent.Items() != nil && len(ent.Items()) > 0 {
+		tx.Where("uuid IN ?", ent.Items()) // get all items
+	}
+
+	if len(ent.Search()) > 0 {
+		val := fmt.Sprintf("%%%s%%", ent.Search()) // this returns %search_value%
+		tx.Where("sender ILIKE ?", val)
+	}
+
+	//
+
+	if err = tx.Count(&total).Error; err != nil {
+		r.lgr.Error("sender.repo.list.count", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	list.SetTotal(total)
+
+	//
+
+	if ent.Items() == nil {
+		tx.Offset(ent.Offset()).Limit(ent.Limit())
+	}
+
+	items := tx.Order(ent.SortOrder()).Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("sender.repo.list", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if items.RowsAffected > 0 {
+		list.ListFromDB(models)
+	}
+
+	res = *list
+	return
+}
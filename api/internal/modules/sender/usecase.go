@@ -0,0 +1,93 @@
+package sender
+
+import (
+	"context"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"time"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale     locale.ILocale
+		Tracer     trace.ITracer
+		Logger     logger.ILogger
+		Tx         orm.ISqlTx
+		SenderRepo port.ISenderRepository
+	}
+
+	Usecase struct {
+		l          locale.ILocale
+		trc        trace.ITracer
+		lgr        logger.ILogger
+		tx         orm.ISqlTx
+		senderRepo port.ISenderRepository
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.ISenderUsecase {
+	return &Usecase{
+		l:          fx.Locale,
+		trc:        fx.Tracer,
+		lgr:        fx.Logger,
+		tx:         fx.Tx,
+		senderRepo: fx.SenderRepo,
+	}
+}
+
+// Create the registered sender is pending until the admin review
+func (uc *Usecase) Create(ctx context.Context, ent domain.Sender) (res domain.Sender, err error) {
+	ent.SetStatus(string(domain.SenderPending))
+
+	res, txErr := uc.senderRepo.Create(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+// Review approves or rejects the sender. the rejected senders can be approved later and vice versa
+func (uc *Usecase) Review(ctx context.Context, ent domain.Sender) (res domain.Sender, err error) {
+	ent.SetReviewedAt(time.Now().UTC())
+
+	if txErr := uc.senderRepo.UpdateStatus(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	res, txErr := uc.senderRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) Delete(ctx context.Context, ent domain.Sender) (err error) {
+	if txErr := uc.senderRepo.Delete(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) GetList(ctx context.Context, ent domain.SenderListReqQryParam) (res domain.SenderList, err error) {
+	res, txErr := uc.senderRepo.GetList(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
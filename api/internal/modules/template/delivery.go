@@ -0,0 +1,263 @@
+package template
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/metric"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"microservice/pkg/meta/status"
+)
+
+type (
+	ITemplateHttpHandler interface {
+		Create(c echo.Context) error
+		Details(c echo.Context) error
+		Update(c echo.Context) error
+		Delete(c echo.Context) error
+		List(c echo.Context) error
+	}
+
+	HandlerFx struct {
+		fx.In
+		Locale     locale.ILocale
+		Tracer     trace.ITracer
+		Logger     logger.ILogger
+		Metric     metric.IMetric
+		TenantUC   port.ITenantUsecase
+		TemplateUC port.ITemplateUsecase
+	}
+
+	Handler struct {
+		l          locale.ILocale
+		trc        trace.ITracer
+		lgr        logger.ILogger
+		metric     metric.IMetric
+		tenantUC   port.ITenantUsecase
+		templateUC port.ITemplateUsecase
+	}
+)
+
+func NewHttpHandlerFx(fx HandlerFx) ITemplateHttpHandler {
+	return &Handler{
+		l:          fx.Locale,
+		trc:        fx.Tracer,
+		lgr:        fx.Logger,
+		metric:     fx.Metric,
+		tenantUC:   fx.TenantUC,
+		templateUC: fx.TemplateUC,
+	}
+}
+
+// Create godoc
+// @Summary Create New Message Template
+// @Description the body placeholders are written like `{{code}}` and filled by the `variables` of the message send request
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body template.CreateRequest true "necessary fields for request"
+// @Success 201 {object} meta.Response{data=template.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "not found"
+// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/template/create [post]
+func (h *Handler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	template, err := meta.ReqBodyToDomain[*CreateRequest, domain.Template](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	template.SetTenantID(tenant.ID())
+
+	res, ucErr := h.templateUC.Create(ctx, template)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Created).Data(DetailsResp(res)).Json()
+}
+
+// Details godoc
+// @Summary Get Message Template Details
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Template UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{data=template.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no template found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/template/{uuid} [get]
+func (h *Handler) Details(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	template, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.Template](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	template.SetTenantID(tenant.ID())
+
+	res, ucErr := h.templateUC.GetDetails(ctx, template)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
+// Update godoc
+// @Summary Update Message Template
+// @Description the empty fields are kept unchanged
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Template UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Param Request body template.UpdateRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=template.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no template found"
+// @Failure	409 {object} meta.Response{data=nil} "already exists"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/template/{uuid} [put]
+func (h *Handler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	template, err := meta.ReqBodyToDomain[*UpdateRequest, domain.Template](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	template.SetTenantID(tenant.ID())
+
+	res, ucErr := h.templateUC.Update(ctx, template)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
+// Delete godoc
+// @Summary Delete Message Template
+// @Description the sent messages keep their reference to the deleted template
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param uuid path string true "Template UUID" example(bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a)
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no template found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/template/{uuid} [delete]
+func (h *Handler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	template, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.Template](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	template.SetTenantID(tenant.ID())
+
+	if ucErr = h.templateUC.Delete(ctx, template); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
+
+// List godoc
+// @Summary Get Message Template List
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param X.TENANT.UUID header string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param page query int false "Page Number"
+// @Param limit query int false "Page Limit"
+// @Param sort query string false "id, name, created_at, updated_at\n(other valid columns are acceptable)"
+// @Param order query string false "`asc` or `desc`"
+// @Param search query string false "Search the Template Name and Body"
+// @Success 200 {object} meta.Response{data=template.ListResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	422 {object} meta.Response{data=nil} "database error while retrieving"
+// @Router /api/v1/template/list [get]
+func (h *Handler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqHeaderToDomain[*dto.TenantUuid, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	list, err := meta.ReqQryParamToDomain[*ListQryRequest, domain.TemplateListReqQryParam](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	tenant, ucErr := h.tenantUC.GetDetails(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	list.SetTenantId(tenant.ID())
+
+	res, err := h.templateUC.GetList(ctx, list)
+	if err != nil {
+		return meta.Resp(c, h.l).Status(status.Failed).Err(err).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(ListResp(list, res)).Json()
+}
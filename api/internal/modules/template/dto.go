@@ -0,0 +1,116 @@
+package template
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"microservice/internal/domain"
+	"microservice/internal/modules/dto"
+	"time"
+)
+
+type CreateRequest struct {
+	Name string `json:"name" validate:"required,alphanum,max=64" example:"otp"`
+	Body string `json:"body" validate:"required,fa_template,max=1000" example:"Your code is {{code}}"`
+}
+
+func (dto *CreateRequest) ToDomain() domain.Template {
+	d := domain.NewTemplate()
+	d.SetName(dto.Name)
+	d.SetBody(dto.Body)
+	return *d
+}
+
+//
+
+type DetailsRequest struct {
+	Uuid string `json:"uuid" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+}
+
+func (dto *DetailsRequest) ToDomain() domain.Template {
+	d := domain.NewTemplate()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	return *d
+}
+
+type DetailsResponse struct {
+	Uuid         string   `json:"uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Name         string   `json:"name" example:"otp"`
+	Body         string   `json:"body" example:"Your code is {{code}}"`
+	Placeholders []string `json:"placeholders" example:"code"`
+	CreatedAt    string   `json:"createdAt" example:"2025-10-01T05:00:00Z"`
+	UpdatedAt    string   `json:"updatedAt" example:"2025-10-01T05:00:00Z"`
+}
+
+func DetailsResp(src domain.Template) DetailsResponse {
+	return DetailsResponse{
+		Uuid:         src.UUID().String(),
+		Name:         src.Name(),
+		Body:         src.Body(),
+		Placeholders: src.Placeholders(),
+		CreatedAt:    src.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:    src.UpdatedAt().Format(time.RFC3339),
+	}
+}
+
+//
+
+type UpdateRequest struct {
+	Uuid string `json:"-" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Name string `json:"name" validate:"omitempty,alphanum,max=64" example:"otp"`
+	Body string `json:"body" validate:"omitempty,fa_template,max=1000" example:"Your verification code is {{code}}"`
+}
+
+func (dto *UpdateRequest) ToDomain() domain.Template {
+	d := domain.NewTemplate()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	d.SetName(dto.Name)
+	d.SetBody(dto.Body)
+	return *d
+}
+
+//
+
+type ListQryRequest struct {
+	dto.ListQryRequest
+}
+
+func (dto *ListQryRequest) ToDomain() domain.TemplateListReqQryParam {
+	qry := domain.NewTemplateListReqQryParam()
+	qry.ReqBaseQryParam = dto.EvalBaseQry()
+
+	return *qry
+}
+
+type (
+	ListItemDetail struct {
+		Uuid string `json:"uuid" example:"67f5627c-2d71-48f0-8afc-b7bed370bb45"`
+		Name string `json:"name" example:"otp"`
+		Body string `json:"body" example:"Your code is {{code}}"`
+	}
+
+	ListResponse struct {
+		dto.ListBaseResponse
+		Templates []ListItemDetail `json:"items"`
+	}
+)
+
+func ListResp(qry domain.TemplateListReqQryParam, src domain.TemplateList) ListResponse {
+	list := new(ListResponse)
+	list.Page = qry.Page()
+	list.Limit = qry.Limit()
+	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
+	list.Total = src.Total()
+	list.Templates = make([]ListItemDetail, 0)
+
+	if len(src.List()) > 0 {
+		for _, template := range src.List() {
+			list.Templates = append(list.Templates, ListItemDetail{
+				Uuid: template.UUID().String(),
+				Name: template.Name(),
+				Body: template.Body(),
+			})
+		}
+	}
+
+	return *list
+}
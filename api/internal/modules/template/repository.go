@@ -0,0 +1,205 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.ITemplateRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, ent domain.Template) (res domain.Template, err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Templates{})
+
+	txErr := tx.Omit("uuid").Clauses(clause.Returning{}).Create(&m).Error
+	if txErr != nil {
+		err = txErr
+		r.lgr.Error("template.repo.create", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+func (r *Repository) GetDetails(ctx context.Context, ent domain.Template) (res domain.Template, err error) {
+	m := model.NewTemplate()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Templates{})
+
+	u := tx.First(&m, "uuid = ? AND tenant_id = ?", ent.UUID(), ent.TenantID())
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("template.repo.detail", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewTemplate()
+	res.FromDB(*m)
+	return
+}
+
+func (r *Repository) Update(ctx context.Context, ent domain.Template) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Templates{}).
+		Omit("uuid", "tenant_id", "created_at", "deleted_at").
+		Where("uuid = ? AND tenant_id = ?", ent.UUID(), ent.TenantID()).
+		Updates(m)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("template.repo.update", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+func (r *Repository) Delete(ctx context.Context, ent domain.Template) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Where("uuid = ? AND tenant_id = ?", ent.UUID(), ent.TenantID()).Delete(&model.Templates{})
+	if err = tx.Error; err != nil {
+		r.lgr.Error("template.repo.delete", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetList(ctx context.Context, ent domain.TemplateListReqQryParam) (res domain.TemplateList, err error) {
+	defer func() {
+		if err != nil {
+			r.lgr.Error("template.repo.list", zap.Error(err))
+		}
+	}()
+
+	list := domain.NewTemplateList()
+
+	var (
+		models []model.Templates
+		total  int64
+	)
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Templates{})
+
+	tx.Where("tenant_id = ?", ent.TenantId())
+
+	if ent.Items() != nil && len(ent.Items()) > 0 {
+		tx.Where("uuid IN ?", ent.Items()) // get all items
+	}
+
+	if len(ent.Search()) > 0 {
+		val := fmt.Sprintf("%%%s%%", ent.Search()) // this returns %search_value%
+		tx.Where("name ILIKE ? OR body ILIKE ?", val, val)
+	}
+
+	//
+
+	if err = tx.Count(&total).Error; err != nil {
+		r.lgr.Error("template.repo.list.count", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	list.SetTotal(total)
+
+	//
+
+	if ent.Items() == nil {
+		tx.Offset(ent.Offset()).Limit(ent.Limit())
+	}
+
+	items := tx.Order(ent.SortOrder()).Find(&models)
+	if err = items.Error; err != nil {
+		r.lgr.Error("template.repo.list", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if items.RowsAffected > 0 {
+		list.ListFromDB(models)
+	}
+
+	res = *list
+	return
+}
@@ -0,0 +1,100 @@
+package template
+
+import (
+	"context"
+	"go.uber.org/fx"
+	"microservice/internal/adapter/cache"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+)
+
+type (
+	UsecaseFx struct {
+		fx.In
+		Locale       locale.ILocale
+		Tracer       trace.ITracer
+		Logger       logger.ILogger
+		Cache        cache.ICache
+		Tx           orm.ISqlTx
+		TemplateRepo port.ITemplateRepository
+	}
+
+	Usecase struct {
+		l            locale.ILocale
+		trc          trace.ITracer
+		lgr          logger.ILogger
+		cache        cache.ICache
+		tx           orm.ISqlTx
+		templateRepo port.ITemplateRepository
+	}
+)
+
+func NewUsecaseFx(fx UsecaseFx) port.ITemplateUsecase {
+	return &Usecase{
+		l:            fx.Locale,
+		trc:          fx.Tracer,
+		lgr:          fx.Logger,
+		cache:        fx.Cache,
+		tx:           fx.Tx,
+		templateRepo: fx.TemplateRepo,
+	}
+}
+
+func (uc *Usecase) Create(ctx context.Context, ent domain.Template) (res domain.Template, err error) {
+	res, txErr := uc.templateRepo.Create(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) GetDetails(ctx context.Context, ent domain.Template) (res domain.Template, err error) {
+	res, txErr := uc.templateRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) Update(ctx context.Context, ent domain.Template) (res domain.Template, err error) {
+	if txErr := uc.templateRepo.Update(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	res, txErr := uc.templateRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) Delete(ctx context.Context, ent domain.Template) (err error) {
+	if txErr := uc.templateRepo.Delete(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) GetList(ctx context.Context, ent domain.TemplateListReqQryParam) (res domain.TemplateList, err error) {
+	res, txErr := uc.templateRepo.GetList(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
@@ -18,6 +18,10 @@ type (
 	ITenantHttpHandler interface {
 		Create(c echo.Context) error
 		Details(c echo.Context) error
+		SetWebhook(c echo.Context) error
+		RemoveWebhook(c echo.Context) error
+		SetDedupWindow(c echo.Context) error
+		SetQuietHours(c echo.Context) error
 		List(c echo.Context) error
 	}
 
@@ -107,6 +111,121 @@ func (h *Handler) Details(c echo.Context) error {
 	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(details)).Json()
 }
 
+// SetWebhook godoc
+// @Summary Set Tenant Webhook
+// @Description the message status changes are posted to the url. the body is signed by HMAC-SHA256 of `{timestamp}.{body}` with the returned secret, sent in the `X.WEBHOOK.SIGNATURE` and `X.WEBHOOK.TIMESTAMP` headers. a new secret is generated on every update. the url has to be https and resolve to the public addresses only
+// @Tags Tenant
+// @Accept json
+// @Produce json
+// @Param uuid path string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body tenant.WebhookRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=tenant.WebhookResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no Tenant found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/tenant/{uuid}/webhook [put]
+func (h *Handler) SetWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqBodyToDomain[*WebhookRequest, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.tenantUC.SetWebhook(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(WebhookResp(res)).Json()
+}
+
+// RemoveWebhook godoc
+// @Summary Remove Tenant Webhook
+// @Tags Tenant
+// @Accept json
+// @Produce json
+// @Param uuid path string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Success 200 {object} meta.Response{error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no Tenant found"
+// @Failure	422 {object} meta.Response{data=nil} "invalid data types"
+// @Router /api/v1/tenant/{uuid}/webhook [delete]
+func (h *Handler) RemoveWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqRouteParamsToDomain[*DetailsRequest, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	if ucErr := h.tenantUC.RemoveWebhook(ctx, req); ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Json()
+}
+
+// SetDedupWindow godoc
+// @Summary Set Tenant Deduplication Window
+// @Description the same message to the same mobile is rejected within the window. `0` disables the deduplication
+// @Tags Tenant
+// @Accept json
+// @Produce json
+// @Param uuid path string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body tenant.DedupRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=tenant.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no Tenant found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/tenant/{uuid}/dedup [put]
+func (h *Handler) SetDedupWindow(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqBodyToDomain[*DedupRequest, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.tenantUC.SetDedupWindow(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
+// SetQuietHours godoc
+// @Summary Set Tenant Quiet Hours
+// @Description the non-urgent channel messages arriving in the quiet hours are held and sent when the window opens, the
+// @Description urgent channel messages are not affected. the window may pass midnight, the empty values reset it to
+// @Description the service defaults (22:00 to 08:00 in the service timezone)
+// @Tags Tenant
+// @Accept json
+// @Produce json
+// @Param uuid path string true "Tenant UUID" example(f81eee2d-2cca-4169-8062-7404a78d5c3b)
+// @Param Request body tenant.QuietHoursRequest true "necessary fields for request"
+// @Success 200 {object} meta.Response{data=tenant.DetailsResponse, error=nil} "success response"
+// @Failure	400 {object} meta.Response{data=nil} "process failure"
+// @Failure	404 {object} meta.Response{data=nil} "no Tenant found"
+// @Failure	422 {object} meta.Response{data=nil} "unprocessable"
+// @Router /api/v1/tenant/{uuid}/quiet-hours [put]
+func (h *Handler) SetQuietHours(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req, err := meta.ReqBodyToDomain[*QuietHoursRequest, domain.Tenant](c)
+	if err != nil {
+		return meta.Resp(c, h.l).ServiceErr(err).Json()
+	}
+
+	res, ucErr := h.tenantUC.SetQuietHours(ctx, req)
+	if ucErr != nil {
+		return meta.Resp(c, h.l).ServiceErr(ucErr).Json()
+	}
+
+	return meta.Resp(c, h.l).Status(status.Success).Data(DetailsResp(res)).Json()
+}
+
 // List godoc
 // @Summary Get Tenant List
 // @Tags Tenant
@@ -116,6 +235,8 @@ func (h *Handler) Details(c echo.Context) error {
 // @Param limit query int false "Page Limit"
 // @Param sort query string false "id, username, tenant_name, created_at, updated_at\n(other valid columns are acceptable)"
 // @Param order query string false "`asc` or `desc`"
+// @Param cursor query string false "the `nextCursor` of the former page, replaces the page and sorts by `created_at`"
+// @Param skipTotal query bool false "skips counting the `total` and `pages`"
 // @Param search query string false "Search the Tenant Username and Name"
 // @Success 200 {object} meta.Response{data=tenant.ListResponse, error=nil} "success response"
 // @Failure	400 {object} meta.Response{data=nil} "process failure"
@@ -2,9 +2,9 @@ package tenant
 
 import (
 	"github.com/google/uuid"
-	"math"
 	"microservice/internal/domain"
 	"microservice/internal/modules/dto"
+	"time"
 )
 
 type CreateRequest struct {
@@ -55,12 +55,20 @@ type (
 	Credit struct {
 		Balance float64 `json:"balance" example:"10.0000"`
 	}
+	QuietHours struct {
+		Start    string `json:"start,omitempty" example:"22:00"`
+		End      string `json:"end,omitempty" example:"08:00"`
+		Timezone string `json:"timezone,omitempty" example:"Asia/Tehran"`
+	}
 	DetailsResponse struct {
-		Uuid       string `json:"uuid"  example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
-		Username   string `json:"username"  example:"dummyUsername"`
-		TenantName string `json:"tenantName"  example:"Jack"`
-		Active     bool   `json:"active"  example:"true"`
-		Credit     Credit `json:"credit"`
+		Uuid       string     `json:"uuid"  example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+		Username   string     `json:"username"  example:"dummyUsername"`
+		TenantName string     `json:"tenantName"  example:"Jack"`
+		Active     bool       `json:"active"  example:"true"`
+		WebhookUrl string     `json:"webhookUrl,omitempty" example:"https://example.com/sms/callback"`
+		DedupSec   int        `json:"dedupWindowSec" example:"60"`
+		QuietHours QuietHours `json:"quietHours"` // the empty values are the service defaults
+		Credit     Credit     `json:"credit"`
 	}
 )
 
@@ -70,6 +78,13 @@ func DetailsResp(src domain.Tenant) DetailsResponse {
 		Username:   src.Username(),
 		TenantName: src.TenantName(),
 		Active:     src.Active(),
+		WebhookUrl: src.Webhook().Url(),
+		DedupSec:   int(src.DedupWindow().Seconds()),
+		QuietHours: QuietHours{
+			Start:    src.QuietHours().Start(),
+			End:      src.QuietHours().End(),
+			Timezone: src.QuietHours().Timezone(),
+		},
 	}
 
 	if credit := src.Credit(); credit.ID() != 0 {
@@ -81,8 +96,64 @@ func DetailsResp(src domain.Tenant) DetailsResponse {
 
 //
 
+type WebhookRequest struct {
+	Uuid string `json:"-" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Url  string `json:"url" validate:"required,url,startswith=https://,max=2048" example:"https://example.com/sms/callback"`
+}
+
+func (dto *WebhookRequest) ToDomain() domain.Tenant {
+	d := domain.NewTenant()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	d.SetWebhook(domain.NewTenantWebhook(dto.Url, ""))
+	return *d
+}
+
+type WebhookResponse struct {
+	Url    string `json:"url" example:"https://example.com/sms/callback"`
+	Secret string `json:"secret" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+func WebhookResp(src domain.Tenant) WebhookResponse {
+	return WebhookResponse{
+		Url:    src.Webhook().Url(),
+		Secret: src.Webhook().Secret(),
+	}
+}
+
+//
+
+type DedupRequest struct {
+	Uuid      string `json:"-" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	WindowSec *int   `json:"windowSec" validate:"required,min=0,max=86400" example:"300"` // 0 disables the deduplication
+}
+
+func (dto *DedupRequest) ToDomain() domain.Tenant {
+	d := domain.NewTenant()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	d.SetDedupWindow(time.Duration(*dto.WindowSec) * time.Second)
+	return *d
+}
+
+//
+
+type QuietHoursRequest struct {
+	Uuid     string `json:"-" param:"uuid" validate:"required,uuid" example:"bf56c6b6-dd02-47ba-8dc4-bd7d2843a77a"`
+	Start    string `json:"start" validate:"required_with=End,omitempty,datetime=15:04" example:"22:00"`
+	End      string `json:"end" validate:"required_with=Start,omitempty,datetime=15:04,nefield=Start" example:"08:00"`
+	Timezone string `json:"timezone" validate:"omitempty,timezone" example:"Asia/Tehran"` // IANA timezone, the service timezone if empty
+}
+
+func (dto *QuietHoursRequest) ToDomain() domain.Tenant {
+	d := domain.NewTenant()
+	d.SetUUID(uuid.MustParse(dto.Uuid))
+	d.SetQuietHours(domain.NewTenantQuietHours(dto.Start, dto.End, dto.Timezone))
+	return *d
+}
+
+//
+
 type ListQryRequest struct {
-	dto.ListQryRequest
+	dto.CursorListQryRequest
 }
 
 func (dto *ListQryRequest) ToDomain() domain.TenantListReqQryParam {
@@ -107,10 +178,7 @@ type (
 
 func ListResp(qry domain.TenantListReqQryParam, src domain.TenantList) ListResponse {
 	list := new(ListResponse)
-	list.Page = qry.Page()
-	list.Limit = qry.Limit()
-	list.Pages = int(math.Ceil(float64(src.Total()) / float64(qry.Limit())))
-	list.Total = src.Total()
+	list.ListBaseResponse = dto.EvalListBase(qry.ReqBaseQryParam, src.BaseList)
 	list.Tenants = make([]ListItemDetail, 0)
 
 	if len(src.List()) > 0 {
@@ -17,6 +17,7 @@ import (
 	"microservice/internal/model"
 	"microservice/internal/modules/port"
 	"microservice/pkg/meta"
+	"strconv"
 )
 
 type (
@@ -48,7 +49,7 @@ func NewRepositoryFx(fx RepositoryFx) port.ITenantRepository {
 func (r *Repository) Create(ctx context.Context, ent domain.Tenant) (res domain.Tenant, err error) {
 	m := ent.ToDB()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Tenants{})
 
 	txErr := tx.Omit("uuid", "active").Clauses(clause.Returning{}).Create(&m).Error
@@ -75,7 +76,7 @@ func (r *Repository) Create(ctx context.Context, ent domain.Tenant) (res domain.
 func (r *Repository) GetDetails(ctx context.Context, ent domain.Tenant) (res domain.Tenant, err error) {
 	m := model.NewTenant()
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Tenants{})
 
 	if ent.GetRelations() != nil {
@@ -104,6 +105,105 @@ func (r *Repository) GetDetails(ctx context.Context, ent domain.Tenant) (res dom
 	return
 }
 
+// GetByID is used by the internal processes which only know the tenant id
+func (r *Repository) GetByID(ctx context.Context, id uint) (res domain.Tenant, err error) {
+	m := model.NewTenant()
+
+	db := r.sql.TxOf(ctx)
+	u := db.WithContext(ctx).Model(&model.Tenants{}).First(&m, "id = ?", id)
+
+	if err = u.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.lgr.Error("tenant.repo.detail.id", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewTenant()
+	res.FromDB(*m)
+	return
+}
+
+// UpdateWebhook sets the tenant webhook. the empty webhook removes it
+func (r *Repository) UpdateWebhook(ctx context.Context, ent domain.Tenant) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Tenants{}).
+		Where("uuid = ?", ent.UUID()).
+		Updates(map[string]interface{}{
+			"webhook_url":    m.WebhookUrl,
+			"webhook_secret": m.WebhookSecret,
+		})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("tenant.repo.update.webhook", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+// UpdateDedupWindow sets the tenant message deduplication window
+func (r *Repository) UpdateDedupWindow(ctx context.Context, ent domain.Tenant) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Tenants{}).
+		Where("uuid = ?", ent.UUID()).
+		Update("dedup_window_sec", m.DedupWindow)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("tenant.repo.update.dedup", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
+// UpdateQuietHours sets the tenant quiet hours, the empty values reset them to the service defaults
+func (r *Repository) UpdateQuietHours(ctx context.Context, ent domain.Tenant) (err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.Tenants{}).
+		Where("uuid = ?", ent.UUID()).
+		Updates(map[string]interface{}{
+			"quiet_hours_start":    m.QuietHoursStart,
+			"quiet_hours_end":      m.QuietHoursEnd,
+			"quiet_hours_timezone": m.QuietHoursTimezone,
+		})
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("tenant.repo.update.quiet_hours", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	return
+}
+
 func (r *Repository) GetList(ctx context.Context, ent domain.TenantListReqQryParam) (res domain.TenantList, err error) {
 	defer func() {
 		if err != nil {
@@ -118,7 +218,7 @@ func (r *Repository) GetList(ctx context.Context, ent domain.TenantListReqQryPar
 		total  int64
 	)
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.Tenants{})
 
 	if ent.GetRelations() != nil {
@@ -138,18 +238,26 @@ func (r *Repository) GetList(ctx context.Context, ent domain.TenantListReqQryPar
 
 	//
 
-	if err = tx.Count(&total).Error; err != nil {
-		r.lgr.Error("tenant.repo.list.count", zap.Error(err))
-		err = meta.Failed
-		return
-	}
+	if !ent.SkipTotal() {
+		if err = tx.Count(&total).Error; err != nil {
+			r.lgr.Error("tenant.repo.list.count", zap.Error(err))
+			err = meta.Failed
+			return
+		}
 
-	list.SetTotal(total)
+		list.SetTotal(total)
+	}
 
 	//
 
 	if ent.Items() == nil {
-		tx.Offset(ent.Offset()).Limit(ent.Limit())
+		if cursor := ent.Cursor(); !cursor.IsZero() {
+			tx.Where(ent.KeysetCond(), cursor.CreatedAt(), cursor.ID())
+		} else {
+			tx.Offset(ent.Offset())
+		}
+
+		tx.Limit(ent.Limit() + 1) // the extra item reports the next page
 	}
 
 	items := tx.Order(ent.SortOrder()).Find(&models)
@@ -159,7 +267,16 @@ func (r *Repository) GetList(ctx context.Context, ent domain.TenantListReqQryPar
 		return
 	}
 
-	if items.RowsAffected > 0 {
+	if ent.Items() == nil && len(models) > ent.Limit() {
+		models = models[:ent.Limit()]
+
+		if ent.Keyset() {
+			last := models[len(models)-1]
+			list.SetNextCursor(domain.NewListCursor(last.CreatedAt, strconv.FormatUint(uint64(last.ID), 10)))
+		}
+	}
+
+	if len(models) > 0 {
 		list.ListFromDB(models)
 	}
 
@@ -12,6 +12,8 @@ import (
 	"microservice/internal/domain"
 	"microservice/internal/modules/port"
 	"microservice/pkg/meta"
+	"microservice/pkg/utils"
+	"net/url"
 )
 
 type (
@@ -49,6 +51,9 @@ func NewUsecaseFx(fx UsecaseFx) port.ITenantUsecase {
 	}
 }
 
+// WebhookSecretLength the length of the generated hex secret of the webhook signatures
+const WebhookSecretLength = 64
+
 func (uc *Usecase) Create(ctx context.Context, ent domain.Tenant) (res domain.Tenant, err error) {
 	var txErr error
 
@@ -99,6 +104,59 @@ func (uc *Usecase) GetDetails(ctx context.Context, ent domain.Tenant) (res domai
 	return
 }
 
+// SetWebhook a new signature secret is generated on every update, the previous one is not valid anymore
+func (uc *Usecase) SetWebhook(ctx context.Context, ent domain.Tenant) (res domain.Tenant, err error) {
+	tenant, txErr := uc.tenantRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	// the webhooks are posted by the server, so the internal addresses are not accepted
+	target, pErr := url.Parse(ent.Webhook().Url())
+	if pErr != nil || utils.PublicHost(ctx, target.Hostname()) != nil {
+		err = meta.Validate.SetErr(uc.l.Get("tenant_webhook_url_invalid"))
+		return
+	}
+
+	secret := utils.RandomStr(WebhookSecretLength)
+	tenant.SetWebhook(domain.NewTenantWebhook(ent.Webhook().Url(), secret))
+
+	if txErr = uc.tenantRepo.UpdateWebhook(ctx, tenant); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	res = tenant
+	return
+}
+
+func (uc *Usecase) RemoveWebhook(ctx context.Context, ent domain.Tenant) (err error) {
+	ent.SetWebhook(domain.TenantWebhook{})
+
+	if txErr := uc.tenantRepo.UpdateWebhook(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
+func (uc *Usecase) SetDedupWindow(ctx context.Context, ent domain.Tenant) (res domain.Tenant, err error) {
+	if txErr := uc.tenantRepo.UpdateDedupWindow(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	res, txErr := uc.tenantRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
+
 func (uc *Usecase) GetList(ctx context.Context, ent domain.TenantListReqQryParam) (res domain.TenantList, err error) {
 	res, txErr := uc.tenantRepo.GetList(ctx, ent)
 	if txErr != nil {
@@ -108,3 +166,18 @@ func (uc *Usecase) GetList(ctx context.Context, ent domain.TenantListReqQryParam
 
 	return
 }
+
+func (uc *Usecase) SetQuietHours(ctx context.Context, ent domain.Tenant) (res domain.Tenant, err error) {
+	if txErr := uc.tenantRepo.UpdateQuietHours(ctx, ent); txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	res, txErr := uc.tenantRepo.GetDetails(ctx, ent)
+	if txErr != nil {
+		err = meta.EvalTxErr(txErr)
+		return
+	}
+
+	return
+}
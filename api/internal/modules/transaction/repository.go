@@ -2,10 +2,12 @@ package transaction
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"microservice/internal/adapter/locale"
 	"microservice/internal/adapter/logger"
@@ -51,7 +53,7 @@ func (r *Repository) Create(ctx context.Context, ent domain.Transaction) (res do
 		columns = append(columns, "message_hash_id")
 	}
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.CreditTransactions{})
 
 	txErr := tx.Omit(columns...).Clauses(clause.Returning{}).Create(&m).Error
@@ -75,6 +77,88 @@ func (r *Repository) Create(ctx context.Context, ent domain.Transaction) (res do
 	return
 }
 
+// CreateIfAbsent keeps the existing transaction of the same id, created reports whether the transaction is inserted.
+// the conflict is not raised as an error, which would abort the transaction of the caller
+func (r *Repository) CreateIfAbsent(ctx context.Context, ent domain.Transaction) (res domain.Transaction, created bool, err error) {
+	m := ent.ToDB()
+	columns := []string{"updated_at", "deleted_at"}
+
+	if m.MessageHashID == nil {
+		columns = append(columns, "message_hash_id")
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.CreditTransactions{}).
+		Omit(columns...).
+		Clauses(clause.OnConflict{DoNothing: true}, clause.Returning{}).
+		Create(&m)
+
+	if err = tx.Error; err != nil {
+		r.lgr.Error("credit.repo.create.absent", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if tx.RowsAffected == 0 {
+		return
+	}
+
+	created = true
+	res = ent.FromDB(m)
+	return
+}
+
+func (r *Repository) CreateBatch(ctx context.Context, ents []domain.Transaction) (err error) {
+	list := domain.NewTransactionList()
+	list.SetList(ents)
+	m := list.ListToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.CreditTransactions{})
+
+	txErr := tx.Omit("updated_at", "deleted_at").CreateInBatches(&m, 500).Error
+	if txErr != nil {
+		err = txErr
+		r.lgr.Error("transaction.repo.create.batch", zap.Error(err))
+
+		var pgErr *pgconn.PgError
+		errors.As(err, &pgErr)
+
+		if pgErr != nil && pgErr.Code == "23505" { // PSQL Unique violation error code
+			err = meta.ItemExist.SetErr(pgErr.Detail)
+			return
+		}
+
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+func (r *Repository) GetByMessageHash(ctx context.Context, hash []byte, txType domain.TransactionType) (res domain.Transaction, err error) {
+	m := model.NewTransaction()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.CreditTransactions{})
+
+	u := tx.Where("message_hash_id = ? AND type = ?", hash, string(txType)).Order("created_at desc").Limit(1).Find(m)
+	if err = u.Error; err != nil {
+		r.lgr.Error("transaction.repo.detail", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	if u.RowsAffected == 0 {
+		err = meta.NotFound
+		return
+	}
+
+	res = *domain.NewTransaction()
+	res.FromDB(*m)
+	return
+}
+
 func (r *Repository) GetList(ctx context.Context, ent domain.TransactionListReqQryParam) (res domain.TransactionList, err error) {
 	defer func() {
 		if err != nil {
@@ -89,31 +173,34 @@ func (r *Repository) GetList(ctx context.Context, ent domain.TransactionListReqQ
 		total  int64
 	)
 
-	db := r.sql.Tx()
+	db := r.sql.TxOf(ctx)
 	tx := db.WithContext(ctx).Model(&model.CreditTransactions{})
 
-	if ent.RelId() != 0 {
-		tx.Where("credit_id = ?", ent.RelId()) // get all items
-	}
-
-	if ent.Items() != nil && len(ent.Items()) > 0 {
-		tx.Where("uuid IN ?", ent.Items()) // get all items
-	}
+	applyListFilters(tx, ent)
 
 	//
 
-	if err = tx.Count(&total).Error; err != nil {
-		r.lgr.Error("transaction.repo.list.count", zap.Error(err))
-		err = meta.Failed
-		return
-	}
+	if !ent.SkipTotal() {
+		if err = tx.Count(&total).Error; err != nil {
+			r.lgr.Error("transaction.repo.list.count", zap.Error(err))
+			err = meta.Failed
+			return
+		}
 
-	list.SetTotal(total)
+		list.SetTotal(total)
+	}
 
 	//
 
 	if ent.Items() == nil {
-		tx.Offset(ent.Offset()).Limit(ent.Limit())
+		if cursor := ent.Cursor(); !cursor.IsZero() {
+			cursorID, _ := hex.DecodeString(cursor.Key()) // the transaction ids are binary
+			tx.Where(ent.KeysetCond(), cursor.CreatedAt(), cursorID)
+		} else {
+			tx.Offset(ent.Offset())
+		}
+
+		tx.Limit(ent.Limit() + 1) // the extra item reports the next page
 	}
 
 	items := tx.Order(ent.SortOrder()).Find(&models)
@@ -123,10 +210,81 @@ func (r *Repository) GetList(ctx context.Context, ent domain.TransactionListReqQ
 		return
 	}
 
-	if items.RowsAffected > 0 {
+	if ent.Items() == nil && len(models) > ent.Limit() {
+		models = models[:ent.Limit()]
+
+		if ent.Keyset() {
+			last := models[len(models)-1]
+			list.SetNextCursor(domain.NewListCursor(last.CreatedAt, hex.EncodeToString(last.ID)))
+		}
+	}
+
+	if len(models) > 0 {
 		list.ListFromDB(models)
 	}
 
 	res = *list
 	return
 }
+
+// Stream passes the transactions of the list filters to the fn one by one, without loading them all in memory.
+// the pagination of the list is ignored
+func (r *Repository) Stream(ctx context.Context, ent domain.TransactionListReqQryParam, fn func(domain.Transaction) error) (err error) {
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.CreditTransactions{})
+
+	applyListFilters(tx, ent)
+
+	rows, err := tx.Order(ent.SortOrder()).Rows()
+	if err != nil {
+		r.lgr.Error("transaction.repo.stream", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		m := model.NewTransaction()
+		if err = tx.ScanRows(rows, m); err != nil {
+			r.lgr.Error("transaction.repo.stream.scan", zap.Error(err))
+			err = meta.Failed
+			return
+		}
+
+		if err = fn(domain.NewTransaction().FromDB(*m)); err != nil {
+			return
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		r.lgr.Error("transaction.repo.stream.rows", zap.Error(err))
+		err = meta.Failed
+	}
+
+	return
+}
+
+// HELPERS
+
+func applyListFilters(tx *gorm.DB, ent domain.TransactionListReqQryParam) {
+	if ent.RelId() != 0 {
+		tx.Where("credit_id = ?", ent.RelId()) // get all items
+	}
+
+	if ent.Items() != nil && len(ent.Items()) > 0 {
+		tx.Where("uuid IN ?", ent.Items()) // get all items
+	}
+
+	if len(ent.Type()) > 0 {
+		tx.Where("type = ?", ent.Type())
+	}
+
+	if !ent.CreatedFrom().IsZero() {
+		tx.Where("created_at >= ?", ent.CreatedFrom())
+	}
+
+	if !ent.CreatedTo().IsZero() {
+		tx.Where("created_at < ?", ent.CreatedTo())
+	}
+}
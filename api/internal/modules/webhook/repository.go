@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+	"microservice/internal/adapter/locale"
+	"microservice/internal/adapter/logger"
+	"microservice/internal/adapter/orm"
+	"microservice/internal/adapter/trace"
+	"microservice/internal/domain"
+	"microservice/internal/model"
+	"microservice/internal/modules/port"
+	"microservice/pkg/meta"
+	"time"
+)
+
+type (
+	RepositoryFx struct {
+		fx.In
+		Locale locale.ILocale
+		Tracer trace.ITracer
+		Logger logger.ILogger
+		Sql    orm.ISqlTx
+	}
+
+	Repository struct {
+		l   locale.ILocale
+		trc trace.ITracer
+		lgr logger.ILogger
+		sql orm.ISqlTx
+	}
+)
+
+func NewRepositoryFx(fx RepositoryFx) port.IWebhookRepository {
+	return &Repository{
+		l:   fx.Locale,
+		trc: fx.Tracer,
+		lgr: fx.Logger,
+		sql: fx.Sql,
+	}
+}
+
+func (r *Repository) CreateDelivery(ctx context.Context, ent domain.WebhookDelivery) (res domain.WebhookDelivery, err error) {
+	m := ent.ToDB()
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.WebhookDeliveries{})
+
+	if err = tx.Omit("created_at").Clauses(clause.Returning{}).Create(&m).Error; err != nil {
+		r.lgr.Error("webhook.repo.delivery.create", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = ent.FromDB(m)
+	return
+}
+
+// CreateRetry holds the failed event until its next attempt is due
+func (r *Repository) CreateRetry(ctx context.Context, event domain.WebhookEvent) (err error) {
+	m := model.WebhookRetries{
+		EventUuid:     event.EventId,
+		TenantID:      event.TenantId,
+		Payload:       event.Json(),
+		NextAttemptAt: event.NextAttemptAt,
+	}
+
+	db := r.sql.TxOf(ctx)
+	tx := db.WithContext(ctx).Model(&model.WebhookRetries{})
+
+	if err = tx.Omit("created_at").Create(&m).Error; err != nil {
+		r.lgr.Error("webhook.repo.retry.create", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	return
+}
+
+// ReleaseRetries removes the due retries and returns their events. the rows locked by other instances are skipped.
+func (r *Repository) ReleaseRetries(ctx context.Context, now time.Time, limit int) (res []domain.WebhookEvent, err error) {
+	var models []model.WebhookRetries
+
+	db := r.sql.TxOf(ctx)
+
+	claim := db.WithContext(ctx).Raw(`
+		DELETE FROM webhook_retries
+		WHERE id IN (
+			SELECT id FROM webhook_retries
+			WHERE next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now, limit,
+	).Scan(&models)
+
+	if err = claim.Error; err != nil {
+		r.lgr.Error("webhook.repo.retry.release", zap.Error(err))
+		err = meta.Failed
+		return
+	}
+
+	res = make([]domain.WebhookEvent, 0, len(models))
+
+	for _, m := range models {
+		var event domain.WebhookEvent
+		if jErr := json.Unmarshal(m.Payload, &event); jErr != nil {
+			r.lgr.Error("webhook.repo.retry.parse", zap.Uint("retry.id", m.ID), zap.Error(jErr))
+			continue
+		}
+
+		res = append(res, event)
+	}
+
+	return
+}
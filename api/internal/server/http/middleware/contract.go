@@ -8,6 +8,8 @@ import (
 type IMiddleware interface {
 	Service() *config.Service
 	SwagAuth(swg *config.Swagger) echo.MiddlewareFunc
+	AdminAuth(adm *config.Admin) echo.MiddlewareFunc
+	ProviderAuth(cb *config.ProviderCallback) echo.MiddlewareFunc
 	RequestCounter(next echo.HandlerFunc) echo.HandlerFunc
 	RequestDuration(next echo.HandlerFunc) echo.HandlerFunc
 	RequestProcess(next echo.HandlerFunc) echo.HandlerFunc
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/labstack/echo/v4"
+	"microservice/config"
+	"microservice/pkg/meta"
+)
+
+const (
+	// AdminTokenHeader the header of the shared admin token
+	AdminTokenHeader = "X.ADMIN.TOKEN"
+	// ProviderSecretHeader the header of the shared secret of the provider callbacks
+	ProviderSecretHeader = "X.PROVIDER.SECRET"
+)
+
+// AdminAuth the admin endpoints are served to the holders of the shared admin token only, the tenants have no access.
+// all of them are refused if the token is not configured
+func (m *Middleware) AdminAuth(adm *config.Admin) echo.MiddlewareFunc {
+	return m.sharedSecret(AdminTokenHeader, adm.Token)
+}
+
+// ProviderAuth the provider callbacks are accepted by the shared secret of the provider only
+func (m *Middleware) ProviderAuth(cb *config.ProviderCallback) echo.MiddlewareFunc {
+	return m.sharedSecret(ProviderSecretHeader, cb.Secret)
+}
+
+func (m *Middleware) sharedSecret(header, secret string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			value := c.Request().Header.Get(header)
+			if len(secret) == 0 || subtle.ConstantTimeCompare([]byte(value), []byte(secret)) != 1 {
+				return meta.Resp(c, m.l).ServiceErr(meta.Unauthorized).Json()
+			}
+
+			return next(c)
+		}
+	}
+}
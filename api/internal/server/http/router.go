@@ -14,6 +14,10 @@ func (s *Server) setRoutes() {
 	s.client.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
 	s.client.GET("/handshake", s.health.Handshake)
 	s.client.GET("/public/swagger/*", routes.Swagger(s.swagger), s.middleware.SwagAuth(s.swagger))
+	routes.Link(s.client, s.link)
+
+	admin := s.middleware.AdminAuth(s.admin)
+	provider := s.middleware.ProviderAuth(s.callback)
 
 	api := s.client.Group("/api")
 	{
@@ -21,7 +25,15 @@ func (s *Server) setRoutes() {
 		{
 			routes.Tenant(v1, s.tenant)
 			routes.Credit(v1, s.credit)
-			routes.Message(v1, s.message)
+			routes.Message(v1, s.message, provider)
+			routes.Template(v1, s.template)
+			routes.Otp(v1, s.otp)
+			routes.Blocklist(v1, s.blocklist, admin)
+			routes.Sender(v1, s.sender, admin)
+			routes.Export(v1, s.export)
+			routes.Report(v1, s.report, admin)
+			routes.Pricing(v1, s.pricing, admin)
+			routes.Policy(v1, s.policy, admin)
 		}
 	}
 }
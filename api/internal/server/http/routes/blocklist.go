@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/blocklist"
+)
+
+func Blocklist(e *echo.Group, h blocklist.IBlocklistHttpHandler, admin echo.MiddlewareFunc) {
+	r := e.Group("/blocklist")
+	r.POST("/create", h.Create)
+	r.GET("/list", h.List)
+	r.DELETE("/:uuid", h.Delete)
+
+	g := r.Group("/global", admin)
+	g.POST("/create", h.CreateGlobal)
+	g.GET("/list", h.GlobalList)
+	g.DELETE("/:uuid", h.DeleteGlobal)
+}
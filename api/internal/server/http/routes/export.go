@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/export"
+)
+
+func Export(e *echo.Group, h export.IExportHttpHandler) {
+	r := e.Group("/export")
+	r.GET("/messages", h.Messages)
+	r.POST("/messages/job", h.MessagesJob)
+	r.GET("/transactions", h.Transactions)
+	r.POST("/transactions/job", h.TransactionsJob)
+	r.GET("/job/:uuid", h.JobDetails)
+	r.GET("/job/:uuid/download", h.Download)
+}
@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/link"
+)
+
+func Link(e *echo.Echo, h link.ILinkHttpHandler) {
+	e.GET("/l/:code", h.Redirect)
+}
@@ -5,8 +5,15 @@ import (
 	"microservice/internal/modules/message"
 )
 
-func Message(e *echo.Group, h message.IMessageHttpHandler) {
+func Message(e *echo.Group, h message.IMessageHttpHandler, provider echo.MiddlewareFunc) {
 	r := e.Group("/message")
 	r.POST("/send", h.Send)
+	r.POST("/bulk", h.SendBulk)
+	r.GET("/bulk/:jobId", h.BulkProgress)
+	r.POST("/dlr", h.Dlr, provider)
+	r.POST("/inbound", h.Inbound, provider)
+	r.GET("/inbound", h.InboundList)
 	r.GET("/list", h.List)
+	r.GET("/:uuid", h.Details)
+	r.POST("/:uuid/cancel", h.Cancel)
 }
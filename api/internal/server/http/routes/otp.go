@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/otp"
+)
+
+func Otp(e *echo.Group, h otp.IOtpHttpHandler) {
+	r := e.Group("/otp")
+	r.POST("/send", h.Send)
+	r.POST("/verify", h.Verify)
+}
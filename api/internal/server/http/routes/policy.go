@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/policy"
+)
+
+func Policy(e *echo.Group, h policy.IPolicyHttpHandler, admin echo.MiddlewareFunc) {
+	r := e.Group("/policy/admin", admin)
+	r.POST("/rule/create", h.CreateRule)
+	r.GET("/rule/list", h.RuleList)
+	r.DELETE("/rule/:uuid", h.DeleteRule)
+	r.GET("/review/list", h.ReviewList)
+	r.PUT("/review/:uuid/approve", h.Approve)
+	r.PUT("/review/:uuid/reject", h.Reject)
+}
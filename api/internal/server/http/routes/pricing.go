@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/pricing"
+)
+
+func Pricing(e *echo.Group, h pricing.IPricingHttpHandler, admin echo.MiddlewareFunc) {
+	r := e.Group("/pricing/admin", admin)
+	r.POST("/plan/create", h.Create)
+	r.GET("/plan/list", h.List)
+	r.GET("/plan/:uuid", h.Details)
+	r.PUT("/plan/:uuid", h.Update)
+	r.DELETE("/plan/:uuid", h.Delete)
+	r.PUT("/tenant/:uuid", h.Assign)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/report"
+)
+
+func Report(e *echo.Group, h report.IReportHttpHandler, admin echo.MiddlewareFunc) {
+	r := e.Group("/reports")
+	r.GET("/usage", h.Usage)
+
+	a := r.Group("/admin", admin)
+	a.GET("/usage", h.AdminUsage)
+}
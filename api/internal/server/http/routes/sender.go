@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/sender"
+)
+
+func Sender(e *echo.Group, h sender.ISenderHttpHandler, admin echo.MiddlewareFunc) {
+	r := e.Group("/sender")
+	r.POST("/create", h.Create)
+	r.GET("/list", h.List)
+	r.DELETE("/:uuid", h.Delete)
+
+	a := r.Group("/admin", admin)
+	a.GET("/list", h.AdminList)
+	a.PUT("/:uuid/review", h.Review)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+	"microservice/internal/modules/template"
+)
+
+func Template(e *echo.Group, h template.ITemplateHttpHandler) {
+	r := e.Group("/template")
+	r.POST("/create", h.Create)
+	r.GET("/list", h.List)
+	r.GET("/:uuid", h.Details)
+	r.PUT("/:uuid", h.Update)
+	r.DELETE("/:uuid", h.Delete)
+}
@@ -10,4 +10,8 @@ func Tenant(e *echo.Group, h tenant.ITenantHttpHandler) {
 	r.POST("/create", h.Create)
 	r.GET("/:uuid", h.Details)
 	r.GET("/list", h.List)
+	r.PUT("/:uuid/webhook", h.SetWebhook)
+	r.DELETE("/:uuid/webhook", h.RemoveWebhook)
+	r.PUT("/:uuid/dedup", h.SetDedupWindow)
+	r.PUT("/:uuid/quiet-hours", h.SetQuietHours)
 }
@@ -12,9 +12,18 @@ import (
 	"microservice/internal/adapter/metric"
 	"microservice/internal/adapter/registry"
 	"microservice/internal/adapter/trace"
+	"microservice/internal/modules/blocklist"
 	"microservice/internal/modules/credit"
+	"microservice/internal/modules/export"
 	"microservice/internal/modules/health"
+	"microservice/internal/modules/link"
 	"microservice/internal/modules/message"
+	"microservice/internal/modules/otp"
+	"microservice/internal/modules/policy"
+	"microservice/internal/modules/pricing"
+	"microservice/internal/modules/report"
+	"microservice/internal/modules/sender"
+	"microservice/internal/modules/template"
 	"microservice/internal/modules/tenant"
 	"microservice/internal/server/http/middleware"
 	"microservice/pkg/utils"
@@ -32,10 +41,19 @@ type (
 		Cache      cache.ICache
 		Middleware middleware.IMiddleware
 		//
-		Health  health.IHealthHttpHandler
-		Tenant  tenant.ITenantHttpHandler
-		Credit  credit.ICreditHttpHandler
-		Message message.IMessageHttpHandler
+		Health    health.IHealthHttpHandler
+		Tenant    tenant.ITenantHttpHandler
+		Credit    credit.ICreditHttpHandler
+		Message   message.IMessageHttpHandler
+		Template  template.ITemplateHttpHandler
+		Otp       otp.IOtpHttpHandler
+		Blocklist blocklist.IBlocklistHttpHandler
+		Sender    sender.ISenderHttpHandler
+		Export    export.IExportHttpHandler
+		Report    report.IReportHttpHandler
+		Pricing   pricing.IPricingHttpHandler
+		Policy    policy.IPolicyHttpHandler
+		Link      link.ILinkHttpHandler
 	}
 
 	Server struct {
@@ -49,14 +67,25 @@ type (
 		service    *config.Service
 		config     *config.HTTP
 		swagger    *config.Swagger
+		admin      *config.Admin
+		callback   *config.ProviderCallback
 		client     *echo.Echo
 	}
 
 	Handler struct {
-		health  health.IHealthHttpHandler
-		tenant  tenant.ITenantHttpHandler
-		credit  credit.ICreditHttpHandler
-		message message.IMessageHttpHandler
+		health    health.IHealthHttpHandler
+		tenant    tenant.ITenantHttpHandler
+		credit    credit.ICreditHttpHandler
+		message   message.IMessageHttpHandler
+		template  template.ITemplateHttpHandler
+		otp       otp.IOtpHttpHandler
+		blocklist blocklist.IBlocklistHttpHandler
+		sender    sender.ISenderHttpHandler
+		export    export.IExportHttpHandler
+		report    report.IReportHttpHandler
+		pricing   pricing.IPricingHttpHandler
+		policy    policy.IPolicyHttpHandler
+		link      link.ILinkHttpHandler
 	}
 )
 
@@ -71,6 +100,14 @@ func NewServer(service *config.Service, registry registry.IRegistry) IHttpServer
 		utils.PrintStd(utils.StdPanic, "http", "swagger config parse err: %s", err)
 	}
 
+	if err := registry.Parse(&s.admin); err != nil {
+		utils.PrintStd(utils.StdPanic, "http", "admin config parse err: %s", err)
+	}
+
+	if err := registry.Parse(&s.callback); err != nil {
+		utils.PrintStd(utils.StdPanic, "http", "provider callback config parse err: %s", err)
+	}
+
 	host := s.config.Host
 	if service.Env == string(config.Dev) {
 		host = "localhost"
@@ -100,10 +137,19 @@ func (s *Server) Fx(lc fx.Lifecycle, sfx ServerFx) {
 			s.cache = sfx.Cache
 			s.middleware = sfx.Middleware
 			s.Handler = &Handler{
-				health:  sfx.Health,
-				tenant:  sfx.Tenant,
-				credit:  sfx.Credit,
-				message: sfx.Message,
+				health:    sfx.Health,
+				tenant:    sfx.Tenant,
+				credit:    sfx.Credit,
+				message:   sfx.Message,
+				template:  sfx.Template,
+				otp:       sfx.Otp,
+				blocklist: sfx.Blocklist,
+				sender:    sfx.Sender,
+				export:    sfx.Export,
+				report:    sfx.Report,
+				pricing:   sfx.Pricing,
+				policy:    sfx.Policy,
+				link:      sfx.Link,
 			}
 
 			s.setupServer()
@@ -15,4 +15,5 @@ var (
 	InvalidClient = ServiceErr(status.InvalidClient)
 	TokenExpired  = ServiceErr(status.TokenExpired)
 	DtoBindErr    = ServiceErr(status.DtoBindErr)
+	Blocked       = ServiceErr(status.Blocked)
 )
@@ -21,4 +21,5 @@ var MappedStatuses = map[HttpMappedStatus]int{
 	InvalidClient: http.StatusUnauthorized,
 	TokenExpired:  http.StatusUnauthorized,
 	DtoBindErr:    http.StatusBadRequest,
+	Blocked:       http.StatusForbidden,
 }
@@ -26,4 +26,5 @@ const (
 	InvalidClient HttpMappedStatus = "invalid_client"
 	TokenExpired  HttpMappedStatus = "access_token_exp"
 	DtoBindErr    HttpMappedStatus = "dto_bind_err"
+	Blocked       HttpMappedStatus = "recipient_blocked"
 )
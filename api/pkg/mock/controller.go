@@ -12,9 +12,9 @@ func NewController() *Controller {
 }
 
 func (c Controller) Errorf(format string, args ...any) {
-	fmt.Printf(format, args)
+	fmt.Printf(format, args...)
 }
 
 func (c Controller) Fatalf(format string, args ...any) {
-	log.Fatalf(format, args)
+	log.Fatalf(format, args...)
 }
@@ -17,3 +17,11 @@ func TransactionIdGen(t domain.Tenant, c domain.Credit, msg *domain.Message) (id
 	id = sum[:]
 	return
 }
+
+// RefundTransactionIdGen the refund id is derived from the message hash only, so a message is never refunded twice
+func RefundTransactionIdGen(msg domain.Message) (id []byte) {
+	data := fmt.Sprintf("%s:%s", domain.TxRefund, msg.MessageHash())
+	sum := sha256.Sum256([]byte(data))
+	id = sum[:]
+	return
+}
@@ -11,3 +11,42 @@ func RandomStr(length int) string {
 	s := hex.EncodeToString(b)
 	return s
 }
+
+const digits = "0123456789"
+
+// RandomDigits a cryptographically random numeric code, like the OTP codes
+func RandomDigits(length int) string {
+	return randomOf(digits, length)
+}
+
+const alphaNum = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// RandomAlphaNum a cryptographically random base62 code, like the short link codes
+func RandomAlphaNum(length int) string {
+	return randomOf(alphaNum, length)
+}
+
+// randomOf a cryptographically random code of the charset characters. the bytes over the largest multiple of the
+// charset length are rejected, so every character is equally likely
+func randomOf(charset string, length int) string {
+	limit := 256 - 256%len(charset)
+	res := make([]byte, 0, length)
+	b := make([]byte, length)
+
+	for len(res) < length {
+		_, _ = rand.Read(b)
+
+		for _, v := range b {
+			if int(v) >= limit {
+				continue
+			}
+
+			res = append(res, charset[int(v)%len(charset)])
+			if len(res) == length {
+				break
+			}
+		}
+	}
+
+	return string(res)
+}
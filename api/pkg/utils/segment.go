@@ -0,0 +1,70 @@
+package utils
+
+import "unicode/utf16"
+
+type SmsEncoding string
+
+const (
+	EncodingGsm7 SmsEncoding = "GSM-7"
+	EncodingUcs2 SmsEncoding = "UCS-2"
+)
+
+const (
+	gsm7SingleLimit = 160 // septets of a single part GSM-7 message
+	gsm7PartLimit   = 153 // septets of each concatenated GSM-7 part (7 septets are taken by UDH)
+	ucs2SingleLimit = 70  // chars of a single part UCS-2 message
+	ucs2PartLimit   = 67  // chars of each concatenated UCS-2 part (3 chars are taken by UDH)
+)
+
+// gsm7Basic the GSM 03.38 default alphabet, each char takes one septet
+var gsm7Basic = map[rune]bool{}
+
+// gsm7Extension the GSM 03.38 extension table, each char takes two septets(escape + char)
+var gsm7Extension = map[rune]bool{
+	'^': true, '{': true, '}': true, '\\': true, '[': true, '~': true, ']': true, '|': true, '€': true, '\f': true,
+}
+
+func init() {
+	basic := "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞ\x1bÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+	for _, r := range basic {
+		gsm7Basic[r] = true
+	}
+}
+
+// SmsSegments detects the text encoding(GSM-7 or UCS-2) and returns the count of the SMS parts needed to send it
+func SmsSegments(text string) (segments int, encoding SmsEncoding) {
+	septets, gsm := 0, true
+
+	for _, r := range text {
+		switch {
+		case gsm7Basic[r]:
+			septets++
+		case gsm7Extension[r]:
+			septets += 2
+		default:
+			gsm = false
+		}
+
+		if !gsm {
+			break
+		}
+	}
+
+	if gsm {
+		return parts(septets, gsm7SingleLimit, gsm7PartLimit), EncodingGsm7
+	}
+
+	// UCS-2 counts UTF-16 code units, the chars out of BMP(like emojis) take two units
+	units := len(utf16.Encode([]rune(text)))
+	return parts(units, ucs2SingleLimit, ucs2PartLimit), EncodingUcs2
+}
+
+func parts(length, single, part int) int {
+	if length <= single {
+		return 1
+	}
+
+	return (length + part - 1) / part
+}
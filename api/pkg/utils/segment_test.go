@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSmsSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		segments int
+		encoding SmsEncoding
+	}{
+		{name: "empty", text: "", segments: 1, encoding: EncodingGsm7},
+		{name: "gsm single part limit", text: strings.Repeat("a", 160), segments: 1, encoding: EncodingGsm7},
+		{name: "gsm two parts", text: strings.Repeat("a", 161), segments: 2, encoding: EncodingGsm7},
+		{name: "gsm two parts limit", text: strings.Repeat("a", 306), segments: 2, encoding: EncodingGsm7},
+		{name: "gsm three parts", text: strings.Repeat("a", 307), segments: 3, encoding: EncodingGsm7},
+		{name: "gsm extension takes two septets", text: strings.Repeat("{", 80), segments: 1, encoding: EncodingGsm7},
+		{name: "gsm extension over the limit", text: strings.Repeat("{", 80) + "a", segments: 2, encoding: EncodingGsm7},
+		{name: "ucs2 single part limit", text: strings.Repeat("س", 70), segments: 1, encoding: EncodingUcs2},
+		{name: "ucs2 two parts", text: strings.Repeat("س", 71), segments: 2, encoding: EncodingUcs2},
+		{name: "ucs2 two parts limit", text: strings.Repeat("س", 134), segments: 2, encoding: EncodingUcs2},
+		{name: "ucs2 three parts", text: strings.Repeat("س", 135), segments: 3, encoding: EncodingUcs2},
+		{name: "one non gsm char switches the encoding", text: strings.Repeat("a", 70) + "س", segments: 2, encoding: EncodingUcs2},
+		{name: "emoji takes two units", text: strings.Repeat("😀", 35), segments: 1, encoding: EncodingUcs2},
+		{name: "emoji over the limit", text: strings.Repeat("😀", 36), segments: 2, encoding: EncodingUcs2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, encoding := SmsSegments(tt.text)
+			if segments != tt.segments || encoding != tt.encoding {
+				t.Errorf("SmsSegments() = %d, %s, want %d, %s", segments, encoding, tt.segments, tt.encoding)
+			}
+		})
+	}
+}
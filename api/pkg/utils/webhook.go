@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+)
+
+var ErrNonPublicHost = errors.New("the webhook host is not public")
+
+// sharedAddressSpace the carrier-grade NAT range (RFC 6598), not reachable on the internet either
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// WebhookSignature the HMAC-SHA256 of `{timestamp}.{body}`. the timestamp is signed to prevent replaying the old requests
+func WebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsPublicIP reports whether the address is reachable on the internet, the loopback, private, link-local (e.g. the
+// cloud metadata 169.254.169.254), multicast and unspecified addresses are not
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// PublicHost resolves the webhook host, every address of it has to be public
+func PublicHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return ErrNonPublicHost
+		}
+	}
+
+	return nil
+}
+
+// WebhookDialControl refuses the webhook connections to the non-public addresses. it checks the dialed address, so the
+// host resolved again after its registration is covered too
+func WebhookDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return ErrNonPublicHost
+	}
+
+	return nil
+}
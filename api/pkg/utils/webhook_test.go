@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{ip: "93.184.216.34", public: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", public: true},
+		{ip: "127.0.0.1", public: false},
+		{ip: "::1", public: false},
+		{ip: "10.1.2.3", public: false},
+		{ip: "172.16.0.1", public: false},
+		{ip: "192.168.1.1", public: false},
+		{ip: "169.254.169.254", public: false},
+		{ip: "100.64.0.1", public: false},
+		{ip: "0.0.0.0", public: false},
+		{ip: "fd00::1", public: false},
+		{ip: "fe80::1", public: false},
+		{ip: "::ffff:127.0.0.1", public: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.public {
+				t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.public)
+			}
+		})
+	}
+}
+
+func TestWebhookDialControl(t *testing.T) {
+	tests := []struct {
+		address string
+		ok      bool
+	}{
+		{address: "93.184.216.34:443", ok: true},
+		{address: "127.0.0.1:443", ok: false},
+		{address: "[::1]:443", ok: false},
+		{address: "169.254.169.254:80", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if err := WebhookDialControl("tcp", tt.address, nil); (err == nil) != tt.ok {
+				t.Errorf("WebhookDialControl(%s) err = %v, want ok %v", tt.address, err, tt.ok)
+			}
+		})
+	}
+}
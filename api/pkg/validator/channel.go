@@ -0,0 +1,26 @@
+package validator
+
+import "sync"
+
+var channels = struct {
+	sync.RWMutex
+	names map[string]bool
+}{names: make(map[string]bool)}
+
+// RegisterChannels sets the message channels accepted by the `channel` tag, the former channels are replaced
+func RegisterChannels(names ...string) {
+	channels.Lock()
+	defer channels.Unlock()
+
+	channels.names = make(map[string]bool, len(names))
+	for _, name := range names {
+		channels.names[name] = true
+	}
+}
+
+func isChannel(name string) bool {
+	channels.RLock()
+	defer channels.RUnlock()
+
+	return channels.names[name]
+}
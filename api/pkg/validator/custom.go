@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"encoding/base64"
 	ut "github.com/go-playground/universal-translator"
 	gvld "github.com/go-playground/validator/v10"
 	"log"
@@ -10,12 +11,25 @@ import (
 
 const errMsg = "[validator] register err: %s"
 
+var (
+	// templatePlaceholder the `{{name}}` placeholders of the template bodies
+	templatePlaceholder = regexp.MustCompile(`\{\{\s*[A-Za-z0-9_]+\s*\}\}`)
+	// plainText the Persian letters and digits of the template bodies and the sms texts, once their placeholders or links are removed
+	plainText = regexp.MustCompile(`^[\p{L}\p{N}\s_-]*$`)
+	// paginationCursor the decoded `{created_at unix micro}|{id}` of the list cursors
+	paginationCursor = regexp.MustCompile(`^\d+\|[0-9A-Za-z]+$`)
+)
+
 func registerCustomValidators() {
 	registerIsPersianAlphaNum()
+	registerIsTemplateBody()
+	registerIsSmsText()
 	registerIsMobileNumber()
+	registerIsChannel()
 	registerIsPasetoSemiToken()
 	registerIsPaginationSort()
 	registerIsPaginationOrder()
+	registerIsPaginationCursor()
 	registerIsAddress()
 	registerIsAlphaDash()
 	registerIsDate()
@@ -61,6 +75,78 @@ func faAlphaNumFieldErr(ut ut.Translator, fe gvld.FieldError) string {
 
 //
 
+func registerIsTemplateBody() {
+	if err := validate.RegisterValidation("fa_template", validateIsTemplateBody); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+
+	if err := validate.RegisterTranslation("fa_template", trans, faTemplateUT, faTemplateFieldErr); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+}
+
+func validateIsTemplateBody(fl gvld.FieldLevel) (res bool) {
+	// Persian letters and digits along with the `{{name}}` placeholders
+	value := fl.Field().String()
+
+	if len(value) == 0 {
+		res = true
+		return
+	}
+
+	value = templatePlaceholder.ReplaceAllString(value, " ")
+
+	res = plainText.MatchString(value)
+	return
+}
+
+func faTemplateUT(ut ut.Translator) error {
+	return ut.Add("fa_template", "فرمت قالب فیلد {0} معتبر نیست", true)
+}
+
+func faTemplateFieldErr(ut ut.Translator, fe gvld.FieldError) string {
+	t, _ := ut.T("fa_template", fe.Field())
+	return t
+}
+
+//
+
+func registerIsSmsText() {
+	if err := validate.RegisterValidation("fa_sms", validateIsSmsText); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+
+	if err := validate.RegisterTranslation("fa_sms", trans, faSmsUT, faSmsFieldErr); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+}
+
+func validateIsSmsText(fl gvld.FieldLevel) (res bool) {
+	// Persian letters and digits along with the links, the links may be replaced by the short links
+	value := fl.Field().String()
+
+	if len(value) == 0 {
+		res = true
+		return
+	}
+
+	value = LinkPattern.ReplaceAllString(value, " ")
+
+	res = plainText.MatchString(value)
+	return
+}
+
+func faSmsUT(ut ut.Translator) error {
+	return ut.Add("fa_sms", "فرمت متن پیامک فیلد {0} معتبر نیست", true)
+}
+
+func faSmsFieldErr(ut ut.Translator, fe gvld.FieldError) string {
+	t, _ := ut.T("fa_sms", fe.Field())
+	return t
+}
+
+//
+
 func registerIsMobileNumber() {
 	if err := validate.RegisterValidation("mobile", validateIsMobileNumber); err != nil {
 		log.Fatalf(errMsg, err)
@@ -72,16 +158,9 @@ func registerIsMobileNumber() {
 }
 
 func validateIsMobileNumber(fl gvld.FieldLevel) (res bool) {
-	phone := fl.Field().String()
-	phone = normalizeDigits(phone)
-	// Remove all non-digit characters
-	re := regexp.MustCompile(`\D`)
-	cleaned := re.ReplaceAllString(phone, "")
-
-	// Check if the cleaned number matches Iranian mobile patterns
-	matched, _ := regexp.MatchString(`^(09|9)\d{2,9}$`, cleaned)
-
-	return matched
+	// the local numbers of the default country or the international numbers of the known countries
+	_, err := NormalizeMobile(fl.Field().String())
+	return err == nil
 }
 
 func mobileUT(ut ut.Translator) error {
@@ -95,6 +174,32 @@ func mobileFieldErr(ut ut.Translator, fe gvld.FieldError) string {
 
 //
 
+func registerIsChannel() {
+	if err := validate.RegisterValidation("channel", validateIsChannel); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+
+	if err := validate.RegisterTranslation("channel", trans, channelUT, channelFieldErr); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+}
+
+func validateIsChannel(fl gvld.FieldLevel) (res bool) {
+	// the channels of the queue configuration
+	return isChannel(fl.Field().String())
+}
+
+func channelUT(ut ut.Translator) error {
+	return ut.Add("channel", "کانال {0} تعریف نشده است", true)
+}
+
+func channelFieldErr(ut ut.Translator, fe gvld.FieldError) string {
+	t, _ := ut.T("channel", fe.Value().(string))
+	return t
+}
+
+//
+
 func registerIsPasetoSemiToken() {
 	if err := validate.RegisterValidation("paseto", validateIsPasetoSemiToken); err != nil {
 		log.Fatalf(errMsg, err)
@@ -199,6 +304,45 @@ func isPaginationOrderFieldErr(ut ut.Translator, fe gvld.FieldError) string {
 
 //
 
+func registerIsPaginationCursor() {
+	if err := validate.RegisterValidation("pagination_cursor", validateIsPaginationCursor); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+
+	if err := validate.RegisterTranslation("pagination_cursor", trans, isPaginationCursorUT, isPaginationCursorFieldErr); err != nil {
+		log.Fatalf(errMsg, err)
+	}
+}
+
+func validateIsPaginationCursor(fl gvld.FieldLevel) (res bool) {
+	// the base64 (raw url) of `{created_at unix micro}|{id}`, as the list responses return
+	value := fl.Field().String()
+
+	if len(value) == 0 {
+		res = true
+		return
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return
+	}
+
+	res = paginationCursor.MatchString(string(raw))
+	return
+}
+
+func isPaginationCursorUT(ut ut.Translator) error {
+	return ut.Add("pagination_cursor", "مقدار فیلد {0} معتبر نیست", true)
+}
+
+func isPaginationCursorFieldErr(ut ut.Translator, fe gvld.FieldError) string {
+	t, _ := ut.T("pagination_cursor", fe.Field())
+	return t
+}
+
+//
+
 func registerIsAddress() {
 	if err := validate.RegisterValidation("address", validateIsAddress); err != nil {
 		log.Fatalf(errMsg, err)
@@ -0,0 +1,7 @@
+package validator
+
+import "regexp"
+
+// LinkPattern the links with a scheme or the www prefix, and the bare domains along with their path (e.g. `example.com/login`).
+// the sms texts are validated and their links are detected by it
+var LinkPattern = regexp.MustCompile(`(?i)\b(?:(?:https?://|www\.)[^\s<>"']+|(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}\b(?:[/?#][^\s<>"']*)?)`)
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// MobileCountry the numbering rules of the mobile numbers of a country
+type MobileCountry struct {
+	Code        string         // ISO 3166-1 alpha-2
+	CallingCode string         // the international calling code, without `+`
+	TrunkPrefix string         // the national prefix of the local format, dropped in E.164
+	Pattern     *regexp.Regexp // the national significant number of the mobiles
+}
+
+// DefaultMobileCountry the country of the numbers in the local format
+const DefaultMobileCountry = "IR"
+
+var (
+	ErrInvalidMobile = errors.New("invalid mobile number")
+	nonDigits        = regexp.MustCompile(`\D`)
+)
+
+var mobileCountries = map[string]MobileCountry{
+	"IR": {Code: "IR", CallingCode: "98", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^9\d{9}$`)},
+	"AE": {Code: "AE", CallingCode: "971", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^5[024568]\d{7}$`)},
+	"AF": {Code: "AF", CallingCode: "93", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^7\d{8}$`)},
+	"DE": {Code: "DE", CallingCode: "49", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^1[5-7]\d{8,9}$`)},
+	"FR": {Code: "FR", CallingCode: "33", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^[67]\d{8}$`)},
+	"GB": {Code: "GB", CallingCode: "44", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^7\d{9}$`)},
+	"IQ": {Code: "IQ", CallingCode: "964", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^7[3-9]\d{8}$`)},
+	"TR": {Code: "TR", CallingCode: "90", TrunkPrefix: "0", Pattern: regexp.MustCompile(`^5\d{9}$`)},
+	"US": {Code: "US", CallingCode: "1", TrunkPrefix: "1", Pattern: regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`)}, // the NANP, Canada included
+}
+
+// mobileCallingCodes the countries by their calling code
+var mobileCallingCodes = func() map[string]MobileCountry {
+	codes := make(map[string]MobileCountry, len(mobileCountries))
+	for _, c := range mobileCountries {
+		codes[c.CallingCode] = c
+	}
+
+	return codes
+}()
+
+// NormalizeMobile parses the number in the local (default country) or the international format (`+`, `00` or the
+// calling code) into E.164, e.g. `09123456789`, `9123456789`, `0098 912 345 6789` and `+989123456789` are `+989123456789`
+func NormalizeMobile(value string) (res string, err error) {
+	digits, international := cleanMobile(value)
+	if len(digits) == 0 {
+		err = ErrInvalidMobile
+		return
+	}
+
+	if international {
+		country, nsn, ok := splitCallingCode(digits)
+		if !ok {
+			err = ErrInvalidMobile
+			return
+		}
+
+		return country.format(nsn)
+	}
+
+	country := mobileCountries[DefaultMobileCountry]
+
+	if res, err = country.format(strings.TrimPrefix(digits, country.TrunkPrefix)); err == nil {
+		return
+	}
+
+	// the international number without `+` or `00`, e.g. `989123456789`
+	if c, nsn, ok := splitCallingCode(digits); ok {
+		return c.format(nsn)
+	}
+
+	return
+}
+
+// NormalizeMobilePrefix the leading digits of a number as the leading chars of its E.164 form, the local prefixes
+// (trunk prefixed) are of the default country
+func NormalizeMobilePrefix(value string) string {
+	digits, international := cleanMobile(value)
+	if international || len(digits) == 0 {
+		return "+" + digits
+	}
+
+	country := mobileCountries[DefaultMobileCountry]
+	if len(country.TrunkPrefix) > 0 && strings.HasPrefix(digits, country.TrunkPrefix) {
+		return "+" + country.CallingCode + strings.TrimPrefix(digits, country.TrunkPrefix)
+	}
+
+	return "+" + digits
+}
+
+// MobileCountryOf the country of the E.164 number
+func MobileCountryOf(e164 string) (res MobileCountry, ok bool) {
+	res, _, ok = splitCallingCode(strings.TrimPrefix(e164, "+"))
+	return
+}
+
+// HELPERS
+
+func (c MobileCountry) format(nsn string) (res string, err error) {
+	if !c.Pattern.MatchString(nsn) {
+		err = ErrInvalidMobile
+		return
+	}
+
+	res = "+" + c.CallingCode + nsn
+	return
+}
+
+// cleanMobile the digits of the number and whether it is in the international format
+func cleanMobile(value string) (digits string, international bool) {
+	value = strings.TrimSpace(normalizeDigits(value))
+
+	international = strings.HasPrefix(value, "+")
+	digits = nonDigits.ReplaceAllString(value, "")
+
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = strings.TrimPrefix(digits, "00")
+	}
+
+	return
+}
+
+// splitCallingCode the calling codes are prefix free, so the first match of 1 to 3 digits is the country.
+// the trunk prefix which is wrongly kept after the calling code (e.g. `+98 0912...`) is dropped
+func splitCallingCode(digits string) (country MobileCountry, nsn string, ok bool) {
+	for i := 1; i <= 3 && i < len(digits); i++ {
+		if country, ok = mobileCallingCodes[digits[:i]]; ok {
+			nsn = digits[i:]
+			if len(country.TrunkPrefix) > 0 && !country.Pattern.MatchString(nsn) {
+				nsn = strings.TrimPrefix(nsn, country.TrunkPrefix)
+			}
+
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeMobile(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		err   error
+	}{
+		{name: "local with trunk prefix", value: "09123456789", want: "+989123456789"},
+		{name: "local without trunk prefix", value: "9123456789", want: "+989123456789"},
+		{name: "plus prefix", value: "+989123456789", want: "+989123456789"},
+		{name: "double zero prefix with spaces", value: "0098 912 345 6789", want: "+989123456789"},
+		{name: "calling code without prefix", value: "989123456789", want: "+989123456789"},
+		{name: "persian digits", value: "۰۹۱۲۳۴۵۶۷۸۹", want: "+989123456789"},
+		{name: "dashes and parentheses", value: "(0912) 345-6789", want: "+989123456789"},
+		{name: "trunk prefix kept after calling code", value: "+98 0912 345 6789", want: "+989123456789"},
+		{name: "other country", value: "+447911123456", want: "+447911123456"},
+		{name: "nanp", value: "+1 212 555 1234", want: "+12125551234"},
+		{name: "empty", value: "", err: ErrInvalidMobile},
+		{name: "letters only", value: "mobile", err: ErrInvalidMobile},
+		{name: "short local", value: "0912345", err: ErrInvalidMobile},
+		{name: "landline", value: "02112345678", err: ErrInvalidMobile},
+		{name: "unknown calling code", value: "+8613812345678", err: ErrInvalidMobile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMobile(tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NormalizeMobile(%q) err = %v, want %v", tt.value, err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Errorf("NormalizeMobile(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitCallingCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		digits  string
+		country string
+		nsn     string
+		ok      bool
+	}{
+		{name: "one digit code", digits: "12125551234", country: "US", nsn: "2125551234", ok: true},
+		{name: "two digits code", digits: "989123456789", country: "IR", nsn: "9123456789", ok: true},
+		{name: "three digits code", digits: "971501234567", country: "AE", nsn: "501234567", ok: true},
+		{name: "trunk prefix dropped", digits: "9809123456789", country: "IR", nsn: "9123456789", ok: true},
+		{name: "unknown code", digits: "8613812345678", ok: false},
+		{name: "code only", digits: "98", ok: false},
+		{name: "empty", digits: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			country, nsn, ok := splitCallingCode(tt.digits)
+			if ok != tt.ok {
+				t.Fatalf("splitCallingCode(%q) ok = %v, want %v", tt.digits, ok, tt.ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if country.Code != tt.country || nsn != tt.nsn {
+				t.Errorf("splitCallingCode(%q) = %s, %q, want %s, %q", tt.digits, country.Code, nsn, tt.country, tt.nsn)
+			}
+		})
+	}
+}
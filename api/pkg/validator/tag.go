@@ -22,6 +22,7 @@ func persianTagNamesEvaluator(fld reflect.StructField) string {
 		"firstname":        "نام",
 		"lastname":         "نام خانوادگی",
 		"mobile":           "موبایل",
+		"mobiles":          "موبایل ها",
 		"email":            "ایمیل",
 		"token":            "توکن",
 		"refreshToken":     "رفرش توکن",
@@ -77,6 +78,38 @@ func persianTagNamesEvaluator(fld reflect.StructField) string {
 		"release":          "وضعیت انتشار",
 		"releaseDate":      "تاریخ انتشار",
 		"expireDate":       "تاریخ پایان",
+		"sendAt":           "زمان ارسال",
+		"name":             "نام",
+		"body":             "متن",
+		"templateId":       "شناسه قالب",
+		"variables":        "متغیرها",
+		"url":              "آدرس وب هوک",
+		"windowSec":        "بازه حذف تکراری",
+		"idempotencyKey":   "کلید یکتایی درخواست",
+		"code":             "کد",
+		"sender":           "فرستنده",
+		"from":             "فرستنده",
+		"to":               "خط گیرنده",
+		"channel":          "کانال",
+		"mobilePrefix":     "پیشوند موبایل",
+		"createdFrom":      "از تاریخ",
+		"createdTo":        "تا تاریخ",
+		"clientRef":        "شناسه مرجع مشتری",
+		"format":           "قالب خروجی",
+		"granularity":      "بازه زمانی",
+		"tenant":           "مشتری",
+		"discount":         "درصد تخفیف",
+		"tiers":            "پله های حجمی",
+		"minVolume":        "حداقل حجم ماهانه",
+		"planUuid":         "شناسه طرح قیمت گذاری",
+		"validUntil":       "پایان اعتبار",
+		"ttl":              "مدت اعتبار",
+		"trackLinks":       "ردیابی لینک‌ها",
+		"start":            "شروع ساعات سکوت",
+		"end":              "پایان ساعات سکوت",
+		"timezone":         "منطقه زمانی",
+		"tenantUuid":       "شناسه مشتری",
+		"value":            "مقدار قانون",
 	}
 
 	jsonName := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS job_uuid UUID NULL;
+
+CREATE INDEX IF NOT EXISTS idx_msg_tenant_job ON messages (tenant_id, job_uuid);
+
+-- +migrate Down
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS segments SMALLINT NOT NULL DEFAULT 1;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS encoding VARCHAR(8) NOT NULL DEFAULT 'GSM-7';
+
+-- +migrate Down
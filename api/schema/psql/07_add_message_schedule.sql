@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TYPE message_status ADD VALUE IF NOT EXISTS 'scheduled';
+ALTER TYPE message_status ADD VALUE IF NOT EXISTS 'canceled';
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS send_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_msg_status_send_at ON messages (status, send_at);
+
+-- +migrate Down
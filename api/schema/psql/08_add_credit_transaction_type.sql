@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE credit_transactions ADD COLUMN IF NOT EXISTS type VARCHAR(16) NULL;
+
+-- the transactions before the type column: deposits have no message hash
+UPDATE credit_transactions
+SET type = CASE WHEN message_hash_id IS NULL THEN 'deposit' ELSE 'charge' END
+WHERE type IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_msg_hash ON credit_transactions (message_hash_id, type);
+
+-- +migrate Down
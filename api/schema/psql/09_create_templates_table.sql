@@ -0,0 +1,21 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS templates (
+    id          SERIAL PRIMARY KEY,
+    uuid        UUID DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id   INTEGER NOT NULL,
+    name        VARCHAR(255) NOT NULL,
+    body        TEXT NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_templates_tenant_name ON templates (tenant_id, name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_templates_uuid ON templates (uuid);
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS template_id INTEGER NULL REFERENCES templates(id) ON DELETE NO ACTION;
+
+-- +migrate Down
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS provider_message_id VARCHAR(128) NULL;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS dlr_state VARCHAR(32) NULL;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS dlr_at TIMESTAMP NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_msg_provider_message_id ON messages (provider_message_id) WHERE provider_message_id IS NOT NULL;
+
+-- +migrate Down
@@ -0,0 +1,25 @@
+-- +migrate Up
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS webhook_url VARCHAR(2048) NULL;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS webhook_secret VARCHAR(128) NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id            SERIAL PRIMARY KEY,
+    event_uuid    UUID NOT NULL,
+    tenant_id     INTEGER NOT NULL,
+    message_id    INTEGER NOT NULL,
+    status        message_status NOT NULL,
+    url           VARCHAR(2048) NOT NULL,
+    attempt       INTEGER NOT NULL DEFAULT 1,
+    response_code INTEGER NULL,
+    error         TEXT NULL,
+    succeeded     BOOLEAN NOT NULL DEFAULT FALSE,
+    duration_ms   INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION,
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (event_uuid);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_tenant_created ON webhook_deliveries (tenant_id, created_at);
+
+-- +migrate Down
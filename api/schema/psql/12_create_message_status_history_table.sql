@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS message_status_history (
+    id          SERIAL PRIMARY KEY,
+    message_id  INTEGER NOT NULL,
+    status      message_status NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_msg_status_history_message ON message_status_history (message_id, created_at);
+
+-- +migrate Down
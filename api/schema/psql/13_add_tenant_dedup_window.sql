@@ -0,0 +1,5 @@
+-- +migrate Up
+-- the same message to the same mobile is rejected within the window (seconds), 0 disables the deduplication
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS dedup_window_sec INTEGER NOT NULL DEFAULT 60;
+
+-- +migrate Down
@@ -0,0 +1,20 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- +migrate Up
+-- the null tenant_id is the global block list, applied to all tenants
+CREATE TABLE IF NOT EXISTS blocked_numbers (
+    id          SERIAL PRIMARY KEY,
+    uuid        UUID DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id   INTEGER NULL,
+    mobile      VARCHAR(255) NOT NULL,
+    reason      VARCHAR(32) NOT NULL DEFAULT 'manual',
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blocked_numbers_tenant_mobile ON blocked_numbers (COALESCE(tenant_id, 0), mobile) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_blocked_numbers_uuid ON blocked_numbers (uuid);
+
+-- +migrate Down
@@ -0,0 +1,32 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'sender_status') THEN
+            CREATE TYPE sender_status AS ENUM ('pending','approved','rejected');
+        END IF;
+END$$;
+
+-- +migrate Up
+-- the tenant sender ids and dedicated lines, usable after the admin approval
+CREATE TABLE IF NOT EXISTS senders (
+    id          SERIAL PRIMARY KEY,
+    uuid        UUID DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id   INTEGER NOT NULL,
+    sender      VARCHAR(32) NOT NULL,
+    status      sender_status NOT NULL DEFAULT 'pending',
+    description VARCHAR(255) NULL,
+    reviewed_at TIMESTAMP NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_senders_tenant_sender ON senders (tenant_id, sender) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_senders_uuid ON senders (uuid);
+CREATE INDEX IF NOT EXISTS idx_senders_status ON senders (status);
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS sender VARCHAR(32) NULL;
+
+-- +migrate Down
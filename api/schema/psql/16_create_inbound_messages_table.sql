@@ -0,0 +1,29 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- +migrate Up
+-- the mobile originated messages, replied to the tenants
+CREATE TABLE IF NOT EXISTS inbound_messages (
+    id           SERIAL PRIMARY KEY,
+    uuid         UUID DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id    INTEGER NOT NULL,
+    mobile       VARCHAR(20) NOT NULL,
+    receiver     VARCHAR(32) NULL,
+    message_text TEXT NOT NULL,
+    opted_out    BOOLEAN NOT NULL DEFAULT FALSE,
+    received_at  TIMESTAMP NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at   TIMESTAMP NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbound_messages_uuid ON inbound_messages (uuid);
+CREATE INDEX IF NOT EXISTS idx_inbound_messages_tenant_created ON inbound_messages (tenant_id, created_at);
+
+-- the inbound messages are posted to the tenant webhooks along with the status changes
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event VARCHAR(32) NOT NULL DEFAULT 'message.status';
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS inbound_message_id INTEGER NULL REFERENCES inbound_messages(id) ON DELETE NO ACTION;
+ALTER TABLE webhook_deliveries ALTER COLUMN message_id DROP NOT NULL;
+ALTER TABLE webhook_deliveries ALTER COLUMN status DROP NOT NULL;
+
+-- +migrate Down
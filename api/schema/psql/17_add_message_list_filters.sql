@@ -0,0 +1,11 @@
+-- +migrate Up
+-- the client side id of the message, to find the message by the tenant own reference
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS client_ref VARCHAR(64) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_messages_tenant_created ON messages (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_messages_tenant_status_created ON messages (tenant_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_messages_tenant_mobile ON messages (tenant_id, mobile varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_messages_tenant_client_ref ON messages (tenant_id, client_ref) WHERE client_ref IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_outboxes_message_event_type ON outboxes (message_id, event_type);
+
+-- +migrate Down
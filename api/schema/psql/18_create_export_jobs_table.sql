@@ -0,0 +1,33 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'export_status') THEN
+            CREATE TYPE export_status AS ENUM ('pending','running','completed','failed');
+        END IF;
+END$$;
+
+-- +migrate Up
+-- the background exports of the tenants, the files are kept in the export directory
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id          SERIAL PRIMARY KEY,
+    uuid        UUID DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id   INTEGER NOT NULL,
+    resource    VARCHAR(32) NOT NULL,
+    format      VARCHAR(8) NOT NULL,
+    filters     TEXT NULL,
+    status      export_status NOT NULL DEFAULT 'pending',
+    rows        BIGINT NOT NULL DEFAULT 0,
+    file_path   VARCHAR(1024) NULL,
+    error       TEXT NULL,
+    finished_at TIMESTAMP NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_jobs_uuid ON export_jobs (uuid);
+CREATE INDEX IF NOT EXISTS idx_export_jobs_tenant_created ON export_jobs (tenant_id, created_at);
+
+-- +migrate Down
@@ -0,0 +1,76 @@
+-- +migrate Up
+-- the hourly usage of the tenants, incremented by the usage consumer on every message status change.
+-- the credit is the charged amount of the bucket, the refunds are negative
+CREATE TABLE IF NOT EXISTS usage_rollups (
+    tenant_id     INTEGER NOT NULL,
+    bucket_at     TIMESTAMP NOT NULL,
+    channel       VARCHAR(32) NOT NULL DEFAULT '',
+    status        VARCHAR(16) NOT NULL,
+    messages      BIGINT NOT NULL DEFAULT 0,
+    credit_spent  NUMERIC(20, 4) NOT NULL DEFAULT 0,
+    latency_ms    BIGINT NOT NULL DEFAULT 0, -- the sum of queued to sent durations
+    latency_count BIGINT NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, bucket_at, channel, status),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_rollups_bucket ON usage_rollups (bucket_at);
+
+-- the one-shot data migrations record their name, every migration file runs on each boot
+CREATE TABLE IF NOT EXISTS schema_backfills (
+    name       VARCHAR(64) PRIMARY KEY,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- the backfill of the existing messages runs once, before the usage consumer increments the buckets. the rollups of
+-- the deployments which ran it before the guard are kept as they are
+DO $$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM schema_backfills WHERE name = 'usage_rollups') THEN
+            RETURN;
+        END IF;
+
+        IF NOT EXISTS (SELECT 1 FROM usage_rollups) THEN
+            WITH changes AS (
+                SELECT m.tenant_id,
+                       date_trunc('hour', h.created_at) AS bucket_at,
+                       COALESCE(o.event_type, '') AS channel,
+                       h.status::TEXT AS status,
+                       COUNT(*) AS messages,
+                       0::NUMERIC AS credit_spent
+                FROM message_status_history h
+                    JOIN messages m ON m.id = h.message_id
+                    LEFT JOIN outboxes o ON o.message_id = m.id
+                WHERE h.status <> 'sending'
+                GROUP BY 1, 2, 3, 4
+
+                UNION ALL
+
+                SELECT m.tenant_id,
+                       date_trunc('hour', t.created_at),
+                       COALESCE(o.event_type, ''),
+                       CASE
+                           WHEN t.type = 'charge' THEN 'queued'
+                           WHEN m.status = 'canceled' THEN 'canceled'
+                           ELSE 'failed'
+                       END,
+                       0,
+                       SUM(CASE WHEN t.type = 'charge' THEN t.amount ELSE -t.amount END)
+                FROM credit_transactions t
+                    JOIN messages m ON m.message_hash = t.message_hash_id
+                    LEFT JOIN outboxes o ON o.message_id = m.id
+                WHERE t.type IN ('charge', 'refund')
+                GROUP BY 1, 2, 3, 4
+            )
+            INSERT INTO usage_rollups (tenant_id, bucket_at, channel, status, messages, credit_spent)
+            SELECT tenant_id, bucket_at, channel, status, SUM(messages), SUM(credit_spent)
+            FROM changes
+            GROUP BY tenant_id, bucket_at, channel, status
+            ON CONFLICT DO NOTHING;
+        END IF;
+
+        INSERT INTO schema_backfills (name) VALUES ('usage_rollups');
+END$$;
+
+-- +migrate Down
@@ -0,0 +1,44 @@
+-- +migrate Up
+-- the numbers were stored as typed, the local and international forms of the Iranian mobiles are rewritten in E.164.
+-- the other numbers are kept, the new ones are normalized by the api
+CREATE OR REPLACE FUNCTION pg_temp.e164_ir_mobile(mobile TEXT) RETURNS TEXT AS $$
+    SELECT CASE
+        WHEN digits ~ '^(0098|98|0)?9\d{9}$' THEN '+98' || right(digits, 10)
+        ELSE mobile
+    END
+    FROM (
+        SELECT regexp_replace(translate(mobile, '۰۱۲۳۴۵۶۷۸۹٠١٢٣٤٥٦٧٨٩', '01234567890123456789'), '\D', '', 'g') AS digits
+    ) AS cleaned
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- the rewrite scans the tables, it runs once
+DO $$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM schema_backfills WHERE name = 'normalize_mobile_numbers') THEN
+            RETURN;
+        END IF;
+
+        UPDATE messages SET mobile = pg_temp.e164_ir_mobile(mobile)
+        WHERE mobile NOT LIKE '+%' AND mobile <> pg_temp.e164_ir_mobile(mobile);
+
+        UPDATE inbound_messages SET mobile = pg_temp.e164_ir_mobile(mobile)
+        WHERE mobile NOT LIKE '+%' AND mobile <> pg_temp.e164_ir_mobile(mobile);
+
+        -- the different forms of a blocked number are merged into the oldest entry
+        UPDATE blocked_numbers b SET deleted_at = CURRENT_TIMESTAMP
+        FROM (
+            SELECT id, row_number() OVER (
+                PARTITION BY COALESCE(tenant_id, 0), pg_temp.e164_ir_mobile(mobile) ORDER BY id
+            ) AS position
+            FROM blocked_numbers
+            WHERE deleted_at IS NULL
+        ) AS d
+        WHERE b.id = d.id AND d.position > 1;
+
+        UPDATE blocked_numbers SET mobile = pg_temp.e164_ir_mobile(mobile)
+        WHERE deleted_at IS NULL AND mobile NOT LIKE '+%' AND mobile <> pg_temp.e164_ir_mobile(mobile);
+
+        INSERT INTO schema_backfills (name) VALUES ('normalize_mobile_numbers');
+END$$;
+
+-- +migrate Down
@@ -0,0 +1,44 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+-- +migrate Up
+-- the price of a single SMS segment per recipient operator and channel
+CREATE TABLE IF NOT EXISTS rate_cards (
+    id          SERIAL PRIMARY KEY,
+    uuid        UUID DEFAULT uuid_generate_v4() NOT NULL,
+    operator    VARCHAR(16) NOT NULL,
+    channel     VARCHAR(32) NOT NULL,
+    price       NUMERIC(20, 4) NOT NULL CHECK (price >= 0),
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rate_cards_operator_channel ON rate_cards (operator, channel) WHERE deleted_at IS NULL;
+
+-- the domestic operators keep the former flat price. the cards are seeded once on the empty table, the later changes
+-- and deletions are left to the operators
+DO $$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM schema_backfills WHERE name = 'rate_cards') THEN
+            RETURN;
+        END IF;
+
+        IF NOT EXISTS (SELECT 1 FROM rate_cards) THEN
+            INSERT INTO rate_cards (operator, channel, price)
+            VALUES ('mci', 'event.prod', 8.9),
+                   ('mci', 'event.express', 8.9),
+                   ('irancell', 'event.prod', 8.9),
+                   ('irancell', 'event.express', 8.9),
+                   ('rightel', 'event.prod', 8.9),
+                   ('rightel', 'event.express', 8.9),
+                   ('international', 'event.prod', 45),
+                   ('international', 'event.express', 45);
+        END IF;
+
+        INSERT INTO schema_backfills (name) VALUES ('rate_cards');
+END$$;
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS operator VARCHAR(16) NULL;
+ALTER TABLE credit_transactions ADD COLUMN IF NOT EXISTS operator VARCHAR(16) NULL;
+
+-- +migrate Down
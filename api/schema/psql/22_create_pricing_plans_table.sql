@@ -0,0 +1,43 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'pricing_plan_type') THEN
+            CREATE TYPE pricing_plan_type AS ENUM ('standard','tiered','custom');
+        END IF;
+END$$;
+
+-- +migrate Up
+-- the discounts on the rate cards, assignable to the tenants. the tenants without plan pay the rate card prices
+CREATE TABLE IF NOT EXISTS pricing_plans (
+    id          SERIAL PRIMARY KEY,
+    uuid        UUID DEFAULT uuid_generate_v4() NOT NULL,
+    name        VARCHAR(64) NOT NULL,
+    type        pricing_plan_type NOT NULL DEFAULT 'standard',
+    discount    NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (discount >= 0 AND discount <= 100), -- the percent of the custom contract
+    description VARCHAR(255) NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pricing_plans_name ON pricing_plans (name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_pricing_plans_uuid ON pricing_plans (uuid);
+
+-- the volume tiers of the tiered plans, the tier of the highest reached monthly volume applies
+CREATE TABLE IF NOT EXISTS pricing_plan_tiers (
+    id              SERIAL PRIMARY KEY,
+    pricing_plan_id INTEGER NOT NULL,
+    min_volume      BIGINT NOT NULL CHECK (min_volume >= 0),
+    discount        NUMERIC(5, 2) NOT NULL CHECK (discount >= 0 AND discount <= 100),
+    FOREIGN KEY (pricing_plan_id) REFERENCES pricing_plans(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pricing_plan_tiers_volume ON pricing_plan_tiers (pricing_plan_id, min_volume);
+
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS pricing_plan_id INTEGER NULL REFERENCES pricing_plans(id) ON DELETE NO ACTION;
+
+-- the price of a single segment after the discount, kept to never reprice the history
+ALTER TABLE credit_transactions ADD COLUMN IF NOT EXISTS unit_price NUMERIC(20, 4) NULL;
+
+-- +migrate Down
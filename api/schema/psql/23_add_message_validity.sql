@@ -0,0 +1,8 @@
+-- +migrate Up
+-- the messages not sent until their validity end are expired without calling the provider and refunded
+ALTER TYPE message_status ADD VALUE IF NOT EXISTS 'expired';
+ALTER TYPE outbox_status ADD VALUE IF NOT EXISTS 'expired';
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS valid_until TIMESTAMP NULL;
+
+-- +migrate Down
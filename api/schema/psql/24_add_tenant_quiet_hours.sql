@@ -0,0 +1,7 @@
+-- +migrate Up
+-- the event.prod messages arriving in the quiet hours are held until the window opens, NULL uses the service default
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS quiet_hours_start VARCHAR(5) NULL;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS quiet_hours_end VARCHAR(5) NULL;
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS quiet_hours_timezone VARCHAR(64) NULL;
+
+-- +migrate Down
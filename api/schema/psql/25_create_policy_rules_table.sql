@@ -0,0 +1,42 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'policy_rule_type') THEN
+            CREATE TYPE policy_rule_type AS ENUM ('keyword','regex','domain_allowlist','max_links');
+        END IF;
+
+        IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'policy_action') THEN
+            CREATE TYPE policy_action AS ENUM ('reject','hold','flag');
+        END IF;
+END$$;
+
+-- +migrate Up
+-- the content rules evaluated on sending, the null tenant_id is the global rule applied to all tenants
+CREATE TABLE IF NOT EXISTS policy_rules (
+    id          SERIAL PRIMARY KEY,
+    uuid        UUID DEFAULT uuid_generate_v4() NOT NULL,
+    tenant_id   INTEGER NULL,
+    type        policy_rule_type NOT NULL,
+    value       VARCHAR(1024) NOT NULL, -- the keyword, the pattern, the comma separated domains or the max link count
+    action      policy_action NOT NULL DEFAULT 'reject',
+    description VARCHAR(255) NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at  TIMESTAMP NULL,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_policy_rules_tenant ON policy_rules (tenant_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_policy_rules_uuid ON policy_rules (uuid);
+
+-- the held messages wait for the admin review, the rejected ones are refunded
+ALTER TYPE message_status ADD VALUE IF NOT EXISTS 'held';
+ALTER TYPE message_status ADD VALUE IF NOT EXISTS 'rejected';
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS policy_action policy_action NULL;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS policy_reason VARCHAR(255) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_messages_policy_action ON messages (policy_action) WHERE policy_action IS NOT NULL;
+
+-- +migrate Down
@@ -0,0 +1,32 @@
+-- +migrate Up
+-- the links of the tracked messages replaced by the short codes, served by the redirect endpoint
+CREATE TABLE IF NOT EXISTS short_links (
+    id         SERIAL PRIMARY KEY,
+    code       VARCHAR(16) NOT NULL UNIQUE,
+    tenant_id  INTEGER NOT NULL,
+    message_id INTEGER NOT NULL,
+    target_url TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION,
+    FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_short_links_message ON short_links (message_id);
+
+-- every click of the short links, the message_id is kept to count the clicks of the message
+CREATE TABLE IF NOT EXISTS link_clicks (
+    id            BIGSERIAL PRIMARY KEY,
+    short_link_id INTEGER NOT NULL,
+    message_id    INTEGER NOT NULL,
+    clicked_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (short_link_id) REFERENCES short_links(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_clicks_message ON link_clicks (message_id);
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS track_links BOOLEAN NOT NULL DEFAULT false;
+
+-- the clicks are rolled up along with the messages, in the buckets of the clicked status
+ALTER TABLE usage_rollups ADD COLUMN IF NOT EXISTS clicks BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
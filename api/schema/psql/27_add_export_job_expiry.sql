@@ -0,0 +1,7 @@
+-- +migrate Up
+-- the files of the completed exports are removed after their ttl
+ALTER TYPE export_status ADD VALUE IF NOT EXISTS 'expired';
+
+CREATE INDEX IF NOT EXISTS idx_export_jobs_status_finished ON export_jobs (status, finished_at);
+
+-- +migrate Down
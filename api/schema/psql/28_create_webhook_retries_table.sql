@@ -0,0 +1,15 @@
+-- +migrate Up
+-- the failed webhook events waiting for their backoff, released back to the webhook topic when due
+CREATE TABLE IF NOT EXISTS webhook_retries (
+    id              SERIAL PRIMARY KEY,
+    event_uuid      UUID NOT NULL,
+    tenant_id       INTEGER NOT NULL,
+    payload         JSONB NOT NULL,
+    next_attempt_at TIMESTAMP NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE NO ACTION
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_retries_next_attempt ON webhook_retries (next_attempt_at);
+
+-- +migrate Down